@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1alpha1 contains the API types of the polymorphic cross-resource
+// references, which can target one of several kinds of managed resources.
+package v1alpha1
+
+import (
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+)
+
+// A PolymorphicReference to a named object of one of several kinds.
+//
+// +kubebuilder:object:generate=true
+type PolymorphicReference struct {
+	// Kind of the referenced object. If not set, all the candidate kinds
+	// are tried and exactly one of them must resolve.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// Policies for referencing.
+	// +optional
+	Policy *xpv2.Policy `json:"policy,omitempty"`
+}
+
+// A NamespacedPolymorphicReference to a named object of one of several kinds.
+//
+// +kubebuilder:object:generate=true
+type NamespacedPolymorphicReference struct {
+	// Kind of the referenced object. If not set, all the candidate kinds
+	// are tried and exactly one of them must resolve.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// Namespace of the referenced object
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Policies for referencing.
+	// +optional
+	Policy *xpv2.Policy `json:"policy,omitempty"`
+}
+
+// A PolymorphicSelector selects an object of one of several kinds.
+//
+// +kubebuilder:object:generate=true
+type PolymorphicSelector struct {
+	// Kind of the selected object. If not set, all the candidate kinds
+	// are tried and exactly one of them must resolve.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// MatchLabels ensures an object with matching labels is selected.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchControllerRef ensures an object with the same controller reference
+	// as the selecting object is selected.
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
+
+	// Policies for selection.
+	// +optional
+	Policy *xpv2.Policy `json:"policy,omitempty"`
+}
+
+// A NamespacedPolymorphicSelector selects a namespaced object of one of
+// several kinds.
+//
+// +kubebuilder:object:generate=true
+type NamespacedPolymorphicSelector struct {
+	// Kind of the selected object. If not set, all the candidate kinds
+	// are tried and exactly one of them must resolve.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// MatchLabels ensures an object with matching labels is selected.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchControllerRef ensures an object with the same controller reference
+	// as the selecting object is selected.
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
+
+	// Policies for selection.
+	// +optional
+	Policy *xpv2.Policy `json:"policy,omitempty"`
+
+	// Namespace for the selector
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1alpha1 contains the API types of the value sources of the spec
+// fields, which are resolved from other Kubernetes objects before the
+// Terraform configuration of a managed resource is built.
+package v1alpha1
+
+// A ValueFromSource is the source of the value of a spec field. Exactly one
+// of the sources must be set.
+//
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="(has(self.configMapKeyRef) ? 1 : 0) + (has(self.secretKeyRef) ? 1 : 0) + (has(self.fieldRef) ? 1 : 0) == 1",message="exactly one of configMapKeyRef, secretKeyRef and fieldRef must be set"
+type ValueFromSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap.
+	// +optional
+	ConfigMapKeyRef *KeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef selects a key of a Secret. It's rejected for the
+	// arguments reported in the status of the managed resource, as the
+	// value is not treated as sensitive.
+	// +optional
+	SecretKeyRef *KeySelector `json:"secretKeyRef,omitempty"`
+
+	// FieldRef selects a field of an arbitrary object, such as a field in the
+	// status of another managed resource.
+	// +optional
+	FieldRef *ObjectFieldSelector `json:"fieldRef,omitempty"`
+}
+
+// A KeySelector selects a key of a ConfigMap or a Secret.
+//
+// +kubebuilder:object:generate=true
+type KeySelector struct {
+	// Name of the ConfigMap or the Secret.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap or the Secret. Defaults to the namespace of
+	// the managed resource and must be set for a cluster scoped managed
+	// resource. A namespaced managed resource can only select the objects in
+	// its own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Key whose value is selected.
+	Key string `json:"key"`
+}
+
+// An ObjectFieldSelector selects a field of an object.
+//
+// +kubebuilder:object:generate=true
+type ObjectFieldSelector struct {
+	// APIVersion of the object, e.g., ec2.aws.upbound.io/v1beta1.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object, e.g., VPC.
+	Kind string `json:"kind"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// Namespace of the object. Defaults to the namespace of the managed
+	// resource. A namespaced managed resource can only select the objects in
+	// its own namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// FieldPath of the selected field, e.g., status.atProvider.id.
+	FieldPath string `json:"fieldPath"`
+}
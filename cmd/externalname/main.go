@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin/v2"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+var (
+	app = kingpin.New(filepath.Base(os.Args[0]), "External name configuration suggester for the resources of a provider").DefaultEnvars()
+)
+
+var (
+	schemaFile   = app.Flag("schema", "The Terraform provider schema JSON file, e.g., as generated by `terraform providers schema -json`").Short('s').Required().ExistingFile()
+	metadataFile = app.Flag("metadata", "The provider metadata file generated by the scraper").Short('m').Default("./config/provider-metadata.yaml").ExistingFile()
+	prefix       = app.Flag("prefix", `The Terraform resource name prefix of the provider, e.g., "aws"`).Short('p').Required().String()
+	out          = app.Flag("out", "Filename for the JSON report. The human-readable report is printed to the standard output if not set").Short('o').String()
+)
+
+// main is the entry point for the externalname tool. It derives the external
+// name configurations of the resources of a provider from the shapes of the
+// IDs in their scraped Terraform import statements, and reports them with
+// their confidence levels.
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+	schema, err := os.ReadFile(filepath.Clean(*schemaFile))
+	kingpin.FatalIfError(err, "cannot read the Terraform provider schema")
+	metadata, err := os.ReadFile(filepath.Clean(*metadataFile))
+	kingpin.FatalIfError(err, "cannot read the provider metadata")
+
+	report := config.NewProvider(schema, *prefix, "", metadata).SuggestExternalNames()
+	if *out == "" {
+		fmt.Print(report.String())
+		return
+	}
+	b, err := json.MarshalIndent(report, "", "  ")
+	kingpin.FatalIfError(err, "cannot marshal the report")
+	kingpin.FatalIfError(os.WriteFile(*out, b, 0o600), "cannot write the report")
+}
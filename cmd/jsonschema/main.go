@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/schema/jsonschema"
+)
+
+var (
+	app = kingpin.New(filepath.Base(os.Args[0]), "JSON Schema bundle generator for the CRDs of a provider").DefaultEnvars()
+)
+
+var (
+	crdDir    = app.Flag("crd-dir", "The directory of the generated CRDs").Short('i').Default("./package/crds").ExistingDir()
+	out       = app.Flag("out", "The directory to write the JSON Schema bundle to").Short('o').Default("./package/jsonschema").String()
+	rootGroup = app.Flag("root-group", "The root API group of the provider the $id URLs of the schemas are derived from, e.g., aws.upbound.io").Required().String()
+)
+
+// main is the entry point for the jsonschema tool. It converts the schemas of
+// the served versions of the CRDs in the specified directory into one JSON
+// Schema file per group, version and kind and writes them together with a
+// catalog file into the output directory.
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+	fs := afero.NewOsFs()
+
+	crds, err := jsonschema.ReadCRDs(fs, *crdDir)
+	kingpin.FatalIfError(err, "cannot read the CRDs")
+
+	var schemas []*jsonschema.Schema
+	for _, crd := range crds {
+		s, err := jsonschema.FromCRD(crd, *rootGroup)
+		kingpin.FatalIfError(err, "cannot convert the CRD %s", crd.Name)
+		schemas = append(schemas, s...)
+	}
+	kingpin.FatalIfError(jsonschema.WriteBundle(fs, *out, schemas), "cannot write the JSON Schema bundle")
+}
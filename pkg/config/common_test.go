@@ -154,6 +154,132 @@ func TestDefaultResource(t *testing.T) {
 	}
 }
 
+func TestDefaultDataSource(t *testing.T) {
+	identityConversion := conversion.NewIdentityConversionExpandPaths(conversion.AllVersions, conversion.AllVersions, nil)
+
+	type args struct {
+		name string
+		opts []ResourceOption
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *Resource
+	}{
+		"ThreeSectionsName": {
+			reason: "It should suffix the kind and read the data source synchronously with an external name from the provider",
+			args: args{
+				name: "aws_ec2_instance_type",
+			},
+			want: &Resource{
+				Name:                           "aws_ec2_instance_type",
+				ShortGroup:                     "ec2",
+				Kind:                           "InstanceTypeDataSource",
+				Version:                        "v1alpha1",
+				ExternalName:                   IdentifierFromProvider,
+				References:                     map[string]Reference{},
+				Sensitive:                      NopSensitive,
+				SchemaElementOptions:           SchemaElementOptions{},
+				ServerSideApplyMergeStrategies: ServerSideApplyMergeStrategies{},
+				Conversions:                    []conversion.Conversion{identityConversion},
+				OverrideFieldNames:             map[string]string{},
+			},
+		},
+		"WithOptions": {
+			reason: "Resource options should be applied after the data source defaults",
+			args: args{
+				name: "aws_ami",
+				opts: []ResourceOption{
+					func(r *Resource) {
+						r.Version = "v1beta1"
+					},
+				},
+			},
+			want: &Resource{
+				Name:                           "aws_ami",
+				ShortGroup:                     "aws",
+				Kind:                           "AMIDataSource",
+				Version:                        "v1beta1",
+				ExternalName:                   IdentifierFromProvider,
+				References:                     map[string]Reference{},
+				Sensitive:                      NopSensitive,
+				SchemaElementOptions:           SchemaElementOptions{},
+				ServerSideApplyMergeStrategies: ServerSideApplyMergeStrategies{},
+				Conversions:                    []conversion.Conversion{identityConversion},
+				OverrideFieldNames:             map[string]string{},
+			},
+		},
+	}
+
+	ignoreUnexported := []cmp.Option{
+		cmpopts.IgnoreFields(Sensitive{}, "fieldPaths", "AdditionalConnectionDetailsFn"),
+		cmpopts.IgnoreFields(LateInitializer{}, "ignoredCanonicalFieldPaths", "conditionalIgnoredCanonicalFieldPaths"),
+		cmpopts.IgnoreFields(ExternalName{}, "SetIdentifierArgumentFn", "GetExternalNameFn", "GetIDFn"),
+		cmpopts.IgnoreUnexported(Resource{}),
+		cmpopts.IgnoreUnexported(reflect.ValueOf(identityConversion).Elem().Interface()),
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ds := DefaultDataSource(tc.args.name, nil, nil, tc.args.opts...)
+			if diff := cmp.Diff(tc.want, ds.Resource, ignoreUnexported...); diff != "" {
+				t.Errorf("\n%s\nDefaultDataSource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if !ds.IsDataSource() {
+				t.Errorf("\n%s\nDefaultDataSource(...): IsDataSource() should be true", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDefaultEphemeralResource(t *testing.T) {
+	sch := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"role_arn": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"duration": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"session_token": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+	er := DefaultEphemeralResource("aws_sts_session", sch, nil)
+	if diff := cmp.Diff("SessionEphemeralResource", er.Kind); diff != "" {
+		t.Errorf("DefaultEphemeralResource(...): -want kind, +got kind:\n%s", diff)
+	}
+	if !er.IsEphemeralResource() {
+		t.Error("DefaultEphemeralResource(...): IsEphemeralResource() should be true")
+	}
+	if !er.ShouldUseTerraformPluginFrameworkClient() {
+		t.Error("DefaultEphemeralResource(...): ShouldUseTerraformPluginFrameworkClient() should be true")
+	}
+	want := map[string]bool{
+		"role_arn":      false,
+		"duration":      false,
+		"session_token": true,
+	}
+	got := map[string]bool{}
+	for n, s := range er.TerraformResource.Schema {
+		got[n] = s.Sensitive
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DefaultEphemeralResource(...): -want sensitive attributes, +got sensitive attributes:\n%s", diff)
+	}
+	for n, s := range sch.Schema {
+		if s.Sensitive {
+			t.Errorf("DefaultEphemeralResource(...): the given schema should not be modified but its attribute %q has been marked as sensitive", n)
+		}
+	}
+}
+
 func TestMoveToStatus(t *testing.T) {
 	type args struct {
 		sch    *schema.Resource
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The type names of the Terraform Plugin Framework validators from the
+// terraform-plugin-framework-validators module that are equivalent to the
+// ConflictsWith, ExactlyOneOf, AtLeastOneOf & RequiredWith constraints of the
+// Terraform Plugin SDK. The validators are matched by their type names and
+// their PathExpressions fields so that we do not depend on the module.
+const (
+	// attribute validators, e.g., stringvalidator.ConflictsWith
+	fwConflictsWithValidator = "ConflictsWithValidator"
+	fwExactlyOneOfValidator  = "ExactlyOneOfValidator"
+	fwAtLeastOneOfValidator  = "AtLeastOneOfValidator"
+	fwAlsoRequiresValidator  = "AlsoRequiresValidator"
+	// resource validators, e.g., resourcevalidator.Conflicting
+	fwConflictingValidator      = "ConflictingValidator"
+	fwRequiredTogetherValidator = "RequiredTogetherValidator"
+)
+
+// injectFrameworkConstraints translates the Terraform Plugin Framework
+// validators of the specified resource that are equivalent to the
+// ConflictsWith, ExactlyOneOf, AtLeastOneOf & RequiredWith constraints of the
+// Terraform Plugin SDK into the Terraform schema of the resource, so that
+// they are also translated into the CEL validation rules of the generated
+// CRD.
+func injectFrameworkConstraints(name string, r *Resource) error {
+	if r.TerraformPluginFrameworkResource == nil || r.TerraformResource == nil {
+		return nil
+	}
+	s, err := frameworkResourceSchema(name, r.TerraformPluginFrameworkResource)
+	if err != nil {
+		return err
+	}
+	c := &frameworkConstraints{res: r.TerraformResource}
+	walkFrameworkSchema(s.Attributes, s.Blocks, fwpath.Expression{}, c.attributeValidators)
+	if rv, ok := r.TerraformPluginFrameworkResource.(fwresource.ResourceWithConfigValidators); ok {
+		for _, v := range rv.ConfigValidators(context.TODO()) {
+			c.resourceValidator(v)
+		}
+	}
+	return nil
+}
+
+// frameworkConstraints injects the constraints collected from a Terraform
+// Plugin Framework resource schema into the corresponding Terraform Plugin
+// SDK schema.
+type frameworkConstraints struct {
+	res *schema.Resource
+}
+
+// attributeValidators injects the constraints of the validators of the
+// attribute or block at the expression e.
+func (c *frameworkConstraints) attributeValidators(e fwpath.Expression, attr any) {
+	for _, v := range frameworkValidators(attr) {
+		kind, exprs, ok := pathExpressionsValidator(v)
+		if !ok {
+			continue
+		}
+		others := make([]string, 0, len(exprs))
+		for _, o := range e.MergeExpressions(exprs...) {
+			others = append(others, sdkSchemaKey(o))
+		}
+		self := sdkSchemaKey(e)
+		switch kind {
+		case fwConflictsWithValidator:
+			c.add(self, func(s *schema.Schema) *[]string { return &s.ConflictsWith }, others...)
+		case fwAlsoRequiresValidator:
+			c.add(self, func(s *schema.Schema) *[]string { return &s.RequiredWith }, others...)
+		case fwExactlyOneOfValidator:
+			c.add(self, func(s *schema.Schema) *[]string { return &s.ExactlyOneOf }, append([]string{self}, others...)...)
+		case fwAtLeastOneOfValidator:
+			c.add(self, func(s *schema.Schema) *[]string { return &s.AtLeastOneOf }, append([]string{self}, others...)...)
+		}
+	}
+}
+
+// resourceValidator injects the constraints of the specified resource-level
+// validator into the schemas of all the attributes it refers to.
+func (c *frameworkConstraints) resourceValidator(v fwresource.ConfigValidator) {
+	kind, exprs, ok := pathExpressionsValidator(reflect.ValueOf(v))
+	if !ok {
+		return
+	}
+	keys := make([]string, 0, len(exprs))
+	for _, e := range exprs {
+		keys = append(keys, sdkSchemaKey(e))
+	}
+	for _, k := range keys {
+		others := slices.DeleteFunc(slices.Clone(keys), func(o string) bool { return o == k })
+		switch kind {
+		case fwConflictingValidator:
+			c.add(k, func(s *schema.Schema) *[]string { return &s.ConflictsWith }, others...)
+		case fwRequiredTogetherValidator:
+			c.add(k, func(s *schema.Schema) *[]string { return &s.RequiredWith }, others...)
+		case fwExactlyOneOfValidator:
+			c.add(k, func(s *schema.Schema) *[]string { return &s.ExactlyOneOf }, keys...)
+		case fwAtLeastOneOfValidator:
+			c.add(k, func(s *schema.Schema) *[]string { return &s.AtLeastOneOf }, keys...)
+		}
+	}
+}
+
+// add appends the specified keys to the constraint of the schema at the key
+// self, which is selected by the constraint function, skipping the keys
+// already in the constraint.
+func (c *frameworkConstraints) add(self string, constraint func(s *schema.Schema) *[]string, keys ...string) {
+	s := GetSchema(c.res, schemaFieldPath(self))
+	if s == nil {
+		return
+	}
+	l := constraint(s)
+	for _, k := range keys {
+		if k != "" && !slices.Contains(*l, k) {
+			*l = append(*l, k)
+		}
+	}
+}
+
+// pathExpressionsValidator returns the kind & the path expressions of the
+// specified validator if it's one of the known validators with path
+// expressions.
+func pathExpressionsValidator(v reflect.Value) (string, fwpath.Expressions, bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return "", nil, false
+	}
+	switch v.Type().Name() {
+	case fwConflictsWithValidator, fwExactlyOneOfValidator, fwAtLeastOneOfValidator, fwAlsoRequiresValidator,
+		fwConflictingValidator, fwRequiredTogetherValidator:
+	default:
+		return "", nil, false
+	}
+	f := v.FieldByName("PathExpressions")
+	if !f.IsValid() || !f.CanInterface() {
+		return "", nil, false
+	}
+	exprs, ok := f.Interface().(fwpath.Expressions)
+	return v.Type().Name(), exprs, ok
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The validators below mimic the validators from the
+// terraform-plugin-framework-validators module.
+
+type ConflictsWithValidator struct {
+	PathExpressions fwpath.Expressions
+}
+
+func (ConflictsWithValidator) Description(context.Context) string         { return "" }
+func (ConflictsWithValidator) MarkdownDescription(context.Context) string { return "" }
+func (ConflictsWithValidator) ValidateString(context.Context, validator.StringRequest, *validator.StringResponse) {
+}
+
+type ExactlyOneOfValidator struct {
+	PathExpressions fwpath.Expressions
+}
+
+func (ExactlyOneOfValidator) Description(context.Context) string         { return "" }
+func (ExactlyOneOfValidator) MarkdownDescription(context.Context) string { return "" }
+func (ExactlyOneOfValidator) ValidateString(context.Context, validator.StringRequest, *validator.StringResponse) {
+}
+
+type RequiredTogetherValidator struct {
+	PathExpressions fwpath.Expressions
+}
+
+func (RequiredTogetherValidator) Description(context.Context) string         { return "" }
+func (RequiredTogetherValidator) MarkdownDescription(context.Context) string { return "" }
+func (RequiredTogetherValidator) ValidateResource(context.Context, fwresource.ValidateConfigRequest, *fwresource.ValidateConfigResponse) {
+}
+
+type constraintsTestResource struct {
+	fwresource.Resource
+}
+
+func (constraintsTestResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"a": rschema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					ConflictsWithValidator{PathExpressions: fwpath.Expressions{fwpath.MatchRelative().AtParent().AtName("b")}},
+				},
+			},
+			"b": rschema.StringAttribute{Optional: true},
+			"c": rschema.StringAttribute{Optional: true},
+			"d": rschema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]rschema.Block{
+			"block": rschema.ListNestedBlock{
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{
+						"x": rschema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								ExactlyOneOfValidator{PathExpressions: fwpath.Expressions{fwpath.MatchRelative().AtParent().AtName("y")}},
+							},
+						},
+						"y": rschema.StringAttribute{Optional: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (constraintsTestResource) ConfigValidators(context.Context) []fwresource.ConfigValidator {
+	return []fwresource.ConfigValidator{
+		RequiredTogetherValidator{PathExpressions: fwpath.Expressions{fwpath.MatchRoot("c"), fwpath.MatchRoot("d")}},
+	}
+}
+
+func TestInjectFrameworkConstraints(t *testing.T) {
+	optional := func() *schema.Schema {
+		return &schema.Schema{Type: schema.TypeString, Optional: true}
+	}
+	r := &Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"a": optional(),
+				"b": optional(),
+				"c": optional(),
+				"d": optional(),
+				"block": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"x": optional(),
+							"y": optional(),
+						},
+					},
+				},
+			},
+		},
+		TerraformPluginFrameworkResource: constraintsTestResource{},
+	}
+	if err := injectFrameworkConstraints("test_resource", r); err != nil {
+		t.Fatalf("injectFrameworkConstraints(...): unexpected error: %v", err)
+	}
+
+	type constraints struct {
+		ConflictsWith, ExactlyOneOf, AtLeastOneOf, RequiredWith []string
+	}
+	want := map[string]constraints{
+		"a":       {ConflictsWith: []string{"b"}},
+		"b":       {},
+		"c":       {RequiredWith: []string{"d"}},
+		"d":       {RequiredWith: []string{"c"}},
+		"block.x": {ExactlyOneOf: []string{"block.0.x", "block.0.y"}},
+		"block.y": {},
+	}
+	got := make(map[string]constraints, len(want))
+	for k := range want {
+		s := GetSchema(r.TerraformResource, k)
+		got[k] = constraints{
+			ConflictsWith: s.ConflictsWith,
+			ExactlyOneOf:  s.ExactlyOneOf,
+			AtLeastOneOf:  s.AtLeastOneOf,
+			RequiredWith:  s.RequiredWith,
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("injectFrameworkConstraints(...): -want constraints, +got constraints:\n%s", diff)
+	}
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// kindSuffixDataSource is appended to the default kind of the managed
+	// resources generated for Terraform data sources so that they do not
+	// collide with the managed resources generated for the Terraform
+	// resources of the same name, e.g., aws_ami.
+	kindSuffixDataSource = "DataSource"
+)
+
+// DataSource is the configuration of a Terraform data source to be
+// generated as an observe-only managed resource. The Terraform data source
+// arguments are exposed under spec.forProvider and the attributes read from
+// the data source are reported under status.atProvider. Its controller only
+// reads the data source and never creates, updates or deletes an external
+// resource.
+type DataSource struct {
+	// Resource holds the code generation & controller configuration of the
+	// data source. Its TerraformResource field holds the Terraform Plugin
+	// SDKv2 schema of the data source.
+	*Resource
+
+	// TerraformPluginFrameworkDataSource is the Terraform Plugin Framework
+	// implementation of the data source. It's only set if the data source
+	// is configured to be read via the Terraform Plugin Framework.
+	TerraformPluginFrameworkDataSource fwdatasource.DataSource
+}
+
+// DefaultDataSource keeps an initial default configuration for all data
+// sources of a provider. The group is derived from the data source name the
+// same way as for resources, and the kind is suffixed with "DataSource".
+// As data sources are only read, they get their external names from the
+// provider and are reconciled synchronously.
+func DefaultDataSource(name string, terraformSchema *schema.Resource, terraformPluginFrameworkDataSource fwdatasource.DataSource, opts ...ResourceOption) *DataSource {
+	r := DefaultResource(name, terraformSchema, nil, nil)
+	r.Kind += kindSuffixDataSource
+	r.ExternalName = IdentifierFromProvider
+	r.UseAsync = false
+	r.dataSource = true
+	for _, f := range opts {
+		f(r)
+	}
+	return &DataSource{
+		Resource:                           r,
+		TerraformPluginFrameworkDataSource: terraformPluginFrameworkDataSource,
+	}
+}
@@ -0,0 +1,383 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ResourceConfigurationAPIVersion is the API version of the declarative
+	// resource configuration files supported by this version of upjet.
+	ResourceConfigurationAPIVersion = "upjet.crossplane.io/v1alpha1"
+	// ResourceConfigurationKind is the kind of the declarative resource
+	// configuration files.
+	ResourceConfigurationKind = "ResourceConfiguration"
+)
+
+// External name configurations that can be referred to by their names in
+// declarative resource configurations.
+const (
+	ExternalNameTypeNameAsIdentifier            = "NameAsIdentifier"
+	ExternalNameTypeIdentifierFromProvider      = "IdentifierFromProvider"
+	ExternalNameTypeParameterAsIdentifier       = "ParameterAsIdentifier"
+	ExternalNameTypeTemplatedStringAsIdentifier = "TemplatedStringAsIdentifier"
+)
+
+// ResourceConfigurationFile is a versioned, declarative alternative to the
+// ResourceConfiguratorFns for the settings that do not need Go code, such as
+// the group, kind, external name and references of the resources. It's read
+// from a YAML or JSON file, e.g.:
+//
+//	apiVersion: upjet.crossplane.io/v1alpha1
+//	kind: ResourceConfiguration
+//	resources:
+//	  aws_subnet:
+//	    shortGroup: ec2
+//	    kind: Subnet
+//	    externalName:
+//	      type: IdentifierFromProvider
+//	    references:
+//	      vpc_id:
+//	        terraformName: aws_vpc
+//
+// The declarative configurations are registered as regular resource
+// configurators, so Go configurators added after them can still override
+// any setting or configure what cannot be expressed declaratively.
+type ResourceConfigurationFile struct {
+	// APIVersion is the version of the configuration file format.
+	APIVersion string `json:"apiVersion"`
+	// Kind must be ResourceConfigurationKind.
+	Kind string `json:"kind"`
+	// Resources are the configurations of the Terraform resources keyed by
+	// their Terraform names.
+	Resources map[string]DeclarativeResource `json:"resources,omitempty"`
+	// DataSources are the configurations of the Terraform data sources
+	// keyed by their Terraform names.
+	DataSources map[string]DeclarativeResource `json:"dataSources,omitempty"`
+	// EphemeralResources are the configurations of the Terraform ephemeral
+	// resources keyed by their Terraform names.
+	EphemeralResources map[string]DeclarativeResource `json:"ephemeralResources,omitempty"`
+}
+
+// DeclarativeResource is the declarative configuration of a single resource.
+// Unset fields leave the corresponding Resource settings untouched.
+type DeclarativeResource struct {
+	// ShortGroup overrides Resource.ShortGroup.
+	ShortGroup *string `json:"shortGroup,omitempty"`
+	// Kind overrides Resource.Kind.
+	Kind *string `json:"kind,omitempty"`
+	// Version overrides Resource.Version.
+	Version *string `json:"version,omitempty"`
+	// UseAsync overrides Resource.UseAsync.
+	UseAsync *bool `json:"useAsync,omitempty"`
+	// ExternalName overrides Resource.ExternalName.
+	ExternalName *DeclarativeExternalName `json:"externalName,omitempty"`
+	// References are added to Resource.References, keyed by the
+	// Terraform field paths of the referencing fields.
+	References map[string]DeclarativeReference `json:"references,omitempty"`
+	// LateInitializer overrides the non-empty Resource.LateInitializer
+	// field lists.
+	LateInitializer *DeclarativeLateInitializer `json:"lateInitializer,omitempty"`
+	// SensitiveFieldPaths are added to the sensitive field paths of the
+	// resource, keyed by their Terraform field paths and with the
+	// Crossplane field paths as values.
+	SensitiveFieldPaths map[string]string `json:"sensitiveFieldPaths,omitempty"`
+	// RequiredFields are the Terraform field paths of the fields to be
+	// marked as required.
+	RequiredFields []string `json:"requiredFields,omitempty"`
+	// SchemaElementOptions are the options of the schema elements keyed by
+	// their Terraform field paths.
+	SchemaElementOptions map[string]DeclarativeSchemaElementOption `json:"schemaElementOptions,omitempty"`
+}
+
+// DeclarativeExternalName is the declarative configuration of an external
+// name. Type selects one of the built-in external name configurations, and
+// the remaining fields customize it.
+type DeclarativeExternalName struct {
+	// Type is one of NameAsIdentifier, IdentifierFromProvider,
+	// ParameterAsIdentifier or TemplatedStringAsIdentifier.
+	Type string `json:"type"`
+	// Parameter is the identifier argument of ParameterAsIdentifier.
+	Parameter string `json:"parameter,omitempty"`
+	// NameFieldPath is the field path of the name argument of
+	// TemplatedStringAsIdentifier. It can be empty.
+	NameFieldPath string `json:"nameFieldPath,omitempty"`
+	// Template is the Terraform ID template of
+	// TemplatedStringAsIdentifier.
+	Template string `json:"template,omitempty"`
+	// OmittedFields overrides ExternalName.OmittedFields if set.
+	OmittedFields []string `json:"omittedFields,omitempty"`
+	// IdentifierFields overrides ExternalName.IdentifierFields if set.
+	IdentifierFields []string `json:"identifierFields,omitempty"`
+	// DisableNameInitializer overrides
+	// ExternalName.DisableNameInitializer if set.
+	DisableNameInitializer *bool `json:"disableNameInitializer,omitempty"`
+}
+
+// DeclarativeReference is the declarative configuration of a cross-resource
+// reference. It has the same fields as Reference except for the deprecated
+// Type.
+type DeclarativeReference struct {
+	TerraformName     string                        `json:"terraformName,omitempty"`
+	Extractor         string                        `json:"extractor,omitempty"`
+	ExtractorTemplate string                        `json:"extractorTemplate,omitempty"`
+	RefFieldName      string                        `json:"refFieldName,omitempty"`
+	SelectorFieldName string                        `json:"selectorFieldName,omitempty"`
+	Targets           []DeclarativeReferenceTarget  `json:"targets,omitempty"`
+	External          *DeclarativeExternalReference `json:"external,omitempty"`
+}
+
+// DeclarativeReferenceTarget is the declarative configuration of a candidate
+// target of a polymorphic cross-resource reference.
+type DeclarativeReferenceTarget struct {
+	TerraformName string `json:"terraformName"`
+	Extractor     string `json:"extractor,omitempty"`
+}
+
+// DeclarativeExternalReference is the declarative configuration of a
+// reference to a managed resource kind of another provider.
+type DeclarativeExternalReference struct {
+	Group     string `json:"group"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	FieldPath string `json:"fieldPath,omitempty"`
+}
+
+// DeclarativeLateInitializer is the declarative configuration of the
+// late-initialization behaviour.
+type DeclarativeLateInitializer struct {
+	IgnoredFields            []string `json:"ignoredFields,omitempty"`
+	ConditionalIgnoredFields []string `json:"conditionalIgnoredFields,omitempty"`
+}
+
+// DeclarativeSchemaElementOption is the declarative configuration of a
+// SchemaElementOption.
+type DeclarativeSchemaElementOption struct {
+	AddToObservation bool `json:"addToObservation,omitempty"`
+	EmbeddedObject   bool `json:"embeddedObject,omitempty"`
+	ValueFrom        bool `json:"valueFrom,omitempty"`
+}
+
+// LoadResourceConfigurationFile reads and validates the declarative resource
+// configuration file at the given path.
+func LoadResourceConfigurationFile(path string) (*ResourceConfigurationFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the path is supplied by the provider author
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read the resource configuration file %q", path)
+	}
+	f, err := ParseResourceConfigurationFile(data)
+	return f, errors.Wrapf(err, "cannot load the resource configuration file %q", path)
+}
+
+// ParseResourceConfigurationFile parses and validates the given YAML or JSON
+// declarative resource configuration. Unknown fields are rejected.
+func ParseResourceConfigurationFile(data []byte) (*ResourceConfigurationFile, error) {
+	f := &ResourceConfigurationFile{}
+	if err := yaml.UnmarshalStrict(data, f); err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal the resource configuration")
+	}
+	if f.APIVersion != ResourceConfigurationAPIVersion {
+		return nil, errors.Errorf("unsupported resource configuration apiVersion %q, expected %q", f.APIVersion, ResourceConfigurationAPIVersion)
+	}
+	if f.Kind != ResourceConfigurationKind {
+		return nil, errors.Errorf("unsupported resource configuration kind %q, expected %q", f.Kind, ResourceConfigurationKind)
+	}
+	for _, m := range []map[string]DeclarativeResource{f.Resources, f.DataSources, f.EphemeralResources} {
+		for _, name := range sortedNames(m) {
+			if err := m[name].validate(); err != nil {
+				return nil, errors.Wrapf(err, "invalid configuration for %q", name)
+			}
+		}
+	}
+	return f, nil
+}
+
+// AddToProvider registers the declarative configurations as resource
+// configurators of the given Provider. They are applied, in the order they
+// are registered together with the other configurators, when
+// Provider.ConfigureResources is called. It's an error to configure a
+// resource that's not generated by the Provider.
+func (f *ResourceConfigurationFile) AddToProvider(p *Provider) error {
+	for _, name := range sortedNames(f.Resources) {
+		if _, ok := p.Resources[name]; !ok {
+			return errors.Errorf("cannot configure resource %q: resource is not generated by the provider", name)
+		}
+		p.AddResourceConfigurator(name, f.Resources[name].Configure)
+	}
+	for _, name := range sortedNames(f.DataSources) {
+		if _, ok := p.DataSources[name]; !ok {
+			return errors.Errorf("cannot configure data source %q: data source is not generated by the provider", name)
+		}
+		p.AddDataSourceConfigurator(name, f.DataSources[name].Configure)
+	}
+	for _, name := range sortedNames(f.EphemeralResources) {
+		if _, ok := p.EphemeralResources[name]; !ok {
+			return errors.Errorf("cannot configure ephemeral resource %q: ephemeral resource is not generated by the provider", name)
+		}
+		p.AddEphemeralResourceConfigurator(name, f.EphemeralResources[name].Configure)
+	}
+	return nil
+}
+
+func (d DeclarativeResource) validate() error {
+	if d.ExternalName != nil {
+		if _, err := d.ExternalName.build(); err != nil {
+			return errors.Wrap(err, "invalid external name configuration")
+		}
+	}
+	for p, ref := range d.References {
+		if ref.ExtractorTemplate != "" {
+			if ref.Extractor != "" || len(ref.Targets) > 0 || (ref.External != nil && ref.External.FieldPath != "") {
+				return errors.Errorf("extractorTemplate of the reference at %q cannot be set together with extractor, targets or the fieldPath of external", p)
+			}
+			if _, err := ParseExtractorTemplate(ref.ExtractorTemplate); err != nil {
+				return errors.Wrapf(err, "invalid extractorTemplate of the reference at %q", p)
+			}
+		}
+		if ref.External != nil {
+			if ref.TerraformName != "" || len(ref.Targets) > 0 {
+				return errors.Errorf("terraformName, targets and external of the reference at %q are mutually exclusive", p)
+			}
+			if ref.External.Group == "" || ref.External.Version == "" || ref.External.Kind == "" {
+				return errors.Errorf("group, version and kind of the external reference at %q must be set", p)
+			}
+			continue
+		}
+		if len(ref.Targets) > 0 {
+			if ref.TerraformName != "" {
+				return errors.Errorf("terraformName and targets of the reference at %q are mutually exclusive", p)
+			}
+			for _, t := range ref.Targets {
+				if t.TerraformName == "" {
+					return errors.Errorf("terraformName of a target of the reference at %q is empty", p)
+				}
+			}
+			continue
+		}
+		if ref.TerraformName == "" {
+			return errors.Errorf("terraformName of the reference at %q is empty", p)
+		}
+	}
+	return nil
+}
+
+// Configure applies the declarative configuration to the given Resource. It
+// implements the ResourceConfigurator interface.
+func (d DeclarativeResource) Configure(r *Resource) {
+	if d.ShortGroup != nil {
+		r.ShortGroup = *d.ShortGroup
+	}
+	if d.Kind != nil {
+		r.Kind = *d.Kind
+	}
+	if d.Version != nil {
+		r.Version = *d.Version
+	}
+	if d.UseAsync != nil {
+		r.UseAsync = *d.UseAsync
+	}
+	if d.ExternalName != nil {
+		// the configuration has already been validated while parsing.
+		e, _ := d.ExternalName.build()
+		r.ExternalName = e
+	}
+	if len(d.References) > 0 && r.References == nil {
+		r.References = make(References, len(d.References))
+	}
+	for p, ref := range d.References {
+		var targets []ReferenceTarget
+		for _, t := range ref.Targets {
+			targets = append(targets, ReferenceTarget{
+				TerraformName: t.TerraformName,
+				Extractor:     t.Extractor,
+			})
+		}
+		var external *ExternalReference
+		if ref.External != nil {
+			external = &ExternalReference{
+				Group:     ref.External.Group,
+				Version:   ref.External.Version,
+				Kind:      ref.External.Kind,
+				FieldPath: ref.External.FieldPath,
+			}
+		}
+		r.References[p] = Reference{
+			TerraformName:     ref.TerraformName,
+			Extractor:         ref.Extractor,
+			ExtractorTemplate: ref.ExtractorTemplate,
+			RefFieldName:      ref.RefFieldName,
+			SelectorFieldName: ref.SelectorFieldName,
+			Targets:           targets,
+			External:          external,
+		}
+	}
+	if d.LateInitializer != nil {
+		if len(d.LateInitializer.IgnoredFields) > 0 {
+			r.LateInitializer.IgnoredFields = d.LateInitializer.IgnoredFields
+		}
+		if len(d.LateInitializer.ConditionalIgnoredFields) > 0 {
+			r.LateInitializer.ConditionalIgnoredFields = d.LateInitializer.ConditionalIgnoredFields
+		}
+	}
+	for tf, xp := range d.SensitiveFieldPaths {
+		r.Sensitive.AddFieldPath(tf, xp)
+	}
+	if len(d.RequiredFields) > 0 {
+		r.MarkAsRequired(d.RequiredFields...)
+	}
+	if len(d.SchemaElementOptions) > 0 && r.SchemaElementOptions == nil {
+		r.SchemaElementOptions = make(SchemaElementOptions, len(d.SchemaElementOptions))
+	}
+	for p, o := range d.SchemaElementOptions {
+		if o.AddToObservation {
+			r.SchemaElementOptions.SetAddToObservation(p)
+		}
+		if o.EmbeddedObject {
+			r.SchemaElementOptions.SetEmbeddedObject(p)
+		}
+		if o.ValueFrom {
+			r.SchemaElementOptions.SetValueFrom(p)
+		}
+	}
+}
+
+func (d *DeclarativeExternalName) build() (ExternalName, error) {
+	var e ExternalName
+	switch d.Type {
+	case ExternalNameTypeNameAsIdentifier:
+		e = NameAsIdentifier
+	case ExternalNameTypeIdentifierFromProvider:
+		e = IdentifierFromProvider
+	case ExternalNameTypeParameterAsIdentifier:
+		if d.Parameter == "" {
+			return ExternalName{}, errors.Errorf("parameter must be set for the %s external name", d.Type)
+		}
+		e = ParameterAsIdentifier(d.Parameter)
+	case ExternalNameTypeTemplatedStringAsIdentifier:
+		if d.Template == "" {
+			return ExternalName{}, errors.Errorf("template must be set for the %s external name", d.Type)
+		}
+		if _, err := parseExternalNameTemplate(d.Template); err != nil {
+			return ExternalName{}, errors.Wrap(err, "cannot parse the external name template")
+		}
+		e = TemplatedStringAsIdentifier(d.NameFieldPath, d.Template)
+	default:
+		return ExternalName{}, errors.Errorf("unknown external name type %q", d.Type)
+	}
+	if d.OmittedFields != nil {
+		e.OmittedFields = d.OmittedFields
+	}
+	if d.IdentifierFields != nil {
+		e.IdentifierFields = d.IdentifierFields
+	}
+	if d.DisableNameInitializer != nil {
+		e.DisableNameInitializer = *d.DisableNameInitializer
+	}
+	return e, nil
+}
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseResourceConfigurationFile(t *testing.T) {
+	type want struct {
+		file *ResourceConfigurationFile
+		err  bool
+	}
+	cases := map[string]struct {
+		reason string
+		data   string
+		want   want
+	}{
+		"Valid": {
+			reason: "A valid YAML configuration should be parsed",
+			data: `
+apiVersion: upjet.crossplane.io/v1alpha1
+kind: ResourceConfiguration
+resources:
+  test_subnet:
+    shortGroup: network
+    useAsync: false
+    externalName:
+      type: TemplatedStringAsIdentifier
+      template: "{{ .parameters.vpc_id }}/{{ .external_name }}"
+    references:
+      vpc_id:
+        terraformName: test_vpc
+`,
+			want: want{
+				file: &ResourceConfigurationFile{
+					APIVersion: ResourceConfigurationAPIVersion,
+					Kind:       ResourceConfigurationKind,
+					Resources: map[string]DeclarativeResource{
+						"test_subnet": {
+							ShortGroup: ptr.To("network"),
+							UseAsync:   ptr.To(false),
+							ExternalName: &DeclarativeExternalName{
+								Type:     ExternalNameTypeTemplatedStringAsIdentifier,
+								Template: "{{ .parameters.vpc_id }}/{{ .external_name }}",
+							},
+							References: map[string]DeclarativeReference{
+								"vpc_id": {TerraformName: "test_vpc"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"ValidJSON": {
+			reason: "JSON configurations should also be parsed",
+			data:   `{"apiVersion": "upjet.crossplane.io/v1alpha1", "kind": "ResourceConfiguration", "dataSources": {"test_ami": {"kind": "AMI"}}}`,
+			want: want{
+				file: &ResourceConfigurationFile{
+					APIVersion: ResourceConfigurationAPIVersion,
+					Kind:       ResourceConfigurationKind,
+					DataSources: map[string]DeclarativeResource{
+						"test_ami": {Kind: ptr.To("AMI")},
+					},
+				},
+			},
+		},
+		"PolymorphicReference": {
+			reason: "The candidate targets of a polymorphic reference should be parsed",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_route:\n    references:\n      target_id:\n        targets:\n        - terraformName: test_gateway\n        - terraformName: test_instance\n          extractor: example.com/extractor.InstanceID()\n",
+			want: want{
+				file: &ResourceConfigurationFile{
+					APIVersion: ResourceConfigurationAPIVersion,
+					Kind:       ResourceConfigurationKind,
+					Resources: map[string]DeclarativeResource{
+						"test_route": {References: map[string]DeclarativeReference{
+							"target_id": {Targets: []DeclarativeReferenceTarget{
+								{TerraformName: "test_gateway"},
+								{TerraformName: "test_instance", Extractor: "example.com/extractor.InstanceID()"},
+							}},
+						}},
+					},
+				},
+			},
+		},
+		"PolymorphicReferenceWithTerraformName": {
+			reason: "A polymorphic reference should not have a terraformName",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_route:\n    references:\n      target_id:\n        terraformName: test_gateway\n        targets:\n        - terraformName: test_instance\n",
+			want:   want{err: true},
+		},
+		"ExternalReference": {
+			reason: "The target kind of an external reference should be parsed",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_record:\n    references:\n      records:\n        external:\n          group: elbv2.aws.upbound.io\n          version: v1beta1\n          kind: LB\n          fieldPath: status.atProvider.dnsName\n",
+			want: want{
+				file: &ResourceConfigurationFile{
+					APIVersion: ResourceConfigurationAPIVersion,
+					Kind:       ResourceConfigurationKind,
+					Resources: map[string]DeclarativeResource{
+						"test_record": {References: map[string]DeclarativeReference{
+							"records": {External: &DeclarativeExternalReference{
+								Group:     "elbv2.aws.upbound.io",
+								Version:   "v1beta1",
+								Kind:      "LB",
+								FieldPath: "status.atProvider.dnsName",
+							}},
+						}},
+					},
+				},
+			},
+		},
+		"ExternalReferenceWithoutKind": {
+			reason: "An external reference should have a kind",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_record:\n    references:\n      records:\n        external:\n          group: elbv2.aws.upbound.io\n          version: v1beta1\n",
+			want:   want{err: true},
+		},
+		"ExtractorTemplate": {
+			reason: "The extractor template of a reference should be parsed",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_policy:\n    references:\n      role:\n        terraformName: test_role\n        extractorTemplate: \"arn:aws:iam::{{ .status.atProvider.accountId }}:role/{{ .external_name }}\"\n",
+			want: want{
+				file: &ResourceConfigurationFile{
+					APIVersion: ResourceConfigurationAPIVersion,
+					Kind:       ResourceConfigurationKind,
+					Resources: map[string]DeclarativeResource{
+						"test_policy": {References: map[string]DeclarativeReference{
+							"role": {TerraformName: "test_role", ExtractorTemplate: "arn:aws:iam::{{ .status.atProvider.accountId }}:role/{{ .external_name }}"},
+						}},
+					},
+				},
+			},
+		},
+		"InvalidExtractorTemplate": {
+			reason: "An extractor template that cannot be parsed should be rejected",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_policy:\n    references:\n      role:\n        terraformName: test_role\n        extractorTemplate: \"{{ .external_name \"\n",
+			want:   want{err: true},
+		},
+		"UnsupportedVersion": {
+			reason: "An unsupported apiVersion should be rejected",
+			data:   "apiVersion: upjet.crossplane.io/v2\nkind: ResourceConfiguration\n",
+			want:   want{err: true},
+		},
+		"UnknownField": {
+			reason: "Unknown fields should be rejected to catch typos",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_vpc:\n    shortGrop: ec2\n",
+			want:   want{err: true},
+		},
+		"UnknownExternalName": {
+			reason: "Unknown external name types should be rejected",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_vpc:\n    externalName:\n      type: NameAsID\n",
+			want:   want{err: true},
+		},
+		"InvalidTemplate": {
+			reason: "Invalid external name templates should be rejected instead of panicking",
+			data:   "apiVersion: upjet.crossplane.io/v1alpha1\nkind: ResourceConfiguration\nresources:\n  test_vpc:\n    externalName:\n      type: TemplatedStringAsIdentifier\n      template: \"{{ .external_name \"\n",
+			want:   want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseResourceConfigurationFile([]byte(tc.data))
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nParseResourceConfigurationFile(...): want error %t, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.file, got); diff != "" {
+				t.Errorf("\n%s\nParseResourceConfigurationFile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDeclarativeResourceConfigure(t *testing.T) {
+	r := DefaultResource("test_subnet", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}, nil, nil)
+	d := DeclarativeResource{
+		ShortGroup: ptr.To("network"),
+		Kind:       ptr.To("VPCSubnet"),
+		ExternalName: &DeclarativeExternalName{
+			Type:          ExternalNameTypeParameterAsIdentifier,
+			Parameter:     "name",
+			OmittedFields: []string{"name"},
+		},
+		References: map[string]DeclarativeReference{
+			"vpc_id": {TerraformName: "test_vpc", Extractor: "example.com/extractor.VPCID()"},
+		},
+		LateInitializer: &DeclarativeLateInitializer{
+			IgnoredFields: []string{"vpc_id"},
+		},
+		SensitiveFieldPaths: map[string]string{"name": "spec.forProvider.nameSecretRef"},
+		RequiredFields:      []string{"vpc_id"},
+		SchemaElementOptions: map[string]DeclarativeSchemaElementOption{
+			"vpc_id": {AddToObservation: true},
+			"name":   {ValueFrom: true},
+		},
+	}
+	d.Configure(r)
+
+	if diff := cmp.Diff("network", r.ShortGroup); diff != "" {
+		t.Errorf("Configure(...): -want short group, +got short group:\n%s", diff)
+	}
+	if diff := cmp.Diff("VPCSubnet", r.Kind); diff != "" {
+		t.Errorf("Configure(...): -want kind, +got kind:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"name"}, r.ExternalName.OmittedFields); diff != "" {
+		t.Errorf("Configure(...): -want omitted fields, +got omitted fields:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"name"}, r.ExternalName.IdentifierFields); diff != "" {
+		t.Errorf("Configure(...): -want identifier fields, +got identifier fields:\n%s", diff)
+	}
+	if diff := cmp.Diff(References{"vpc_id": {TerraformName: "test_vpc", Extractor: "example.com/extractor.VPCID()"}}, r.References); diff != "" {
+		t.Errorf("Configure(...): -want references, +got references:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"vpc_id"}, r.LateInitializer.IgnoredFields); diff != "" {
+		t.Errorf("Configure(...): -want late-init ignored fields, +got late-init ignored fields:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]string{"name": "spec.forProvider.nameSecretRef"}, r.Sensitive.GetFieldPaths()); diff != "" {
+		t.Errorf("Configure(...): -want sensitive field paths, +got sensitive field paths:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"vpc_id"}, r.RequiredFields()); diff != "" {
+		t.Errorf("Configure(...): -want required fields, +got required fields:\n%s", diff)
+	}
+	if !r.SchemaElementOptions.AddToObservation("vpc_id") {
+		t.Error("Configure(...): vpc_id should be added to the observation")
+	}
+	if diff := cmp.Diff(map[string]string{"name": "nameFrom"}, r.ValueFromFields()); diff != "" {
+		t.Errorf("Configure(...): -want value from fields, +got value from fields:\n%s", diff)
+	}
+}
+
+func TestResourceConfigurationFileAddToProvider(t *testing.T) {
+	f := &ResourceConfigurationFile{
+		Resources: map[string]DeclarativeResource{
+			"test_vpc": {ShortGroup: ptr.To("network")},
+		},
+	}
+	p := &Provider{
+		Resources: map[string]*Resource{
+			"test_vpc": DefaultResource("test_vpc", &schema.Resource{}, nil, nil),
+		},
+		resourceConfigurators: map[string]ResourceConfiguratorChain{},
+	}
+	if err := f.AddToProvider(p); err != nil {
+		t.Fatalf("AddToProvider(...): unexpected error: %v", err)
+	}
+	// Go configurators registered afterwards take precedence.
+	p.AddResourceConfigurator("test_vpc", func(r *Resource) {
+		r.Kind = "Network"
+	})
+	p.ConfigureResources()
+	if diff := cmp.Diff("network", p.Resources["test_vpc"].ShortGroup); diff != "" {
+		t.Errorf("AddToProvider(...): -want short group, +got short group:\n%s", diff)
+	}
+	if diff := cmp.Diff("Network", p.Resources["test_vpc"].Kind); diff != "" {
+		t.Errorf("AddToProvider(...): -want kind, +got kind:\n%s", diff)
+	}
+
+	f.Resources["test_subnet"] = DeclarativeResource{}
+	if err := f.AddToProvider(p); err == nil {
+		t.Error("AddToProvider(...): expected an error for a resource not generated by the provider")
+	}
+}
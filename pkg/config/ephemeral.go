@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	fwephemeral "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// kindSuffixEphemeralResource is appended to the default kind of the
+	// managed resources generated for Terraform ephemeral resources.
+	kindSuffixEphemeralResource = "EphemeralResource"
+)
+
+// EphemeralResource is the configuration of a Terraform Plugin Framework
+// ephemeral resource, such as a short-lived token or a generated password, to
+// be generated as a managed resource. The ephemeral resource arguments are
+// exposed under spec.forProvider and its result is written to the connection
+// secret of the managed resource. The controller opens the ephemeral resource,
+// renews it before it expires and closes it when the managed resource is
+// deleted.
+type EphemeralResource struct {
+	// Resource holds the code generation & controller configuration of the
+	// ephemeral resource. Its TerraformResource field holds the schema of
+	// the ephemeral resource converted from the Terraform JSON schema.
+	*Resource
+
+	// TerraformPluginFrameworkEphemeralResource is the Terraform Plugin
+	// Framework implementation of the ephemeral resource.
+	TerraformPluginFrameworkEphemeralResource fwephemeral.EphemeralResource
+}
+
+// DefaultEphemeralResource keeps an initial default configuration for all
+// ephemeral resources of a provider. The group is derived from the ephemeral
+// resource name the same way as for resources, and the kind is suffixed with
+// "EphemeralResource". All computed-only top-level attributes of the given
+// schema are marked as sensitive so that the result of the ephemeral
+// resource is only published via the connection secret and never stored in
+// the status of the managed resource. The given schema is not modified.
+func DefaultEphemeralResource(name string, terraformSchema *schema.Resource, terraformPluginFrameworkEphemeralResource fwephemeral.EphemeralResource, opts ...ResourceOption) *EphemeralResource {
+	if terraformSchema != nil {
+		terraformSchema = copyResourceSchema(terraformSchema)
+		for _, s := range terraformSchema.Schema {
+			if s.Computed && !s.Optional {
+				s.Sensitive = true
+			}
+		}
+	}
+	r := DefaultResource(name, terraformSchema, nil, nil)
+	r.Kind += kindSuffixEphemeralResource
+	r.ExternalName = IdentifierFromProvider
+	r.UseAsync = false
+	r.useTerraformPluginFrameworkClient = true
+	r.ephemeralResource = true
+	for _, f := range opts {
+		f(r)
+	}
+	return &EphemeralResource{
+		Resource: r,
+		TerraformPluginFrameworkEphemeralResource: terraformPluginFrameworkEphemeralResource,
+	}
+}
+
+// copyResourceSchema returns a deep copy of the given Terraform resource
+// schema, which can be modified without modifying the given schema, e.g.,
+// when it's shared with the resource of the same name.
+func copyResourceSchema(r *schema.Resource) *schema.Resource {
+	c := *r
+	c.Schema = make(map[string]*schema.Schema, len(r.Schema))
+	for k, s := range r.Schema {
+		c.Schema[k] = copySchema(s)
+	}
+	return &c
+}
+
+func copySchema(s *schema.Schema) *schema.Schema {
+	c := *s
+	switch e := s.Elem.(type) {
+	case *schema.Resource:
+		c.Elem = copyResourceSchema(e)
+	case *schema.Schema:
+		c.Elem = copySchema(e)
+	}
+	return &c
+}
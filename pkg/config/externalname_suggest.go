@@ -0,0 +1,546 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	tjname "github.com/crossplane/upjet/v2/pkg/types/name"
+)
+
+// ExternalNameKind is the kind of a suggested external name configuration.
+type ExternalNameKind string
+
+const (
+	// ExternalNameKindNameAsIdentifier suggests NameAsIdentifier.
+	ExternalNameKindNameAsIdentifier ExternalNameKind = "NameAsIdentifier"
+	// ExternalNameKindParameterAsIdentifier suggests ParameterAsIdentifier.
+	ExternalNameKindParameterAsIdentifier ExternalNameKind = "ParameterAsIdentifier"
+	// ExternalNameKindIdentifierFromProvider suggests IdentifierFromProvider.
+	ExternalNameKindIdentifierFromProvider ExternalNameKind = "IdentifierFromProvider"
+	// ExternalNameKindTemplatedStringAsIdentifier suggests
+	// TemplatedStringAsIdentifier.
+	ExternalNameKindTemplatedStringAsIdentifier ExternalNameKind = "TemplatedStringAsIdentifier"
+)
+
+// ExternalNameConfidence is the confidence level of an external name
+// suggestion.
+type ExternalNameConfidence string
+
+const (
+	// ExternalNameConfidenceHigh is the confidence level of the suggestions
+	// whose import ID segments are all matched to the arguments explicitly,
+	// i.e., via the placeholders or the example values, or are recognized as
+	// provider-assigned identifiers.
+	ExternalNameConfidenceHigh ExternalNameConfidence = "High"
+	// ExternalNameConfidenceMedium is the confidence level of the
+	// suggestions with segments matched to the arguments by their names.
+	ExternalNameConfidenceMedium ExternalNameConfidence = "Medium"
+	// ExternalNameConfidenceLow is the confidence level of the suggestions
+	// with segments not matched to any argument, which need to be reviewed.
+	ExternalNameConfidenceLow ExternalNameConfidence = "Low"
+	// ExternalNameConfidenceNone is the confidence level of the resources
+	// for which no suggestion can be made, e.g., because they do not have
+	// any import statements.
+	ExternalNameConfidenceNone ExternalNameConfidence = "None"
+)
+
+var (
+	confidenceLevels = []ExternalNameConfidence{ExternalNameConfidenceHigh, ExternalNameConfidenceMedium, ExternalNameConfidenceLow, ExternalNameConfidenceNone}
+
+	importBlockIDPattern   = regexp.MustCompile(`\bid\s*=\s*"([^"]*)"`)
+	importIDSeparators     = regexp.MustCompile(`[/:,|;]`)
+	importIDPlaceholder    = regexp.MustCompile(`^(?:\{\{\s*([\w.-]+)\s*\}\}|\{([\w.-]+)\}|\$\{([\w.-]+)\}|<([\w.-]+)>|\[([\w.-]+)\])$`)
+	importIDKeyword        = regexp.MustCompile(`^[A-Za-z]+$`)
+	importIDNamespace      = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*(\.[A-Za-z0-9]+)+$`)
+	providerAssignedIDForm = []*regexp.Regexp{
+		// prefixed hexadecimal identifiers, e.g., vpc-0123456789abcdef0
+		regexp.MustCompile(`^[a-z]{1,12}-[0-9a-f]{8,17}$`),
+		// UUIDs
+		regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+		// numeric and long hexadecimal identifiers
+		regexp.MustCompile(`^[0-9]+$`),
+		regexp.MustCompile(`^[0-9a-f]{16,}$`),
+	}
+)
+
+// ExternalNameSuggestion is an external name configuration suggested for a
+// resource from the shape of the IDs in its Terraform import statements.
+type ExternalNameSuggestion struct {
+	// Resource is the name of the Terraform resource.
+	Resource string `json:"resource"`
+	// ImportID is the example import ID the suggestion is derived from.
+	ImportID string `json:"importID,omitempty"`
+	// Kind is the kind of the suggested configuration. It's empty if no
+	// configuration can be suggested.
+	Kind ExternalNameKind `json:"kind,omitempty"`
+	// Parameter is the argument used as the external name by the
+	// ParameterAsIdentifier and TemplatedStringAsIdentifier configurations.
+	Parameter string `json:"parameter,omitempty"`
+	// Template is the template of the TemplatedStringAsIdentifier
+	// configuration.
+	Template string `json:"template,omitempty"`
+	// Confidence is the confidence level of the suggestion.
+	Confidence ExternalNameConfidence `json:"confidence"`
+	// Notes explain how the import ID segments are matched, and what needs
+	// to be reviewed.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// Configuration returns the Go expression of the suggested external name
+// configuration, which can be used in the resource configurators.
+func (s ExternalNameSuggestion) Configuration() string {
+	switch s.Kind {
+	case ExternalNameKindNameAsIdentifier, ExternalNameKindIdentifierFromProvider:
+		return "config." + string(s.Kind)
+	case ExternalNameKindParameterAsIdentifier:
+		return fmt.Sprintf("config.ParameterAsIdentifier(%q)", s.Parameter)
+	case ExternalNameKindTemplatedStringAsIdentifier:
+		return fmt.Sprintf("config.TemplatedStringAsIdentifier(%q, %q)", s.Parameter, s.Template)
+	default:
+		return ""
+	}
+}
+
+// ExternalName returns the suggested external name configuration. It
+// returns false if no configuration can be suggested.
+func (s ExternalNameSuggestion) ExternalName() (ExternalName, bool) {
+	switch s.Kind {
+	case ExternalNameKindNameAsIdentifier:
+		return NameAsIdentifier, true
+	case ExternalNameKindIdentifierFromProvider:
+		return IdentifierFromProvider, true
+	case ExternalNameKindParameterAsIdentifier:
+		return ParameterAsIdentifier(s.Parameter), true
+	case ExternalNameKindTemplatedStringAsIdentifier:
+		return TemplatedStringAsIdentifier(s.Parameter, s.Template), true
+	default:
+		return ExternalName{}, false
+	}
+}
+
+// ExternalNameReport is the report of the external name configurations
+// suggested for the resources of a provider.
+type ExternalNameReport struct {
+	// Suggestions are the suggestions sorted by the resource names.
+	Suggestions []ExternalNameSuggestion `json:"suggestions"`
+}
+
+// Summary returns the number of the suggestions per confidence level.
+func (r ExternalNameReport) Summary() map[ExternalNameConfidence]int {
+	m := make(map[ExternalNameConfidence]int, len(confidenceLevels))
+	for _, s := range r.Suggestions {
+		m[s.Confidence]++
+	}
+	return m
+}
+
+// String returns a human-readable report with the suggested configurations,
+// their confidence levels and notes, followed by a summary.
+func (r ExternalNameReport) String() string {
+	sb := &strings.Builder{}
+	for _, s := range r.Suggestions {
+		c := s.Configuration()
+		if c == "" {
+			c = "<no suggestion>"
+		}
+		fmt.Fprintf(sb, "%s: %s (confidence: %s)\n", s.Resource, c, s.Confidence)
+		if s.ImportID != "" {
+			fmt.Fprintf(sb, "  import ID: %s\n", s.ImportID)
+		}
+		for _, n := range s.Notes {
+			fmt.Fprintf(sb, "  - %s\n", n)
+		}
+	}
+	summary := r.Summary()
+	fmt.Fprintf(sb, "\n%d resources:", len(r.Suggestions))
+	for _, c := range confidenceLevels {
+		fmt.Fprintf(sb, " %s=%d", c, summary[c])
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// SuggestExternalNames suggests external name configurations for the
+// resources of the provider from the import statements scraped from the
+// Terraform registry. The configured external names are not taken into
+// account, so the report can be used both for bootstrapping the
+// configurations of the new resources and for reviewing the existing ones.
+func (p *Provider) SuggestExternalNames() ExternalNameReport {
+	names := make([]string, 0, len(p.Resources))
+	for n := range p.Resources {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	r := ExternalNameReport{Suggestions: make([]ExternalNameSuggestion, 0, len(names))}
+	for _, n := range names {
+		r.Suggestions = append(r.Suggestions, SuggestExternalName(p.Resources[n]))
+	}
+	return r
+}
+
+// importIDSegment is a segment of an import ID delimited by the separators.
+type importIDSegment struct {
+	// value is the segment as it appears in the import ID.
+	value string
+	// separator is the separator following the segment, if any.
+	separator string
+	// variable is true if the segment is a value varying per resource
+	// rather than a constant of the ID format.
+	variable bool
+	// placeholder is the name of the placeholder, if the segment is one,
+	// e.g., project for {{project}}.
+	placeholder string
+	// key is the preceding collection keyword, e.g., resourceGroups for
+	// the resource group name segment of an Azure resource ID.
+	key string
+}
+
+// SuggestExternalName suggests an external name configuration for the
+// resource from the shape of the ID in its Terraform import statements. The
+// import ID is split into segments by the /, :, ,, | and ; separators, and
+// the variable segments are matched to the arguments of the resource by
+// their placeholders (e.g., {{project}}), the values in the registry
+// examples, the preceding collection keywords (e.g., resourceGroups/<name>)
+// and the resource name. The last variable segment is assumed to be the
+// external name. Single segment IDs yield NameAsIdentifier,
+// ParameterAsIdentifier or IdentifierFromProvider and the others yield
+// TemplatedStringAsIdentifier.
+func SuggestExternalName(r *Resource) ExternalNameSuggestion { //nolint:gocyclo // easier to follow as a unit
+	s := ExternalNameSuggestion{Resource: r.Name, Confidence: ExternalNameConfidenceNone}
+	var ids []string
+	if r.MetaResource != nil {
+		for _, st := range r.MetaResource.ImportStatements {
+			if id, ok := parseImportID(st); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		s.Notes = append(s.Notes, "no import statement with an import ID is found in the registry metadata")
+		return s
+	}
+	s.ImportID = ids[0]
+	segments := splitImportID(s.ImportID)
+	examples := exampleValues(r)
+	var vars []int
+	for i := range segments {
+		if segments[i].variable {
+			vars = append(vars, i)
+		}
+	}
+	if len(vars) == 0 {
+		s.Notes = append(s.Notes, fmt.Sprintf("import ID %q has no variable segment", s.ImportID))
+		return s
+	}
+
+	confidence := ExternalNameConfidenceHigh
+	lower := func(c ExternalNameConfidence) {
+		if slices.Index(confidenceLevels, c) > slices.Index(confidenceLevels, confidence) {
+			confidence = c
+		}
+	}
+	args := make(map[int]string, len(vars))
+	for j, i := range vars {
+		seg := segments[i]
+		arg, how, c := matchImportIDSegment(r, seg, examples, j == len(vars)-1)
+		if arg == "" {
+			continue
+		}
+		args[i] = arg
+		lower(c)
+		s.Notes = append(s.Notes, fmt.Sprintf("segment %q is matched to the argument %q %s", seg.value, arg, how))
+	}
+
+	last := segments[vars[len(vars)-1]]
+	lastArg := args[vars[len(vars)-1]]
+	if lastArg == "" {
+		if last.placeholder == "id" || isProviderAssignedID(last.value) {
+			s.Notes = append(s.Notes, fmt.Sprintf("segment %q looks like an identifier assigned by the provider", last.value))
+		} else {
+			lower(ExternalNameConfidenceLow)
+			s.Notes = append(s.Notes, fmt.Sprintf("segment %q is not matched to any argument, and is assumed to be assigned by the provider", last.value))
+		}
+	}
+
+	if len(segments) == 1 {
+		switch lastArg {
+		case "":
+			s.Kind = ExternalNameKindIdentifierFromProvider
+		case "name":
+			s.Kind = ExternalNameKindNameAsIdentifier
+		default:
+			s.Kind = ExternalNameKindParameterAsIdentifier
+			s.Parameter = lastArg
+		}
+	} else {
+		s.Kind = ExternalNameKindTemplatedStringAsIdentifier
+		s.Parameter = lastArg
+		sb := &strings.Builder{}
+		for i, seg := range segments {
+			switch {
+			case i == vars[len(vars)-1]:
+				sb.WriteString("{{ .external_name }}")
+			case args[i] != "":
+				fmt.Fprintf(sb, "{{ .parameters.%s }}", args[i])
+			case seg.variable && seg.placeholder != "":
+				lower(ExternalNameConfidenceLow)
+				key := tjname.NewFromCamel(seg.placeholder).Snake
+				fmt.Fprintf(sb, "{{ .setup.configuration.%s }}", key)
+				s.Notes = append(s.Notes, fmt.Sprintf("segment %q is not matched to any argument, and is assumed to be the provider configuration %q", seg.value, key))
+			case seg.variable:
+				lower(ExternalNameConfidenceLow)
+				sb.WriteString(seg.value)
+				s.Notes = append(s.Notes, fmt.Sprintf("segment %q is not matched to any argument, and is kept as a constant", seg.value))
+			default:
+				sb.WriteString(seg.value)
+			}
+			sb.WriteString(seg.separator)
+		}
+		s.Template = sb.String()
+	}
+
+	for _, id := range ids[1:] {
+		if !sameImportIDShape(segments, splitImportID(id)) {
+			lower(ExternalNameConfidenceLow)
+			s.Notes = append(s.Notes, fmt.Sprintf("import ID %q has a different shape, e.g., an optional segment, which may need a custom configuration", id))
+		}
+	}
+	s.Confidence = confidence
+	return s
+}
+
+// parseImportID parses the import ID from an import statement of the form
+// `terraform import <address> <ID>` or an import block. The first command
+// is used for the shell snippets with multiple commands.
+func parseImportID(statement string) (string, bool) {
+	for _, l := range strings.Split(statement, "\n") {
+		l = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "$"))
+		if !strings.HasPrefix(l, "terraform import") {
+			continue
+		}
+		var args []string
+		for _, f := range strings.Fields(l)[2:] {
+			if !strings.HasPrefix(f, "-") {
+				args = append(args, f)
+			}
+		}
+		if len(args) < 2 {
+			continue
+		}
+		if id := strings.Trim(strings.Join(args[1:], " "), `'"`); id != "" {
+			return id, true
+		}
+	}
+	if m := importBlockIDPattern.FindStringSubmatch(statement); m != nil && m[1] != "" {
+		return m[1], true
+	}
+	return "", false
+}
+
+// splitImportID splits the import ID into its segments, and marks the
+// segments varying per resource: the placeholders, the values following the
+// plural collection keywords, the values that are not plain words and the
+// last segment. The namespaces like Microsoft.KeyVault are constants.
+func splitImportID(id string) []importIDSegment { //nolint:gocyclo // easier to follow as a unit
+	var segments []importIDSegment
+	start := 0
+	for _, loc := range importIDSeparators.FindAllStringIndex(id, -1) {
+		segments = append(segments, importIDSegment{value: id[start:loc[0]], separator: id[loc[0]:loc[1]]})
+		start = loc[1]
+	}
+	segments = append(segments, importIDSegment{value: id[start:]})
+	for i := range segments {
+		seg := &segments[i]
+		if m := importIDPlaceholder.FindStringSubmatch(seg.value); m != nil {
+			for _, p := range m[1:] {
+				if p != "" {
+					seg.placeholder = strings.ReplaceAll(p, "-", "_")
+				}
+			}
+			seg.variable = true
+			continue
+		}
+		if i > 0 && segments[i-1].separator == "/" && !segments[i-1].variable && isCollectionKeyword(segments[i-1].value) {
+			seg.key = segments[i-1].value
+		}
+		switch {
+		case seg.value == "" || importIDNamespace.MatchString(seg.value):
+		case seg.key != "" || i == len(segments)-1:
+			seg.variable = true
+		default:
+			seg.variable = !importIDKeyword.MatchString(seg.value)
+		}
+	}
+	// the last non-empty segment is the varying one, e.g., for the IDs
+	// ending with a separator.
+	if !slices.ContainsFunc(segments, func(s importIDSegment) bool { return s.variable }) {
+		for i := len(segments) - 1; i >= 0; i-- {
+			if segments[i].value != "" {
+				segments[i].variable = true
+				break
+			}
+		}
+	}
+	return segments
+}
+
+// isCollectionKeyword returns true if the segment is a plural word naming a
+// collection, e.g., projects or resourceGroups.
+func isCollectionKeyword(s string) bool {
+	return len(s) > 2 && importIDKeyword.MatchString(s) && strings.HasSuffix(s, "s")
+}
+
+// matchImportIDSegment matches a variable import ID segment to an argument
+// of the resource, and returns the argument, how it's matched and the
+// confidence level of the match.
+func matchImportIDSegment(r *Resource, seg importIDSegment, examples map[string][]string, last bool) (string, string, ExternalNameConfidence) { //nolint:gocyclo // the matchers are tried in the order of their confidence levels
+	if seg.placeholder != "" {
+		p := seg.placeholder
+		if !strings.Contains(p, "_") {
+			p = tjname.NewFromCamel(p).Snake
+		}
+		if arg := findIdentifierArgument(r, p, p+"_name", p+"_id", strings.TrimSuffix(p, "_name")); arg != "" {
+			return arg, "by its placeholder", ExternalNameConfidenceHigh
+		}
+		if !last || p == "id" {
+			return "", "", ""
+		}
+	}
+	if args := examples[seg.value]; len(args) == 1 {
+		return args[0], "by the value in the registry example", ExternalNameConfidenceHigh
+	}
+	if seg.key != "" {
+		if arg := findArgumentByKeyword(r, seg.key); arg != "" {
+			return arg, fmt.Sprintf("by the preceding keyword %q", seg.key), ExternalNameConfidenceMedium
+		}
+	}
+	if !last {
+		return "", "", ""
+	}
+	// the external name is matched to the argument named after the resource,
+	// e.g., bucket for aws_s3_bucket, or the name argument. The arguments
+	// mentioned in the sample value, e.g., bucket for my-bucket, are preferred.
+	parts := strings.Split(r.Name, "_")
+	var candidates []string
+	for _, p := range parts[1:] {
+		if strings.Contains(strings.ToLower(seg.value), p) {
+			candidates = append(candidates, p)
+		}
+	}
+	for i := 1; i < len(parts); i++ {
+		candidates = append(candidates, strings.Join(parts[i:], "_"), strings.Join(parts[i:], "_")+"_name")
+	}
+	if arg := findIdentifierArgument(r, candidates...); arg != "" {
+		return arg, "by the resource name", ExternalNameConfidenceMedium
+	}
+	if isProviderAssignedID(seg.value) {
+		return "", "", ""
+	}
+	if arg := findIdentifierArgument(r, "name"); arg != "" {
+		return arg, "as the name of the resource", ExternalNameConfidenceMedium
+	}
+	return "", "", ""
+}
+
+// findIdentifierArgument returns the first of the candidates that is a
+// configurable, primitive top-level argument of the resource.
+func findIdentifierArgument(r *Resource, candidates ...string) string {
+	if r.TerraformResource == nil {
+		return ""
+	}
+	for _, c := range candidates {
+		sch, ok := r.TerraformResource.Schema[c]
+		if !ok || (!sch.Required && !sch.Optional) {
+			continue
+		}
+		switch sch.Type { //nolint:exhaustive // only the primitive arguments can be identifiers
+		case schema.TypeString, schema.TypeInt:
+			return c
+		}
+	}
+	return ""
+}
+
+// findArgumentByKeyword returns the identifier argument named after the
+// singular form of the collection keyword, regardless of its case, e.g.,
+// resource_group_name for both resourceGroups and resourcegroups.
+func findArgumentByKeyword(r *Resource, keyword string) string {
+	if r.TerraformResource == nil {
+		return ""
+	}
+	k := singular(strings.ToLower(keyword))
+	args := make(map[string]string, len(r.TerraformResource.Schema))
+	for a := range r.TerraformResource.Schema {
+		args[strings.ReplaceAll(a, "_", "")] = a
+	}
+	for _, c := range []string{k, k + "name", k + "id"} {
+		if a, ok := args[c]; ok && findIdentifierArgument(r, a) != "" {
+			return a
+		}
+	}
+	return ""
+}
+
+// exampleValues returns the top-level arguments of the registry examples of
+// the resource keyed by their string values.
+func exampleValues(r *Resource) map[string][]string {
+	values := map[string][]string{}
+	if r.MetaResource == nil {
+		return values
+	}
+	for _, e := range r.MetaResource.Examples {
+		m := map[string]any{}
+		if err := json.Unmarshal([]byte(e.Manifest), &m); err != nil {
+			continue
+		}
+		for k, v := range m {
+			if s, ok := v.(string); ok && s != "" && findIdentifierArgument(r, k) != "" && !slices.Contains(values[s], k) {
+				values[s] = append(values[s], k)
+			}
+		}
+	}
+	return values
+}
+
+// sameImportIDShape returns true if both of the import IDs have the same
+// separators and constant segments.
+func sameImportIDShape(a, b []importIDSegment) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].separator != b[i].separator || a[i].variable != b[i].variable || (!a[i].variable && a[i].value != b[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+func isProviderAssignedID(s string) bool {
+	for _, p := range providerAssignedIDForm {
+		if p.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "sses"):
+		return strings.TrimSuffix(s, "es")
+	default:
+		return strings.TrimSuffix(s, "s")
+	}
+}
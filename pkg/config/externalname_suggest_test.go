@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/crossplane/upjet/v2/pkg/registry"
+)
+
+func newSuggestionTestResource(name string, args []string, examples []registry.ResourceExample, importStatements ...string) *Resource {
+	sch := map[string]*schema.Schema{
+		"id":  {Type: schema.TypeString, Computed: true},
+		"arn": {Type: schema.TypeString, Computed: true},
+	}
+	for _, a := range args {
+		sch[a] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	}
+	return &Resource{
+		Name:              name,
+		TerraformResource: &schema.Resource{Schema: sch},
+		MetaResource: &registry.Resource{
+			Name:             name,
+			Examples:         examples,
+			ImportStatements: importStatements,
+		},
+	}
+}
+
+func TestSuggestExternalName(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		r      *Resource
+		want   ExternalNameSuggestion
+	}{
+		"NoImportStatements": {
+			reason: "No configuration should be suggested for a resource without import statements.",
+			r:      newSuggestionTestResource("aws_vpc", nil, nil),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_vpc",
+				Confidence: ExternalNameConfidenceNone,
+				Notes:      []string{"no import statement with an import ID is found in the registry metadata"},
+			},
+		},
+		"SeparatorOnlyImportID": {
+			reason: "No configuration should be suggested for an import ID without any variable segment.",
+			r:      newSuggestionTestResource("aws_vpc", nil, nil, "terraform import aws_vpc.test_vpc /"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_vpc",
+				ImportID:   "/",
+				Confidence: ExternalNameConfidenceNone,
+				Notes:      []string{`import ID "/" has no variable segment`},
+			},
+		},
+		"ConstantSegmentsImportID": {
+			reason: "No configuration should be suggested for an import ID consisting of constant separators.",
+			r:      newSuggestionTestResource("aws_vpc", nil, nil, "terraform import aws_vpc.test_vpc ::"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_vpc",
+				ImportID:   "::",
+				Confidence: ExternalNameConfidenceNone,
+				Notes:      []string{`import ID "::" has no variable segment`},
+			},
+		},
+		"IdentifierFromProvider": {
+			reason: "A provider-assigned identifier should yield IdentifierFromProvider.",
+			r:      newSuggestionTestResource("aws_vpc", []string{"cidr_block"}, nil, "terraform import aws_vpc.test_vpc vpc-a01106c2"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_vpc",
+				ImportID:   "vpc-a01106c2",
+				Kind:       ExternalNameKindIdentifierFromProvider,
+				Confidence: ExternalNameConfidenceHigh,
+				Notes:      []string{`segment "vpc-a01106c2" looks like an identifier assigned by the provider`},
+			},
+		},
+		"ParameterAsIdentifierFromExample": {
+			reason: "A single segment ID with the value of an argument in the examples should yield ParameterAsIdentifier.",
+			r: newSuggestionTestResource("aws_s3_bucket", []string{"bucket", "name"},
+				[]registry.ResourceExample{{Manifest: `{"bucket": "my-tf-test-bucket"}`}},
+				"terraform import aws_s3_bucket.bucket my-tf-test-bucket"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_s3_bucket",
+				ImportID:   "my-tf-test-bucket",
+				Kind:       ExternalNameKindParameterAsIdentifier,
+				Parameter:  "bucket",
+				Confidence: ExternalNameConfidenceHigh,
+				Notes:      []string{`segment "my-tf-test-bucket" is matched to the argument "bucket" by the value in the registry example`},
+			},
+		},
+		"NameAsIdentifier": {
+			reason: "A single segment ID matched to the name argument should yield NameAsIdentifier.",
+			r:      newSuggestionTestResource("aws_iam_role", []string{"name"}, nil, "$ terraform import aws_iam_role.developer developer_name"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_iam_role",
+				ImportID:   "developer_name",
+				Kind:       ExternalNameKindNameAsIdentifier,
+				Confidence: ExternalNameConfidenceMedium,
+				Notes:      []string{`segment "developer_name" is matched to the argument "name" as the name of the resource`},
+			},
+		},
+		"AzureResourceID": {
+			reason: "The collection keywords of a resource ID should be kept as constants and the values should be matched to the arguments.",
+			r: newSuggestionTestResource("azurerm_key_vault", []string{"name", "resource_group_name"}, nil,
+				"terraform import azurerm_key_vault.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mygroup1/providers/Microsoft.KeyVault/vaults/vault1"),
+			want: ExternalNameSuggestion{
+				Resource:   "azurerm_key_vault",
+				ImportID:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mygroup1/providers/Microsoft.KeyVault/vaults/vault1",
+				Kind:       ExternalNameKindTemplatedStringAsIdentifier,
+				Parameter:  "name",
+				Template:   "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/{{ .parameters.resource_group_name }}/providers/Microsoft.KeyVault/vaults/{{ .external_name }}",
+				Confidence: ExternalNameConfidenceLow,
+				Notes: []string{
+					`segment "mygroup1" is matched to the argument "resource_group_name" by the preceding keyword "resourceGroups"`,
+					`segment "vault1" is matched to the argument "name" as the name of the resource`,
+					`segment "00000000-0000-0000-0000-000000000000" is not matched to any argument, and is kept as a constant`,
+				},
+			},
+		},
+		"PlaceholdersInImportBlock": {
+			reason: "The placeholders should be matched to the arguments or the provider configuration.",
+			r: newSuggestionTestResource("google_compute_network", []string{"name"}, nil,
+				"import {\n  id = \"projects/{{project}}/global/networks/{{name}}\"\n  to = google_compute_network.default\n}"),
+			want: ExternalNameSuggestion{
+				Resource:   "google_compute_network",
+				ImportID:   "projects/{{project}}/global/networks/{{name}}",
+				Kind:       ExternalNameKindTemplatedStringAsIdentifier,
+				Parameter:  "name",
+				Template:   "projects/{{ .setup.configuration.project }}/global/networks/{{ .external_name }}",
+				Confidence: ExternalNameConfidenceLow,
+				Notes: []string{
+					`segment "{{name}}" is matched to the argument "name" by its placeholder`,
+					`segment "{{project}}" is not matched to any argument, and is assumed to be the provider configuration "project"`,
+				},
+			},
+		},
+		"ProviderAssignedSuffix": {
+			reason: "A provider-assigned last segment should be the external name without an identifier argument.",
+			r: newSuggestionTestResource("example_node", []string{"cluster_id"}, nil,
+				"terraform import example_node.n <cluster_id>:<id>"),
+			want: ExternalNameSuggestion{
+				Resource:   "example_node",
+				ImportID:   "<cluster_id>:<id>",
+				Kind:       ExternalNameKindTemplatedStringAsIdentifier,
+				Template:   "{{ .parameters.cluster_id }}:{{ .external_name }}",
+				Confidence: ExternalNameConfidenceHigh,
+				Notes: []string{
+					`segment "<cluster_id>" is matched to the argument "cluster_id" by its placeholder`,
+					`segment "<id>" looks like an identifier assigned by the provider`,
+				},
+			},
+		},
+		"DifferentShapes": {
+			reason: "Import IDs with different shapes should lower the confidence.",
+			r: newSuggestionTestResource("aws_s3_bucket_acl", []string{"bucket", "expected_bucket_owner", "acl"}, nil,
+				"terraform import aws_s3_bucket_acl.example bucket-name",
+				"terraform import aws_s3_bucket_acl.example bucket-name,123456789012"),
+			want: ExternalNameSuggestion{
+				Resource:   "aws_s3_bucket_acl",
+				ImportID:   "bucket-name",
+				Kind:       ExternalNameKindParameterAsIdentifier,
+				Parameter:  "bucket",
+				Confidence: ExternalNameConfidenceLow,
+				Notes: []string{
+					`segment "bucket-name" is matched to the argument "bucket" by the resource name`,
+					`import ID "bucket-name,123456789012" has a different shape, e.g., an optional segment, which may need a custom configuration`,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SuggestExternalName(tc.r)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nSuggestExternalName(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
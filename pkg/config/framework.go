@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// frameworkResourceSchema returns the schema of the specified Terraform
+// Plugin Framework resource.
+func frameworkResourceSchema(name string, resource fwresource.Resource) (rschema.Schema, error) {
+	schemaResp := fwresource.SchemaResponse{}
+	resource.Schema(context.TODO(), fwresource.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return rschema.Schema{}, errors.Errorf("failed to retrieve framework schema for resource %q: %v", name, schemaResp.Diagnostics)
+	}
+	return schemaResp.Schema, nil
+}
+
+// walkFrameworkSchema calls fn for each of the specified attributes & blocks
+// and their nested attributes & blocks with their path expressions relative
+// to the parent path expression.
+func walkFrameworkSchema(attrs map[string]rschema.Attribute, blocks map[string]rschema.Block, parent fwpath.Expression, fn func(e fwpath.Expression, attr any)) {
+	for n, a := range attrs {
+		e := attributeExpression(parent, n)
+		fn(e, a)
+		switch a := a.(type) {
+		case rschema.ListNestedAttribute:
+			walkFrameworkSchema(a.NestedObject.Attributes, nil, e.AtAnyListIndex(), fn)
+		case rschema.SetNestedAttribute:
+			walkFrameworkSchema(a.NestedObject.Attributes, nil, e.AtAnySetValue(), fn)
+		case rschema.MapNestedAttribute:
+			walkFrameworkSchema(a.NestedObject.Attributes, nil, e.AtAnyMapKey(), fn)
+		case rschema.SingleNestedAttribute:
+			walkFrameworkSchema(a.Attributes, nil, e, fn)
+		}
+	}
+	for n, b := range blocks {
+		e := attributeExpression(parent, n)
+		fn(e, b)
+		switch b := b.(type) {
+		case rschema.ListNestedBlock:
+			walkFrameworkSchema(b.NestedObject.Attributes, b.NestedObject.Blocks, e.AtAnyListIndex(), fn)
+		case rschema.SetNestedBlock:
+			walkFrameworkSchema(b.NestedObject.Attributes, b.NestedObject.Blocks, e.AtAnySetValue(), fn)
+		case rschema.SingleNestedBlock:
+			walkFrameworkSchema(b.Attributes, b.Blocks, e, fn)
+		}
+	}
+}
+
+func attributeExpression(parent fwpath.Expression, name string) fwpath.Expression {
+	if len(parent.Steps()) == 0 {
+		return fwpath.MatchRoot(name)
+	}
+	return parent.AtName(name)
+}
+
+// frameworkValidators returns the validators of the specified framework
+// attribute or block. The validators are typed per attribute type, so we
+// access them via reflection.
+func frameworkValidators(attr any) []reflect.Value {
+	return frameworkSliceField(attr, "Validators")
+}
+
+// frameworkPlanModifiers returns the plan modifiers of the specified
+// framework attribute or block. Like the validators, the plan modifiers are
+// typed per attribute type, so we access them via reflection.
+func frameworkPlanModifiers(attr any) []reflect.Value {
+	return frameworkSliceField(attr, "PlanModifiers")
+}
+
+func frameworkSliceField(attr any, name string) []reflect.Value {
+	v := reflect.Indirect(reflect.ValueOf(attr))
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.FieldByName(name)
+	if !f.IsValid() || f.Kind() != reflect.Slice {
+		return nil
+	}
+	result := make([]reflect.Value, 0, f.Len())
+	for i := range f.Len() {
+		result = append(result, f.Index(i))
+	}
+	return result
+}
+
+// sdkSchemaKey converts the specified path expression into a Terraform
+// Plugin SDK schema key such as a.0.b. The element steps are represented
+// with the index 0. An empty string is returned if the expression cannot be
+// resolved.
+func sdkSchemaKey(e fwpath.Expression) string {
+	steps := e.Resolve().Steps()
+	parts := make([]string, 0, len(steps))
+	for _, s := range steps {
+		switch s := s.(type) {
+		case fwpath.ExpressionStepAttributeNameExact:
+			parts = append(parts, string(s))
+		case fwpath.ExpressionStepParent:
+			return ""
+		default:
+			parts = append(parts, "0")
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// schemaFieldPath converts the specified schema key into a field path
+// without the list indices as expected by GetSchema.
+func schemaFieldPath(key string) string {
+	parts := strings.Split(key, ".")
+	return strings.Join(slices.DeleteFunc(parts, func(p string) bool { return p == "0" }), ".")
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+const (
+	// the type name of the stringvalidator.OneOf validator from the
+	// terraform-plugin-framework-validators module.
+	fwOneOfValidator        = "oneOfValidator"
+	fwStringValidatorSuffix = "/stringvalidator"
+)
+
+// collectFrameworkDefaults collects the static defaults and the
+// stringvalidator.OneOf validators of the Terraform Plugin Framework
+// attributes of the specified resource, so that the kubebuilder markers can
+// be inferred from them like the Terraform Plugin SDK resources. The
+// Terraform schema of the resource is not modified.
+func collectFrameworkDefaults(name string, r *Resource) error {
+	if r.TerraformPluginFrameworkResource == nil || r.TerraformResource == nil {
+		return nil
+	}
+	s, err := frameworkResourceSchema(name, r.TerraformPluginFrameworkResource)
+	if err != nil {
+		return err
+	}
+	walkFrameworkSchema(s.Attributes, s.Blocks, fwpath.Expression{}, func(e fwpath.Expression, a any) {
+		fieldPath := schemaFieldPath(sdkSchemaKey(e))
+		if GetSchema(r.TerraformResource, fieldPath) == nil {
+			return
+		}
+		d, hasDefault := frameworkDefault(a)
+		values, hasEnum := frameworkOneOfValues(a)
+		if !hasDefault && !hasEnum {
+			return
+		}
+		if r.frameworkAttributeValues == nil {
+			r.frameworkAttributeValues = map[string]FrameworkAttributeValues{}
+		}
+		r.frameworkAttributeValues[fieldPath] = FrameworkAttributeValues{Default: d, Enum: values}
+	})
+	return nil
+}
+
+// frameworkDefault returns the default value of the specified framework
+// attribute if it has a known scalar default. The numbers are returned as
+// float64s as the numbers in the Terraform schemas converted from the
+// framework schemas are floats.
+func frameworkDefault(attribute any) (any, bool) { //nolint:gocyclo // a switch over the attribute types
+	ctx := context.TODO()
+	var v interface {
+		IsNull() bool
+		IsUnknown() bool
+	}
+	var val any
+	switch a := attribute.(type) {
+	case rschema.StringAttribute:
+		if a.Default == nil {
+			return nil, false
+		}
+		resp := &defaults.StringResponse{}
+		a.Default.DefaultString(ctx, defaults.StringRequest{}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, false
+		}
+		v, val = resp.PlanValue, resp.PlanValue.ValueString()
+	case rschema.BoolAttribute:
+		if a.Default == nil {
+			return nil, false
+		}
+		resp := &defaults.BoolResponse{}
+		a.Default.DefaultBool(ctx, defaults.BoolRequest{}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, false
+		}
+		v, val = resp.PlanValue, resp.PlanValue.ValueBool()
+	case rschema.Int64Attribute:
+		if a.Default == nil {
+			return nil, false
+		}
+		resp := &defaults.Int64Response{}
+		a.Default.DefaultInt64(ctx, defaults.Int64Request{}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, false
+		}
+		v, val = resp.PlanValue, float64(resp.PlanValue.ValueInt64())
+	case rschema.Int32Attribute:
+		if a.Default == nil {
+			return nil, false
+		}
+		resp := &defaults.Int32Response{}
+		a.Default.DefaultInt32(ctx, defaults.Int32Request{}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, false
+		}
+		v, val = resp.PlanValue, float64(resp.PlanValue.ValueInt32())
+	case rschema.Float64Attribute:
+		if a.Default == nil {
+			return nil, false
+		}
+		resp := &defaults.Float64Response{}
+		a.Default.DefaultFloat64(ctx, defaults.Float64Request{}, resp)
+		if resp.Diagnostics.HasError() {
+			return nil, false
+		}
+		v, val = resp.PlanValue, resp.PlanValue.ValueFloat64()
+	default:
+		return nil, false
+	}
+	if v.IsNull() || v.IsUnknown() {
+		return nil, false
+	}
+	return val, true
+}
+
+// frameworkOneOfValues returns the allowed values of the specified framework
+// attribute if it has a stringvalidator.OneOf validator. The validator keeps
+// the values in an unexported field, so we read them via reflection.
+func frameworkOneOfValues(attribute any) ([]string, bool) {
+	if _, ok := attribute.(rschema.StringAttribute); !ok {
+		return nil, false
+	}
+	for _, v := range frameworkValidators(attribute) {
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+		v = reflect.Indirect(v)
+		if v.Kind() != reflect.Struct || v.Type().Name() != fwOneOfValidator || !strings.HasSuffix(v.Type().PkgPath(), fwStringValidatorSuffix) {
+			continue
+		}
+		values := v.FieldByName("values")
+		if !values.IsValid() || values.Kind() != reflect.Slice || values.Len() == 0 {
+			continue
+		}
+		result := make([]string, 0, values.Len())
+		for i := range values.Len() {
+			s, ok := stringValue(values.Index(i))
+			if !ok {
+				return nil, false
+			}
+			result = append(result, s)
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+// stringValue returns the string held by the specified value which is either
+// a string or a known basetypes.StringValue.
+func stringValue(v reflect.Value) (string, bool) {
+	switch {
+	case v.Kind() == reflect.String:
+		return v.String(), true
+	case v.Type() == reflect.TypeFor[basetypes.StringValue]():
+		// we cannot call the methods of a value obtained from an unexported
+		// field, so we read its unexported fields instead.
+		state, value := v.FieldByName("state"), v.FieldByName("value")
+		if !state.IsValid() || !value.IsValid() || state.Kind() != reflect.Uint8 || state.Uint() != uint64(attr.ValueStateKnown) {
+			return "", false
+		}
+		return value.String(), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type defaultsTestResource struct {
+	fwresource.Resource
+}
+
+func (defaultsTestResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"name": rschema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("default"),
+			},
+			"count": rschema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(3),
+			},
+			"enabled": rschema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"other": rschema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]rschema.Block{
+			"block": rschema.ListNestedBlock{
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{
+						"x": rschema.StringAttribute{
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString("x"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCollectFrameworkDefaults(t *testing.T) {
+	r := &Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":    {Type: schema.TypeString, Optional: true, Computed: true},
+				"count":   {Type: schema.TypeFloat, Optional: true, Computed: true},
+				"enabled": {Type: schema.TypeBool, Optional: true, Computed: true},
+				"other":   {Type: schema.TypeString, Optional: true},
+				"block": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"x": {Type: schema.TypeString, Optional: true, Computed: true},
+						},
+					},
+				},
+			},
+		},
+		TerraformPluginFrameworkResource: defaultsTestResource{},
+	}
+	if err := collectFrameworkDefaults("test_resource", r); err != nil {
+		t.Fatalf("collectFrameworkDefaults(...): unexpected error: %v", err)
+	}
+
+	want := map[string]FrameworkAttributeValues{
+		"name":    {Default: "default"},
+		"count":   {Default: float64(3)},
+		"enabled": {Default: true},
+		"block.x": {Default: "x"},
+	}
+	if diff := cmp.Diff(want, r.frameworkAttributeValues); diff != "" {
+		t.Errorf("collectFrameworkDefaults(...): -want values, +got values:\n%s", diff)
+	}
+	if _, ok := r.FrameworkAttributeValues("other"); ok {
+		t.Errorf("FrameworkAttributeValues(%q): got values for an attribute without a default", "other")
+	}
+	// the Terraform schema, which may be shared, must not be modified.
+	for _, k := range []string{"name", "count", "enabled", "other", "block.x"} {
+		if d := GetSchema(r.TerraformResource, k).Default; d != nil {
+			t.Errorf("collectFrameworkDefaults(...): Terraform schema of %q got a default: %v", k, d)
+		}
+	}
+}
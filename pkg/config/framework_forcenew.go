@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// fwRequiresReplaceDescription is the description of the unconditional
+// RequiresReplace plan modifiers of the Terraform Plugin Framework, e.g.,
+// stringplanmodifier.RequiresReplace. These plan modifiers are implemented
+// with the same type as the conditional RequiresReplaceIf plan modifiers, so
+// we distinguish them by their descriptions.
+const fwRequiresReplaceDescription = "If the value of this attribute changes, Terraform will destroy and recreate the resource."
+
+// injectFrameworkForceNew marks the Terraform schemas of the attributes of
+// the specified Terraform Plugin Framework resource that have an
+// unconditional RequiresReplace plan modifier as ForceNew, as the
+// conversion from the framework schema does not preserve the plan
+// modifiers.
+func injectFrameworkForceNew(name string, r *Resource) error {
+	if r.TerraformPluginFrameworkResource == nil || r.TerraformResource == nil {
+		return nil
+	}
+	s, err := frameworkResourceSchema(name, r.TerraformPluginFrameworkResource)
+	if err != nil {
+		return err
+	}
+	walkFrameworkSchema(s.Attributes, s.Blocks, fwpath.Expression{}, func(e fwpath.Expression, a any) {
+		if !frameworkRequiresReplace(a) {
+			return
+		}
+		if sch := GetSchema(r.TerraformResource, schemaFieldPath(sdkSchemaKey(e))); sch != nil {
+			sch.ForceNew = true
+		}
+	})
+	return nil
+}
+
+// frameworkRequiresReplace returns true if the specified framework attribute
+// or block has an unconditional RequiresReplace plan modifier.
+func frameworkRequiresReplace(attr any) bool {
+	for _, v := range frameworkPlanModifiers(attr) {
+		if !v.CanInterface() {
+			continue
+		}
+		m, ok := v.Interface().(interface {
+			Description(ctx context.Context) string
+		})
+		if ok && m.Description(context.TODO()) == fwRequiresReplaceDescription {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type forceNewTestResource struct {
+	fwresource.Resource
+}
+
+func (forceNewTestResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"name": rschema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"count": rschema.Int64Attribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.UseStateForUnknown(), int64planmodifier.RequiresReplace()},
+			},
+			"configured": rschema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplaceIfConfigured()},
+			},
+			"other": rschema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]rschema.Block{
+			"block": rschema.ListNestedBlock{
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+				NestedObject: rschema.NestedBlockObject{
+					Attributes: map[string]rschema.Attribute{
+						"x": rschema.StringAttribute{
+							Optional:      true,
+							PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestInjectFrameworkForceNew(t *testing.T) {
+	r := &Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name":       {Type: schema.TypeString, Optional: true},
+				"count":      {Type: schema.TypeFloat, Optional: true},
+				"configured": {Type: schema.TypeString, Optional: true},
+				"other":      {Type: schema.TypeString, Optional: true},
+				"block": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"x": {Type: schema.TypeString, Optional: true},
+						},
+					},
+				},
+			},
+		},
+		TerraformPluginFrameworkResource: forceNewTestResource{},
+	}
+	if err := injectFrameworkForceNew("test_resource", r); err != nil {
+		t.Fatalf("injectFrameworkForceNew(...): unexpected error: %v", err)
+	}
+
+	want := map[string]bool{
+		"name":       true,
+		"count":      true,
+		"configured": false,
+		"other":      false,
+		"block":      true,
+		"block.x":    true,
+	}
+	got := make(map[string]bool, len(want))
+	for k := range want {
+		got[k] = GetSchema(r.TerraformResource, k).ForceNew
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("injectFrameworkForceNew(...): -want ForceNew, +got ForceNew:\n%s", diff)
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"regexp"
 
 	tfjson "github.com/hashicorp/terraform-json"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwephemeral "github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -150,6 +152,22 @@ type Provider struct {
 	// to ensure backwards-compatibility.
 	TerraformedTemplate string
 
+	// GenerationParallelism is the maximum number of API groups for which
+	// the code generation pipelines run concurrently. If this is not set,
+	// the number of the logical CPUs usable by the generator is used.
+	GenerationParallelism int
+
+	// IncrementalGeneration enables skipping the code generation for the API
+	// groups whose resource configurations & Terraform schemas have not
+	// changed since the previous run. The fingerprints of the previous run
+	// are stored in the hack directory.
+	IncrementalGeneration bool
+
+	// APIReferenceDocs enables generating the Markdown API reference
+	// documentation pages of the managed resources under the docs/api
+	// directory.
+	APIReferenceDocs bool
+
 	// skippedResourceNames is a list of Terraform resource names
 	// available in the Terraform provider schema, but
 	// not in the include list or in the skip list, meaning that
@@ -182,10 +200,41 @@ type Provider struct {
 	// Defaults to []string{".+"} which would include all resources.
 	TerraformPluginFrameworkIncludeList []string
 
+	// TerraformPluginSDKDataSourceIncludeList is a list of regex for the
+	// Terraform data sources implemented with Terraform Plugin SDKv2 to be
+	// generated as observe-only managed resources.
+	// For example, to include "aws_ami" into the generated data sources,
+	// one can add "aws_ami$".
+	// Defaults to an empty list, i.e., no data sources are generated.
+	TerraformPluginSDKDataSourceIncludeList []string
+
+	// TerraformPluginFrameworkDataSourceIncludeList is a list of regex for the
+	// Terraform data sources implemented with Terraform Plugin Framework to be
+	// generated as observe-only managed resources.
+	// Defaults to an empty list, i.e., no data sources are generated.
+	TerraformPluginFrameworkDataSourceIncludeList []string
+
+	// TerraformPluginFrameworkEphemeralResourceIncludeList is a list of regex
+	// for the Terraform ephemeral resources implemented with Terraform Plugin
+	// Framework to be generated as managed resources publishing their results
+	// as connection secrets.
+	// For example, to include "random_password" into the generated ephemeral
+	// resources, one can add "random_password$".
+	// Defaults to an empty list, i.e., no ephemeral resources are generated.
+	TerraformPluginFrameworkEphemeralResourceIncludeList []string
+
 	// Resources is a map holding resource configurations where key is Terraform
 	// resource name.
 	Resources map[string]*Resource
 
+	// DataSources is a map holding data source configurations where key is
+	// Terraform data source name.
+	DataSources map[string]*DataSource
+
+	// EphemeralResources is a map holding ephemeral resource configurations
+	// where key is Terraform ephemeral resource name.
+	EphemeralResources map[string]*EphemeralResource
+
 	// TerraformProvider is the Terraform provider in Terraform Plugin SDKv2
 	// compatible format
 	TerraformProvider *schema.Provider
@@ -194,6 +243,11 @@ type Provider struct {
 	// in Terraform Plugin Framework compatible format
 	TerraformPluginFrameworkProvider fwprovider.Provider
 
+	// TerraformProviderBinary is the path of the native Terraform provider
+	// binary from which the Terraform JSON schema of the provider is read
+	// over the plugin gRPC protocol, if no schema is passed to NewProvider.
+	TerraformProviderBinary string
+
 	// ExampleManifestConfiguration is the optional example manifest
 	// generation pipeline configuration for the provider.
 	ExampleManifestConfiguration ExampleManifestConfiguration
@@ -206,6 +260,14 @@ type Provider struct {
 	// is Terraform resource name.
 	resourceConfigurators map[string]ResourceConfiguratorChain
 
+	// dataSourceConfigurators is a map holding data source configurators
+	// where key is Terraform data source name.
+	dataSourceConfigurators map[string]ResourceConfiguratorChain
+
+	// ephemeralResourceConfigurators is a map holding ephemeral resource
+	// configurators where key is Terraform ephemeral resource name.
+	ephemeralResourceConfigurators map[string]ResourceConfiguratorChain
+
 	// schemaTraversers is a chain of schema traversers to be used with
 	// this Provider configuration. Schema traversers can be used to inspect or
 	// modify the Provider configuration based on the underlying Terraform
@@ -260,6 +322,33 @@ func WithTerraformPluginFrameworkIncludeList(l []string) ProviderOption {
 	}
 }
 
+// WithTerraformPluginSDKDataSourceIncludeList configures the
+// TerraformPluginSDKDataSourceIncludeList for this Provider, with the given
+// Terraform Plugin SDKv2-based data source name list
+func WithTerraformPluginSDKDataSourceIncludeList(l []string) ProviderOption {
+	return func(p *Provider) {
+		p.TerraformPluginSDKDataSourceIncludeList = l
+	}
+}
+
+// WithTerraformPluginFrameworkDataSourceIncludeList configures the
+// TerraformPluginFrameworkDataSourceIncludeList for this Provider, with the
+// given Terraform Plugin Framework-based data source name list
+func WithTerraformPluginFrameworkDataSourceIncludeList(l []string) ProviderOption {
+	return func(p *Provider) {
+		p.TerraformPluginFrameworkDataSourceIncludeList = l
+	}
+}
+
+// WithTerraformPluginFrameworkEphemeralResourceIncludeList configures the
+// TerraformPluginFrameworkEphemeralResourceIncludeList for this Provider, with
+// the given Terraform Plugin Framework-based ephemeral resource name list
+func WithTerraformPluginFrameworkEphemeralResourceIncludeList(l []string) ProviderOption {
+	return func(p *Provider) {
+		p.TerraformPluginFrameworkEphemeralResourceIncludeList = l
+	}
+}
+
 // WithTerraformProvider configures the TerraformProvider for this Provider.
 func WithTerraformProvider(tp *schema.Provider) ProviderOption {
 	return func(p *Provider) {
@@ -275,6 +364,17 @@ func WithTerraformPluginFrameworkProvider(tp fwprovider.Provider) ProviderOption
 	}
 }
 
+// WithTerraformProviderBinary configures the TerraformProviderBinary for
+// this Provider, i.e., the path of the native Terraform provider binary from
+// which the Terraform JSON schema of the provider is read if no schema is
+// passed to NewProvider. This allows generating the provider without
+// importing the Go module of the native provider.
+func WithTerraformProviderBinary(path string) ProviderOption {
+	return func(p *Provider) {
+		p.TerraformProviderBinary = path
+	}
+}
+
 // WithSkipList configures SkipList for this Provider.
 func WithSkipList(l []string) ProviderOption {
 	return func(p *Provider) {
@@ -352,6 +452,31 @@ func WithTerraformedTemplate(template string) ProviderOption {
 	}
 }
 
+// WithGenerationParallelism configures the maximum number of API groups for
+// which the code generation pipelines run concurrently.
+func WithGenerationParallelism(n int) ProviderOption {
+	return func(p *Provider) {
+		p.GenerationParallelism = n
+	}
+}
+
+// WithIncrementalGeneration enables skipping the code generation for the API
+// groups whose resource configurations & Terraform schemas have not changed
+// since the previous run.
+func WithIncrementalGeneration() ProviderOption {
+	return func(p *Provider) {
+		p.IncrementalGeneration = true
+	}
+}
+
+// WithAPIReferenceDocs enables generating the Markdown API reference
+// documentation pages of the managed resources.
+func WithAPIReferenceDocs() ProviderOption {
+	return func(p *Provider) {
+		p.APIReferenceDocs = true
+	}
+}
+
 // WithSchemaTraversers configures a chain of schema traversers to be used with
 // this Provider configuration. Schema traversers can be used to inspect or
 // modify the Provider configuration based on the underlying Terraform
@@ -373,20 +498,9 @@ func WithExampleManifestConfiguration(emc ExampleManifestConfiguration) Provider
 // NewProvider builds and returns a new Provider from provider
 // tfjson schema, that is generated using Terraform CLI with:
 // `terraform providers schema --json`
+// If the schema is empty, it's read from the native provider binary
+// configured with WithTerraformProviderBinary.
 func NewProvider(schema []byte, prefix string, modulePath string, metadata []byte, opts ...ProviderOption) *Provider { //nolint:gocyclo
-	ps := tfjson.ProviderSchemas{}
-	if err := ps.UnmarshalJSON(schema); err != nil {
-		panic(errors.Wrap(err, "failed to unmarshal the Terraform JSON schema"))
-	}
-	if len(ps.Schemas) != 1 {
-		panic(fmt.Sprintf("there should exactly be 1 provider schema but there are %d", len(ps.Schemas)))
-	}
-	var rs map[string]*tfjson.Schema
-	for _, v := range ps.Schemas {
-		rs = v.ResourceSchemas
-		break
-	}
-	resourceMap := conversiontfjson.GetV2ResourceMap(rs)
 	providerMetadata, err := registry.NewProviderMetadataFromFile(metadata)
 	if err != nil {
 		panic(errors.Wrap(err, "cannot load provider metadata"))
@@ -402,14 +516,22 @@ func NewProvider(schema []byte, prefix string, modulePath string, metadata []byt
 			// Include all Resources
 			".+",
 		},
-		Resources:             map[string]*Resource{},
-		resourceConfigurators: map[string]ResourceConfiguratorChain{},
+		Resources:                      map[string]*Resource{},
+		DataSources:                    map[string]*DataSource{},
+		EphemeralResources:             map[string]*EphemeralResource{},
+		resourceConfigurators:          map[string]ResourceConfiguratorChain{},
+		dataSourceConfigurators:        map[string]ResourceConfiguratorChain{},
+		ephemeralResourceConfigurators: map[string]ResourceConfiguratorChain{},
 	}
 
 	for _, o := range opts {
 		o(p)
 	}
 
+	ps := p.providerSchema(schema)
+	resourceMap := conversiontfjson.GetV2ResourceMap(ps.ResourceSchemas)
+	dataSourceMap := conversiontfjson.GetV2ResourceMap(ps.DataSourceSchemas)
+	ephemeralResourceMap := conversiontfjson.GetV2ResourceMap(ps.EphemeralResourceSchemas)
 	p.skippedResourceNames = make([]string, 0, len(resourceMap))
 	terraformPluginFrameworkResourceFunctionsMap := terraformPluginFrameworkResourceFunctionsMap(p.TerraformPluginFrameworkProvider)
 	for name, terraformResource := range resourceMap {
@@ -484,9 +606,19 @@ func NewProvider(schema []byte, prefix string, modulePath string, metadata []byt
 				p.Resources[name].dynamicAttributeConversionPaths = paths
 				p.Resources[name].TerraformConversions = append(p.Resources[name].TerraformConversions, NewTFDynamicValueConversion())
 			}
-
+			if err := injectFrameworkConstraints(name, p.Resources[name]); err != nil {
+				panic(errors.Wrapf(err, "failed to translate the validators of the framework resource %q", name))
+			}
+			if err := collectFrameworkDefaults(name, p.Resources[name]); err != nil {
+				panic(errors.Wrapf(err, "failed to translate the defaults of the framework resource %q", name))
+			}
+			if err := injectFrameworkForceNew(name, p.Resources[name]); err != nil {
+				panic(errors.Wrapf(err, "failed to translate the plan modifiers of the framework resource %q", name))
+			}
 		}
 	}
+	p.addDataSources(dataSourceMap)
+	p.addEphemeralResources(ephemeralResourceMap)
 	for i, refInjector := range p.refInjectors {
 		if err := refInjector.InjectReferences(p.Resources); err != nil {
 			panic(errors.Wrapf(err, "cannot inject references using the configured ReferenceInjector at index %d", i))
@@ -495,6 +627,30 @@ func NewProvider(schema []byte, prefix string, modulePath string, metadata []byt
 	return p
 }
 
+// providerSchema returns the Terraform JSON schema of the provider parsed from
+// the specified schema document or, if the document is empty, read from the
+// configured native provider binary.
+func (p *Provider) providerSchema(schema []byte) *tfjson.ProviderSchema {
+	if len(schema) == 0 && p.TerraformProviderBinary != "" {
+		ps, err := readProviderSchema(context.Background(), p.TerraformProviderBinary, providerPluginConfig(p.TerraformProviderBinary))
+		if err != nil {
+			panic(errors.Wrap(err, "failed to read the Terraform JSON schema from the provider binary"))
+		}
+		return ps
+	}
+	ps := tfjson.ProviderSchemas{}
+	if err := ps.UnmarshalJSON(schema); err != nil {
+		panic(errors.Wrap(err, "failed to unmarshal the Terraform JSON schema"))
+	}
+	if len(ps.Schemas) != 1 {
+		panic(fmt.Sprintf("there should exactly be 1 provider schema but there are %d", len(ps.Schemas)))
+	}
+	for _, v := range ps.Schemas {
+		return v
+	}
+	return nil
+}
+
 // AddResourceConfigurator adds resource specific configurators.
 func (p *Provider) AddResourceConfigurator(resource string, c ResourceConfiguratorFn) {
 	// Note(turkenh): nolint reasoning - easier to provide a function without
@@ -504,6 +660,17 @@ func (p *Provider) AddResourceConfigurator(resource string, c ResourceConfigurat
 	p.resourceConfigurators[resource] = append(p.resourceConfigurators[resource], c)
 }
 
+// AddDataSourceConfigurator adds data source specific configurators.
+func (p *Provider) AddDataSourceConfigurator(dataSource string, c ResourceConfiguratorFn) {
+	p.dataSourceConfigurators[dataSource] = append(p.dataSourceConfigurators[dataSource], c)
+}
+
+// AddEphemeralResourceConfigurator adds ephemeral resource specific
+// configurators.
+func (p *Provider) AddEphemeralResourceConfigurator(ephemeralResource string, c ResourceConfiguratorFn) {
+	p.ephemeralResourceConfigurators[ephemeralResource] = append(p.ephemeralResourceConfigurators[ephemeralResource], c)
+}
+
 // SetResourceConfigurator sets ResourceConfigurator for a resource. This will
 // override all previously added ResourceConfigurators for this resource.
 func (p *Provider) SetResourceConfigurator(resource string, c ResourceConfigurator) {
@@ -518,6 +685,67 @@ func (p *Provider) ConfigureResources() {
 			c.Configure(r)
 		}
 	}
+	for name, c := range p.dataSourceConfigurators {
+		if ds, ok := p.DataSources[name]; ok {
+			c.Configure(ds.Resource)
+		}
+	}
+	for name, c := range p.ephemeralResourceConfigurators {
+		if er, ok := p.EphemeralResources[name]; ok {
+			c.Configure(er.Resource)
+		}
+	}
+}
+
+// addDataSources initializes the configurations of the Terraform data sources
+// that match the data source include lists of this Provider.
+func (p *Provider) addDataSources(dataSourceMap map[string]*schema.Resource) {
+	terraformPluginFrameworkDataSourceFunctionsMap := terraformPluginFrameworkDataSourceFunctionsMap(p.TerraformPluginFrameworkProvider)
+	for name, terraformDataSource := range dataSourceMap {
+		isTerraformPluginSDK := matches(name, p.TerraformPluginSDKDataSourceIncludeList)
+		isPluginFrameworkDataSource := matches(name, p.TerraformPluginFrameworkDataSourceIncludeList)
+		if isTerraformPluginSDK && isPluginFrameworkDataSource {
+			panic(errors.Errorf(`data source %q is specified in more than one include list. It should appear in at most one of the lists "TerraformPluginSDKDataSourceIncludeList" or "TerraformPluginFrameworkDataSourceIncludeList"`, name))
+		}
+		if !isTerraformPluginSDK && !isPluginFrameworkDataSource {
+			continue
+		}
+		if len(terraformDataSource.Schema) == 0 {
+			fmt.Printf("Skipping data source %s because it has no schema\n", name)
+			continue
+		}
+		if isTerraformPluginSDK {
+			if p.TerraformProvider == nil || p.TerraformProvider.DataSourcesMap[name] == nil {
+				panic(errors.Errorf("data source %q is configured to be read with Terraform Plugin SDK "+
+					"but either config.Provider.TerraformProvider is not configured or the Go schema does not exist for the data source", name))
+			}
+			terraformDataSource = p.TerraformProvider.DataSourcesMap[name]
+			if terraformDataSource.Schema == nil {
+				if terraformDataSource.SchemaFunc == nil {
+					fmt.Printf("Skipping data source %s because it has no schema and no schema function\n", name)
+					continue
+				}
+				terraformDataSource.Schema = terraformDataSource.SchemaFunc()
+			}
+		}
+
+		var terraformPluginFrameworkDataSource fwdatasource.DataSource
+		if isPluginFrameworkDataSource {
+			dataSourceFunc := terraformPluginFrameworkDataSourceFunctionsMap[name]
+			if p.TerraformPluginFrameworkProvider == nil || dataSourceFunc == nil {
+				panic(errors.Errorf("data source %q is configured to be read with Terraform Plugin Framework "+
+					"but either config.Provider.TerraformPluginFrameworkProvider is not configured or the provider doesn't have the data source.", name))
+			}
+			terraformPluginFrameworkDataSource = dataSourceFunc()
+		}
+
+		p.DataSources[name] = DefaultDataSource(name, terraformDataSource, terraformPluginFrameworkDataSource, p.DefaultResourceOptions...)
+		p.DataSources[name].useTerraformPluginSDKClient = isTerraformPluginSDK
+		p.DataSources[name].useTerraformPluginFrameworkClient = isPluginFrameworkDataSource
+		if err := TraverseSchemas(name, p.DataSources[name].Resource, p.schemaTraversers...); err != nil {
+			panic(errors.Wrap(err, "failed to execute the Terraform schema traverser chain"))
+		}
+	}
 }
 
 // GetSkippedResourceNames returns a list of Terraform resource names
@@ -567,3 +795,83 @@ func terraformPluginFrameworkResourceFunctionsMap(provider fwprovider.Provider)
 
 	return resourceFunctionsMap
 }
+
+// addEphemeralResources initializes the configurations of the Terraform
+// ephemeral resources that match the ephemeral resource include list of this
+// Provider.
+func (p *Provider) addEphemeralResources(ephemeralResourceMap map[string]*schema.Resource) {
+	terraformPluginFrameworkEphemeralResourceFunctionsMap := terraformPluginFrameworkEphemeralResourceFunctionsMap(p.TerraformPluginFrameworkProvider)
+	for name, terraformEphemeralResource := range ephemeralResourceMap {
+		if !matches(name, p.TerraformPluginFrameworkEphemeralResourceIncludeList) {
+			continue
+		}
+		if len(terraformEphemeralResource.Schema) == 0 {
+			fmt.Printf("Skipping ephemeral resource %s because it has no schema\n", name)
+			continue
+		}
+		ephemeralResourceFunc := terraformPluginFrameworkEphemeralResourceFunctionsMap[name]
+		if p.TerraformPluginFrameworkProvider == nil || ephemeralResourceFunc == nil {
+			panic(errors.Errorf("ephemeral resource %q is configured to be reconciled with Terraform Plugin Framework "+
+				"but either config.Provider.TerraformPluginFrameworkProvider is not configured or the provider doesn't have the ephemeral resource.", name))
+		}
+		p.EphemeralResources[name] = DefaultEphemeralResource(name, terraformEphemeralResource, ephemeralResourceFunc(), p.DefaultResourceOptions...)
+		if err := TraverseSchemas(name, p.EphemeralResources[name].Resource, p.schemaTraversers...); err != nil {
+			panic(errors.Wrap(err, "failed to execute the Terraform schema traverser chain"))
+		}
+	}
+}
+
+func terraformPluginFrameworkDataSourceFunctionsMap(provider fwprovider.Provider) map[string]func() fwdatasource.DataSource {
+	if provider == nil {
+		return make(map[string]func() fwdatasource.DataSource, 0)
+	}
+
+	ctx := context.TODO()
+	dataSourceFunctions := provider.DataSources(ctx)
+	dataSourceFunctionsMap := make(map[string]func() fwdatasource.DataSource, len(dataSourceFunctions))
+
+	providerMetadata := fwprovider.MetadataResponse{}
+	provider.Metadata(ctx, fwprovider.MetadataRequest{}, &providerMetadata)
+
+	for _, dataSourceFunction := range dataSourceFunctions {
+		dataSource := dataSourceFunction()
+
+		dataSourceTypeNameReq := fwdatasource.MetadataRequest{
+			ProviderTypeName: providerMetadata.TypeName,
+		}
+		dataSourceTypeNameResp := fwdatasource.MetadataResponse{}
+		dataSource.Metadata(ctx, dataSourceTypeNameReq, &dataSourceTypeNameResp)
+
+		dataSourceFunctionsMap[dataSourceTypeNameResp.TypeName] = dataSourceFunction
+	}
+
+	return dataSourceFunctionsMap
+}
+
+func terraformPluginFrameworkEphemeralResourceFunctionsMap(provider fwprovider.Provider) map[string]func() fwephemeral.EphemeralResource {
+	p, ok := provider.(fwprovider.ProviderWithEphemeralResources)
+	if !ok {
+		return make(map[string]func() fwephemeral.EphemeralResource, 0)
+	}
+
+	ctx := context.TODO()
+	ephemeralResourceFunctions := p.EphemeralResources(ctx)
+	ephemeralResourceFunctionsMap := make(map[string]func() fwephemeral.EphemeralResource, len(ephemeralResourceFunctions))
+
+	providerMetadata := fwprovider.MetadataResponse{}
+	p.Metadata(ctx, fwprovider.MetadataRequest{}, &providerMetadata)
+
+	for _, ephemeralResourceFunction := range ephemeralResourceFunctions {
+		ephemeralResource := ephemeralResourceFunction()
+
+		ephemeralResourceTypeNameReq := fwephemeral.MetadataRequest{
+			ProviderTypeName: providerMetadata.TypeName,
+		}
+		ephemeralResourceTypeNameResp := fwephemeral.MetadataResponse{}
+		ephemeralResource.Metadata(ctx, ephemeralResourceTypeNameReq, &ephemeralResourceTypeNameResp)
+
+		ephemeralResourceFunctionsMap[ephemeralResourceTypeNameResp.TypeName] = ephemeralResourceFunction
+	}
+
+	return ephemeralResourceFunctionsMap
+}
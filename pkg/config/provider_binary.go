@@ -0,0 +1,331 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	goplugin "github.com/hashicorp/go-plugin"
+	tfjson "github.com/hashicorp/terraform-json"
+	// the servers register the protocol buffer types of the plugin
+	// protocols, which we use to call the native providers.
+	_ "github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	_ "github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/pkg/errors"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	// the handshake configuration of the Terraform plugin protocol
+	pluginMagicCookieKey   = "TF_PLUGIN_MAGIC_COOKIE"
+	pluginMagicCookieValue = "d602bf8f470bc67ca7faa0386276bbdd4330efaf76d1a219cb4d6991ca9872b2"
+	pluginName             = "provider"
+
+	errFmtStartPlugin       = "cannot start the native provider %q"
+	errFmtDispensePlugin    = "cannot connect to the native provider %q"
+	errFmtPluginProtocol    = "unsupported plugin protocol version %d of the native provider %q"
+	errFmtGetProviderSchema = "cannot get the schema of the native provider %q"
+	errFmtDecodeSchema      = "cannot decode the schema of the native provider %q"
+	errFmtSchemaDiagnostics = "cannot get the schema of the native provider %q: %s"
+)
+
+// grpcPlugin is a go-plugin plugin that dispenses the gRPC client connection
+// to a native provider.
+type grpcPlugin struct {
+	goplugin.NetRPCUnsupportedPlugin
+}
+
+func (grpcPlugin) GRPCServer(*goplugin.GRPCBroker, *grpc.Server) error {
+	return errors.New("serving the native provider is not supported")
+}
+
+func (grpcPlugin) GRPCClient(_ context.Context, _ *goplugin.GRPCBroker, conn *grpc.ClientConn) (any, error) {
+	return conn, nil
+}
+
+// providerPluginConfig returns the go-plugin client configuration to start
+// the native provider binary at the specified path.
+func providerPluginConfig(path string) *goplugin.ClientConfig {
+	return &goplugin.ClientConfig{
+		Cmd:              exec.Command(path),
+		AllowedProtocols: []goplugin.Protocol{goplugin.ProtocolGRPC},
+		AutoMTLS:         true,
+		Managed:          true,
+	}
+}
+
+// readProviderSchema starts the native provider with the specified go-plugin
+// client configuration, reads its schema via the GetProviderSchema RPC of the
+// plugin gRPC protocol and stops the native provider. The schema is returned
+// as the Terraform JSON schema of the provider, i.e., like the output of
+// `terraform providers schema -json`, so that it carries the nested
+// attributes and the write-only attributes of the provider.
+func readProviderSchema(ctx context.Context, name string, cfg *goplugin.ClientConfig) (*tfjson.ProviderSchema, error) {
+	cfg.HandshakeConfig = goplugin.HandshakeConfig{
+		MagicCookieKey:   pluginMagicCookieKey,
+		MagicCookieValue: pluginMagicCookieValue,
+	}
+	cfg.VersionedPlugins = map[int]goplugin.PluginSet{
+		5: {pluginName: grpcPlugin{}},
+		6: {pluginName: grpcPlugin{}},
+	}
+	version := 0
+	if cfg.Reattach != nil {
+		// the protocol version is not negotiated when reattaching to a
+		// running provider.
+		version = cfg.Reattach.ProtocolVersion
+		cfg.Plugins = cfg.VersionedPlugins[version]
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = hclog.NewNullLogger()
+	}
+	client := goplugin.NewClient(cfg)
+	defer client.Kill()
+
+	rpcClient, err := client.Client()
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtStartPlugin, name)
+	}
+	raw, err := rpcClient.Dispense(pluginName)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtDispensePlugin, name)
+	}
+	conn, ok := raw.(*grpc.ClientConn)
+	if !ok {
+		return nil, errors.Errorf(errFmtDispensePlugin, name)
+	}
+	if cfg.Reattach == nil {
+		version = client.NegotiatedVersion()
+	}
+	var pkg, method string
+	switch version {
+	case 5:
+		pkg, method = "tfplugin5", "GetSchema"
+	case 6:
+		pkg, method = "tfplugin6", "GetProviderSchema"
+	default:
+		return nil, errors.Errorf(errFmtPluginProtocol, version, name)
+	}
+	req, err := newPluginMessage(pkg + ".GetProviderSchema.Request")
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtGetProviderSchema, name)
+	}
+	resp, err := newPluginMessage(pkg + ".GetProviderSchema.Response")
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtGetProviderSchema, name)
+	}
+	// the schemas of the large providers exceed the default limit of 4MB.
+	if err := conn.Invoke(ctx, "/"+pkg+".Provider/"+method, req.Interface(), resp.Interface(), grpc.MaxCallRecvMsgSize(math.MaxInt32)); err != nil {
+		return nil, errors.Wrapf(err, errFmtGetProviderSchema, name)
+	}
+	b, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(resp.Interface())
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtDecodeSchema, name)
+	}
+	ps := &pluginProviderSchema{}
+	if err := json.Unmarshal(b, ps); err != nil {
+		return nil, errors.Wrapf(err, errFmtDecodeSchema, name)
+	}
+	var diags []string
+	for _, d := range ps.Diagnostics {
+		if d.Severity == "ERROR" {
+			diags = append(diags, strings.TrimSpace(d.Summary+": "+d.Detail))
+		}
+	}
+	if len(diags) > 0 {
+		return nil, errors.Errorf(errFmtSchemaDiagnostics, name, strings.Join(diags, "; "))
+	}
+	result, err := ps.toTFJSON()
+	return result, errors.Wrapf(err, errFmtDecodeSchema, name)
+}
+
+// newPluginMessage returns a new message of the protocol buffer type with the
+// specified full name from the plugin protocols.
+func newPluginMessage(name string) (protoreflect.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot find the plugin protocol message %q", name)
+	}
+	return mt.New(), nil
+}
+
+// pluginProviderSchema is the protocol buffer JSON representation of the
+// GetProviderSchema response of the plugin protocol versions 5 & 6.
+type pluginProviderSchema struct {
+	Provider                 *pluginSchema            `json:"provider"`
+	ResourceSchemas          map[string]*pluginSchema `json:"resource_schemas"`
+	DataSourceSchemas        map[string]*pluginSchema `json:"data_source_schemas"`
+	EphemeralResourceSchemas map[string]*pluginSchema `json:"ephemeral_resource_schemas"`
+	Diagnostics              []pluginDiagnostic       `json:"diagnostics"`
+}
+
+type pluginDiagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail"`
+}
+
+type pluginSchema struct {
+	Version int64        `json:"version,string"`
+	Block   *pluginBlock `json:"block"`
+}
+
+type pluginBlock struct {
+	Attributes      []*pluginAttribute   `json:"attributes"`
+	BlockTypes      []*pluginNestedBlock `json:"block_types"`
+	Description     string               `json:"description"`
+	DescriptionKind string               `json:"description_kind"`
+	Deprecated      bool                 `json:"deprecated"`
+}
+
+type pluginAttribute struct {
+	Name            string        `json:"name"`
+	Type            []byte        `json:"type"`
+	NestedType      *pluginObject `json:"nested_type"`
+	Description     string        `json:"description"`
+	DescriptionKind string        `json:"description_kind"`
+	Required        bool          `json:"required"`
+	Optional        bool          `json:"optional"`
+	Computed        bool          `json:"computed"`
+	Sensitive       bool          `json:"sensitive"`
+	Deprecated      bool          `json:"deprecated"`
+	WriteOnly       bool          `json:"write_only"`
+}
+
+type pluginNestedBlock struct {
+	TypeName string       `json:"type_name"`
+	Block    *pluginBlock `json:"block"`
+	Nesting  string       `json:"nesting"`
+	MinItems int64        `json:"min_items,string"`
+	MaxItems int64        `json:"max_items,string"`
+}
+
+type pluginObject struct {
+	Attributes []*pluginAttribute `json:"attributes"`
+	Nesting    string             `json:"nesting"`
+}
+
+func (ps *pluginProviderSchema) toTFJSON() (*tfjson.ProviderSchema, error) {
+	result := &tfjson.ProviderSchema{}
+	var err error
+	if ps.Provider != nil {
+		if result.ConfigSchema, err = ps.Provider.toTFJSON(); err != nil {
+			return nil, errors.Wrap(err, "cannot convert the provider configuration schema")
+		}
+	}
+	for _, m := range []struct {
+		in  map[string]*pluginSchema
+		out *map[string]*tfjson.Schema
+	}{
+		{in: ps.ResourceSchemas, out: &result.ResourceSchemas},
+		{in: ps.DataSourceSchemas, out: &result.DataSourceSchemas},
+		{in: ps.EphemeralResourceSchemas, out: &result.EphemeralResourceSchemas},
+	} {
+		if len(m.in) == 0 {
+			continue
+		}
+		*m.out = make(map[string]*tfjson.Schema, len(m.in))
+		for n, s := range m.in {
+			if (*m.out)[n], err = s.toTFJSON(); err != nil {
+				return nil, errors.Wrapf(err, "cannot convert the schema of %q", n)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (s *pluginSchema) toTFJSON() (*tfjson.Schema, error) {
+	result := &tfjson.Schema{Version: uint64(max(s.Version, 0))} //nolint:gosec // cannot be negative
+	if s.Block == nil {
+		return result, nil
+	}
+	var err error
+	result.Block, err = s.Block.toTFJSON()
+	return result, err
+}
+
+func (b *pluginBlock) toTFJSON() (*tfjson.SchemaBlock, error) {
+	result := &tfjson.SchemaBlock{
+		Description:     b.Description,
+		DescriptionKind: descriptionKind(b.DescriptionKind),
+		Deprecated:      b.Deprecated,
+	}
+	var err error
+	if result.Attributes, err = attributesToTFJSON(b.Attributes); err != nil {
+		return nil, err
+	}
+	for _, nb := range b.BlockTypes {
+		if result.NestedBlocks == nil {
+			result.NestedBlocks = make(map[string]*tfjson.SchemaBlockType, len(b.BlockTypes))
+		}
+		bt := &tfjson.SchemaBlockType{
+			NestingMode: tfjson.SchemaNestingMode(strings.ToLower(nb.Nesting)),
+			MinItems:    uint64(max(nb.MinItems, 0)), //nolint:gosec // cannot be negative
+			MaxItems:    uint64(max(nb.MaxItems, 0)), //nolint:gosec // cannot be negative
+			Block:       &tfjson.SchemaBlock{},
+		}
+		if nb.Block != nil {
+			if bt.Block, err = nb.Block.toTFJSON(); err != nil {
+				return nil, errors.Wrapf(err, "cannot convert the block %q", nb.TypeName)
+			}
+		}
+		result.NestedBlocks[nb.TypeName] = bt
+	}
+	return result, nil
+}
+
+func attributesToTFJSON(attrs []*pluginAttribute) (map[string]*tfjson.SchemaAttribute, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]*tfjson.SchemaAttribute, len(attrs))
+	for _, a := range attrs {
+		sa := &tfjson.SchemaAttribute{
+			Description:     a.Description,
+			DescriptionKind: descriptionKind(a.DescriptionKind),
+			Deprecated:      a.Deprecated,
+			Required:        a.Required,
+			Optional:        a.Optional,
+			Computed:        a.Computed,
+			Sensitive:       a.Sensitive,
+			WriteOnly:       a.WriteOnly,
+		}
+		switch {
+		case a.NestedType != nil:
+			nested, err := attributesToTFJSON(a.NestedType.Attributes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot convert the attribute %q", a.Name)
+			}
+			sa.AttributeNestedType = &tfjson.SchemaNestedAttributeType{
+				Attributes:  nested,
+				NestingMode: tfjson.SchemaNestingMode(strings.ToLower(a.NestedType.Nesting)),
+			}
+		default:
+			t := cty.NilType
+			if err := t.UnmarshalJSON(a.Type); err != nil {
+				return nil, errors.Wrapf(err, "cannot parse the type of the attribute %q", a.Name)
+			}
+			sa.AttributeType = t
+		}
+		result[a.Name] = sa
+	}
+	return result, nil
+}
+
+func descriptionKind(k string) tfjson.SchemaDescriptionKind {
+	if k == "MARKDOWN" {
+		return tfjson.SchemaDescriptionKindMarkdown
+	}
+	return tfjson.SchemaDescriptionKindPlain
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	goplugin "github.com/hashicorp/go-plugin"
+	tfjson "github.com/hashicorp/terraform-json"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type binaryTestProvider struct{}
+
+func (binaryTestProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "test"
+}
+
+func (binaryTestProvider) Schema(context.Context, fwprovider.SchemaRequest, *fwprovider.SchemaResponse) {
+}
+
+func (binaryTestProvider) Configure(context.Context, fwprovider.ConfigureRequest, *fwprovider.ConfigureResponse) {
+}
+
+func (binaryTestProvider) DataSources(context.Context) []func() fwdatasource.DataSource {
+	return nil
+}
+
+func (binaryTestProvider) Resources(context.Context) []func() fwresource.Resource {
+	return []func() fwresource.Resource{func() fwresource.Resource { return binaryTestResource{} }}
+}
+
+type binaryTestResource struct {
+	fwresource.Resource
+}
+
+func (binaryTestResource) Metadata(_ context.Context, _ fwresource.MetadataRequest, resp *fwresource.MetadataResponse) {
+	resp.TypeName = "test_resource"
+}
+
+func (binaryTestResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"name":     rschema.StringAttribute{Required: true},
+			"password": rschema.StringAttribute{Optional: true, WriteOnly: true},
+			"settings": rschema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]rschema.Attribute{
+					"size": rschema.Int64Attribute{Optional: true},
+				},
+			},
+		},
+	}
+}
+
+// serveTestProvider serves the specified native provider in-process and
+// returns the go-plugin client configuration to reattach to it.
+func serveTestProvider(t *testing.T, serve func(ctx context.Context, ch chan *goplugin.ReattachConfig, closeCh chan struct{}) error) *goplugin.ClientConfig {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *goplugin.ReattachConfig)
+	closeCh := make(chan struct{})
+	go func() {
+		if err := serve(ctx, ch, closeCh); err != nil {
+			t.Errorf("cannot serve the test provider: %v", err)
+		}
+	}()
+	rc := <-ch
+	t.Cleanup(func() {
+		cancel()
+		<-closeCh
+	})
+	return &goplugin.ClientConfig{Reattach: rc}
+}
+
+func TestReadProviderSchema(t *testing.T) {
+	type want struct {
+		schema *tfjson.ProviderSchema
+	}
+	cases := map[string]struct {
+		reason string
+		serve  func(ctx context.Context, ch chan *goplugin.ReattachConfig, closeCh chan struct{}) error
+		want   want
+	}{
+		"PluginProtocolV5": {
+			reason: "The schema of a Terraform Plugin SDK provider should be read over the plugin protocol version 5.",
+			serve: func(ctx context.Context, ch chan *goplugin.ReattachConfig, closeCh chan struct{}) error {
+				p := &schema.Provider{
+					ResourcesMap: map[string]*schema.Resource{
+						"test_resource": {
+							Schema: map[string]*schema.Schema{
+								"name":     {Type: schema.TypeString, Required: true, Description: "The name."},
+								"password": {Type: schema.TypeString, Optional: true, WriteOnly: true},
+								"block": {
+									Type:     schema.TypeList,
+									Optional: true,
+									MaxItems: 1,
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"size": {Type: schema.TypeInt, Optional: true, Computed: true},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+				return tf5server.Serve("test", func() tfprotov5.ProviderServer { return p.GRPCProvider() }, tf5server.WithDebug(ctx, ch, closeCh))
+			},
+			want: want{
+				schema: &tfjson.ProviderSchema{
+					ConfigSchema: &tfjson.Schema{Block: &tfjson.SchemaBlock{DescriptionKind: tfjson.SchemaDescriptionKindPlain}},
+					ResourceSchemas: map[string]*tfjson.Schema{
+						"test_resource": {
+							Block: &tfjson.SchemaBlock{
+								DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"id":       {AttributeType: cty.String, Optional: true, Computed: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+									"name":     {AttributeType: cty.String, Required: true, Description: "The name.", DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+									"password": {AttributeType: cty.String, Optional: true, WriteOnly: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+								},
+								NestedBlocks: map[string]*tfjson.SchemaBlockType{
+									"block": {
+										NestingMode: tfjson.SchemaNestingModeList,
+										MaxItems:    1,
+										Block: &tfjson.SchemaBlock{
+											DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+											Attributes: map[string]*tfjson.SchemaAttribute{
+												"size": {AttributeType: cty.Number, Optional: true, Computed: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"PluginProtocolV6": {
+			reason: "The schema of a Terraform Plugin Framework provider should be read over the plugin protocol version 6 with its nested attributes.",
+			serve: func(ctx context.Context, ch chan *goplugin.ReattachConfig, closeCh chan struct{}) error {
+				return tf6server.Serve("test", providerserver.NewProtocol6(binaryTestProvider{}), tf6server.WithDebug(ctx, ch, closeCh))
+			},
+			want: want{
+				schema: &tfjson.ProviderSchema{
+					ConfigSchema: &tfjson.Schema{Block: &tfjson.SchemaBlock{DescriptionKind: tfjson.SchemaDescriptionKindPlain}},
+					ResourceSchemas: map[string]*tfjson.Schema{
+						"test_resource": {
+							Block: &tfjson.SchemaBlock{
+								DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+								Attributes: map[string]*tfjson.SchemaAttribute{
+									"name":     {AttributeType: cty.String, Required: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+									"password": {AttributeType: cty.String, Optional: true, WriteOnly: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+									"settings": {
+										Optional:        true,
+										DescriptionKind: tfjson.SchemaDescriptionKindPlain,
+										AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+											NestingMode: tfjson.SchemaNestingModeSingle,
+											Attributes: map[string]*tfjson.SchemaAttribute{
+												"size": {AttributeType: cty.Number, Optional: true, DescriptionKind: tfjson.SchemaDescriptionKindPlain},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := readProviderSchema(context.Background(), "test", serveTestProvider(t, tc.serve))
+			if err != nil {
+				t.Fatalf("\n%s\nreadProviderSchema(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.schema, got, cmp.Comparer(func(a, b cty.Type) bool { return a.Equals(b) })); diff != "" {
+				t.Errorf("\n%s\nreadProviderSchema(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
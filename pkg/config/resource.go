@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"go/types"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
@@ -30,6 +31,7 @@ import (
 	"github.com/crossplane/upjet/v2/pkg/config/conversion"
 	"github.com/crossplane/upjet/v2/pkg/registry"
 	"github.com/crossplane/upjet/v2/pkg/types/markers/kubebuilder"
+	tjname "github.com/crossplane/upjet/v2/pkg/types/name"
 	"github.com/crossplane/upjet/v2/pkg/types/structtag"
 )
 
@@ -81,6 +83,33 @@ const (
 	StructTypeGranular StructType = "granular"
 )
 
+// A ReplacementPolicy is a policy for the changes that require replacing the
+// external resource, i.e., the changes to the ForceNew arguments of the
+// Terraform plugin SDK resources or to the attributes with the RequiresReplace
+// plan modifiers of the Terraform Plugin Framework resources.
+type ReplacementPolicy string
+
+// Replacement policies.
+const (
+	// ReplacementPolicyDeny refuses to update the external resource if the
+	// update requires replacing it. This is the default policy.
+	ReplacementPolicyDeny ReplacementPolicy = "Deny"
+
+	// ReplacementPolicyReplace destroys the external resource and then
+	// creates its replacement.
+	ReplacementPolicyReplace ReplacementPolicy = "Replace"
+
+	// ReplacementPolicyCreateBeforeDestroy creates the replacement of the
+	// external resource and then destroys the replaced resource.
+	ReplacementPolicyCreateBeforeDestroy ReplacementPolicy = "CreateBeforeDestroy"
+
+	// ReplacementPolicyRequireApproval destroys the external resource and
+	// then creates its replacement only if the replacement has been approved
+	// by annotating the managed resource with the hash of the planned
+	// replacement.
+	ReplacementPolicyRequireApproval ReplacementPolicy = "RequireApproval"
+)
+
 // SetIdentifierArgumentsFn sets the name of the resource in Terraform attributes map,
 // i.e. Main HCL file.
 type SetIdentifierArgumentsFn func(base map[string]any, externalName string)
@@ -232,6 +261,19 @@ type Reference struct {
 	// referenced type. Defaults to getting external name.
 	// Optional
 	Extractor string
+	// ExtractorTemplate is a Go template to be used to extract value from
+	// the referenced resource, as an alternative to writing an Extractor
+	// function for composite values such as ARNs. The fields of the
+	// referenced resource are available with their field paths, such as
+	// {{ .spec.forProvider.region }} or {{ .status.atProvider.accountId }},
+	// and its external name as {{ .external_name }}. The ToLower and ToUpper
+	// functions are available. If a field is missing, the extracted value
+	// is empty and the reference is not resolved. When set, Extractor,
+	// Targets and the FieldPath of External must not be set.
+	// Example:
+	// arn:aws:iam::{{ .status.atProvider.accountId }}:role/{{ .external_name }}
+	// Optional
+	ExtractorTemplate string
 	// RefFieldName is the field name for the Reference field. Defaults to
 	// <field-name>Ref or <field-name>Refs.
 	// Optional
@@ -240,6 +282,68 @@ type Reference struct {
 	// <field-name>Selector.
 	// Optional
 	SelectorFieldName string
+	// Targets are the candidate target resources of a polymorphic reference,
+	// i.e., a reference that can resolve to one of several kinds. When set,
+	// the generated Ref and Selector fields carry the kind of the chosen
+	// target and TerraformName, Type and Extractor must not be set. Only the
+	// single-valued fields can be polymorphic references.
+	// Optional
+	Targets []ReferenceTarget
+	// External is the managed resource kind of another provider to be
+	// referenced. Such references are resolved via unstructured objects, so
+	// the generated provider does not need a Go dependency on the API
+	// packages of the other provider. When set, TerraformName, Type,
+	// Extractor and Targets must not be set.
+	// Optional
+	External *ExternalReference
+}
+
+// ExternalReference is a managed resource kind of another provider
+// referenced by its API group, version and kind.
+type ExternalReference struct {
+	// Group is the API group of the referenced kind, e.g.,
+	// elbv2.aws.upbound.io.
+	Group string
+	// Version is the API version of the referenced kind, e.g., v1beta1.
+	Version string
+	// Kind is the kind of the referenced managed resource, e.g., LB.
+	Kind string
+	// FieldPath is the path of the field of the referenced object from
+	// which the value is extracted, e.g., status.atProvider.dnsName. Defaults
+	// to getting external name.
+	// Optional
+	FieldPath string
+}
+
+// ReferenceTarget is a candidate target resource of a polymorphic reference.
+type ReferenceTarget struct {
+	// TerraformName is the name of the Terraform resource which can be
+	// referenced.
+	TerraformName string
+	// Extractor is the function to be used to extract value from the
+	// referenced resource. Defaults to getting external name.
+	// Optional
+	Extractor string
+	// Type is the Go type path of the CRD of the target resource, i.e.,
+	// <package-path>.<type-name>. It's set from TerraformName before the
+	// code generation.
+	Type string
+}
+
+// IsPolymorphic returns true if the reference can target one of several
+// kinds.
+func (r Reference) IsPolymorphic() bool {
+	return len(r.Targets) > 0
+}
+
+// ParseExtractorTemplate parses the specified Reference.ExtractorTemplate
+// with the functions available to the extractor templates.
+func ParseExtractorTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("extractor").Funcs(template.FuncMap{
+		"ToLower": strings.ToLower,
+		"ToUpper": strings.ToUpper,
+	}).Option("missingkey=error").Parse(tmpl)
+	return t, errors.Wrap(err, "cannot parse the extractor template")
 }
 
 // Sensitive represents configurations to handle sensitive information
@@ -601,6 +705,20 @@ type Resource struct {
 	// path and the plural name for the generated CRD.
 	Path string
 
+	// ShortNames are the short names of the generated CRD, which can be
+	// used with kubectl, e.g., `kubectl get <short name>`.
+	ShortNames []string
+
+	// Categories are the additional categories of the generated CRD. The
+	// generated CRDs always belong to the crossplane, managed and the
+	// provider's short name categories.
+	Categories []string
+
+	// PrinterColumns are the additional printer columns of the generated CRD.
+	// They are printed after the SYNCED, READY and EXTERNAL-NAME columns and
+	// before the AGE column.
+	PrinterColumns []PrinterColumn
+
 	// SchemaElementOptions is a map from the schema element paths to
 	// SchemaElementOption for configuring options for schema elements.
 	SchemaElementOptions SchemaElementOptions
@@ -664,6 +782,13 @@ type Resource struct {
 	// Terraform stack at runtime.
 	dynamicAttributeConversionPaths []string
 
+	// frameworkAttributeValues are the static defaults and the allowed
+	// values of the Terraform Plugin Framework attributes keyed by their
+	// Terraform field paths without the wildcard segments. They are kept
+	// apart from TerraformResource, which may be shared, and are only read
+	// by the type builder to infer the kubebuilder markers.
+	frameworkAttributeValues map[string]FrameworkAttributeValues
+
 	// TerraformConfigurationInjector allows a managed resource to inject
 	// configuration values in the Terraform configuration map obtained by
 	// deserializing its `spec.forProvider` value. Managed resources can
@@ -688,6 +813,40 @@ type Resource struct {
 	// index notation (i.e., array/map components do not need indices).
 	ServerSideApplyMergeStrategies ServerSideApplyMergeStrategies
 
+	// DisableConstraintValidationRules disables the generation of the CEL
+	// validation rules for the ConflictsWith, ExactlyOneOf, AtLeastOneOf &
+	// RequiredWith constraints of the Terraform resource schema. It can be
+	// set for the resources whose constraints cannot be expressed with the
+	// generated CRD schema, e.g., when they refer to fields that are
+	// configured via references or late-initialization.
+	DisableConstraintValidationRules bool
+
+	// ImmutableForceNewFields generates the `self == oldSelf` CEL transition
+	// rules for the ForceNew (or RequiresReplace for the Terraform Plugin
+	// Framework resources) arguments of the resource, so that the changes to
+	// these arguments, which would require replacing the external resource,
+	// are rejected by the API server instead of failing at reconciliation
+	// time. The rules can be enabled or disabled per field via the
+	// SchemaElementOptions.SetImmutable.
+	ImmutableForceNewFields bool
+
+	// ValidatingWebhook generates a validating admission webhook for the
+	// resource, which rejects the creations and the updates of the managed
+	// resources whose specs fail the validations of the Terraform schema,
+	// such as the SDKv2 ValidateFunc and ValidateDiagFunc validators or the
+	// Plugin Framework validators, instead of surfacing them only after the
+	// controller reconciles the resources. The webhooks are registered via
+	// the generated SetupValidatingWebhookWithManager functions.
+	ValidatingWebhook bool
+
+	// ReplacementPolicy is the policy for the updates that require replacing
+	// the external resource, which can be overridden per managed resource via
+	// the upjet.upbound.io/replacement-policy annotation. Defaults to
+	// ReplacementPolicyDeny if not set. The policy is only effective for the
+	// resources reconciled via the Terraform plugin SDK or the Terraform
+	// Plugin Framework clients.
+	ReplacementPolicy ReplacementPolicy
+
 	// Conversions is the list of CRD API conversion functions to be invoked
 	// in-chain by the installed conversion Webhook for the generated CRD.
 	// This list of conversion.Conversion registered here are responsible for
@@ -708,6 +867,15 @@ type Resource struct {
 	// the Terraform Plugin SDKv2 client.
 	useTerraformPluginFrameworkClient bool
 
+	// dataSource indicates that this configuration belongs to a Terraform
+	// data source, which is generated as an observe-only managed resource.
+	dataSource bool
+
+	// ephemeralResource indicates that this configuration belongs to a
+	// Terraform Plugin Framework ephemeral resource, whose result is
+	// published as a connection secret.
+	ephemeralResource bool
+
 	// overrideGeneratedFieldType allows to manually override the type for the
 	// generated field of a Resource at the specified Terraform path.
 	// We only support type overrides for scalar fields currently.
@@ -873,6 +1041,18 @@ func (r *Resource) ShouldUseTerraformPluginFrameworkClient() bool {
 	return r.useTerraformPluginFrameworkClient
 }
 
+// IsDataSource returns whether this configuration belongs to a Terraform
+// data source instead of a Terraform resource.
+func (r *Resource) IsDataSource() bool {
+	return r.dataSource
+}
+
+// IsEphemeralResource returns whether this configuration belongs to a
+// Terraform ephemeral resource instead of a Terraform resource.
+func (r *Resource) IsEphemeralResource() bool {
+	return r.ephemeralResource
+}
+
 // CustomDiff customizes the computed Terraform InstanceDiff. This can be used
 // in cases where, for example, changes in a certain argument should just be
 // dismissed. The new InstanceDiff is returned along with any errors.
@@ -937,6 +1117,15 @@ func (r *Resource) TFDynamicAttributeConversionPaths() []string {
 	return r.dynamicAttributeConversionPaths
 }
 
+// FrameworkAttributeValues returns the static default and the allowed values
+// of the Terraform Plugin Framework attribute at the specified field path,
+// which is a Terraform field path without the wildcard segments, like a.b.c.
+// The returned bool is false if no values are known for the attribute.
+func (r *Resource) FrameworkAttributeValues(fieldPath string) (FrameworkAttributeValues, bool) {
+	v, ok := r.frameworkAttributeValues[fieldPath]
+	return v, ok
+}
+
 // CRDStorageVersion returns the CRD storage version if configured. If not,
 // returns the Version being generated as the default value.
 func (r *Resource) CRDStorageVersion() string {
@@ -1226,6 +1415,24 @@ func (r *Resource) FieldTypeOverride(path string) FieldTypeOverrideConfiguration
 	}
 }
 
+// PrinterColumn is an additional printer column of a generated CRD.
+type PrinterColumn struct {
+	// Name of the column, e.g., STATE.
+	Name string
+	// Type of the column, which is one of integer, number, string, boolean
+	// or date.
+	Type string
+	// JSONPath of the field to be printed in the column, e.g.,
+	// .status.atProvider.state. It must refer to a scalar field under
+	// spec.forProvider, spec.initProvider or status.atProvider. The list
+	// items can be referred to via their indices, e.g.,
+	// .status.atProvider.endpoint[0].address.
+	JSONPath string
+	// Priority of the column. The columns with a priority greater than zero
+	// are only printed in the wide output, e.g., `kubectl get -o wide`.
+	Priority int
+}
+
 // SetEmbeddedObject sets the EmbeddedObject for the specified key.
 // The key is a Terraform field path without the wildcard segments.
 func (m SchemaElementOptions) SetEmbeddedObject(el string) {
@@ -1252,6 +1459,67 @@ func (m SchemaElementOptions) SetInitProviderOverrides(el string, o *InitProvide
 	m[el].InitProviderOverrides = o
 }
 
+// SetInferredMarkers sets the InferredMarkers for the specified key.
+// The key is a Terraform field path without the wildcard segments, like
+// a.b.c.
+func (m SchemaElementOptions) SetInferredMarkers(el string, o *InferredMarkers) {
+	if m[el] == nil {
+		m[el] = &SchemaElementOption{}
+	}
+	m[el].InferredMarkers = o
+}
+
+// SetImmutable sets whether the `self == oldSelf` CEL transition rule is
+// generated for the specified key regardless of the
+// Resource.ImmutableForceNewFields configuration and of whether it's a
+// ForceNew argument. The key is a Terraform field path without the wildcard
+// segments, like a.b.c.
+func (m SchemaElementOptions) SetImmutable(el string, immutable bool) {
+	if m[el] == nil {
+		m[el] = &SchemaElementOption{}
+	}
+	m[el].Immutable = &immutable
+}
+
+// SetValueFrom sets the ValueFrom option for the specified key, which
+// generates an accompanying `<field>From` spec field for sourcing the value
+// of the top-level argument from a ConfigMap, a Secret or a field of an
+// arbitrary object. The key is the Terraform name of the argument. Only the
+// resources reconciled via the Terraform plugin SDK or the Terraform Plugin
+// Framework clients can source values from other objects.
+func (m SchemaElementOptions) SetValueFrom(el string) {
+	if m[el] == nil {
+		m[el] = &SchemaElementOption{}
+	}
+	m[el].ValueFrom = true
+}
+
+// ValueFrom returns true if the value of the top-level argument at the
+// specified path can be sourced from another object.
+func (m SchemaElementOptions) ValueFrom(el string) bool {
+	return m[el] != nil && m[el].ValueFrom
+}
+
+// ValueFromFields returns the Terraform names of the top-level arguments
+// whose values can be sourced from other objects, mapped to the JSON names
+// of the spec fields specifying their sources.
+func (r *Resource) ValueFromFields() map[string]string {
+	fields := make(map[string]string)
+	for el, o := range r.SchemaElementOptions {
+		if o == nil || !o.ValueFrom {
+			continue
+		}
+		fields[el] = ValueFromFieldName(el)
+	}
+	return fields
+}
+
+// ValueFromFieldName returns the JSON name of the spec field specifying the
+// source of the value of the specified top-level Terraform argument.
+func ValueFromFieldName(tfName string) string {
+	return tjname.NewFromSnake(tfName).LowerCamelComputed + "From"
+}
+
 // TagOverrides can be used to override the generated struct tags in
 // the generated InitProvider, ForProvider or Observation APIs for
 // the Terraform schema element.
@@ -1296,4 +1564,48 @@ type SchemaElementOption struct {
 	// InitProviderOverrides is set to override the generated InitProvider field
 	// corresponding to a schema element.
 	InitProviderOverrides *InitProviderOverrides
+	// InferredMarkers is set to override or suppress the kubebuilder markers
+	// inferred from the Terraform schema element.
+	InferredMarkers *InferredMarkers
+	// Immutable is set to override whether the field represented by
+	// a schema element is immutable once set. If nil, the ForceNew
+	// arguments are immutable when Resource.ImmutableForceNewFields is set.
+	Immutable *bool
+	// ValueFrom is set to true if the value of the top-level argument
+	// represented by a schema element can be sourced from a ConfigMap,
+	// a Secret or a field of an arbitrary object via an accompanying
+	// `<field>From` spec field.
+	ValueFrom bool
+}
+
+// FrameworkAttributeValues are the static default and the allowed values of
+// a Terraform Plugin Framework attribute from which the kubebuilder markers
+// are inferred like the Default and ValidateFunc attributes of a Terraform
+// Plugin SDK schema.
+type FrameworkAttributeValues struct {
+	// Default is the static default value of the attribute. The numbers are
+	// float64s like the numbers of the Terraform schemas converted from the
+	// framework schemas.
+	Default any
+	// Enum is the list of the allowed values of a string attribute.
+	Enum []string
+}
+
+// InferredMarkers is a set of overrides for the kubebuilder markers inferred
+// from a Terraform schema element, i.e., the +kubebuilder:default marker
+// inferred from its default value and the +kubebuilder:validation:Enum
+// marker inferred from its allowed values.
+type InferredMarkers struct {
+	// Default overrides the inferred default value. Please note that you
+	// will need to include the quotes for the string values, e.g., `"10"`.
+	Default *string
+	// Enum overrides the inferred allowed values. Like Default, the string
+	// values need to be quoted.
+	Enum []string
+	// DisableDefault suppresses the +kubebuilder:default marker if Default
+	// is not set.
+	DisableDefault bool
+	// DisableEnum suppresses the +kubebuilder:validation:Enum marker if Enum
+	// is not set.
+	DisableEnum bool
 }
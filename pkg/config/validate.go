@@ -0,0 +1,299 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// suffixOmittedPrefixField is the suffix of the "<name>_prefix" companion
+	// fields the built-in external name configurations omit together with
+	// their identifier field. Not all resources have such a field, so they
+	// are not reported when missing.
+	suffixOmittedPrefixField = "_prefix"
+)
+
+// ValidationError is a configuration problem found by Provider.Validate.
+type ValidationError struct {
+	// Resource is the Terraform name of the resource, data source or
+	// ephemeral resource whose configuration is invalid.
+	Resource string
+	// Field is the configuration field that has the problem, e.g.,
+	// References or LateInitializer.IgnoredFields.
+	Field string
+	// Path is the Terraform field path the configuration refers to, if any.
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("resource %q: %s: %s", e.Resource, e.Field, e.Message)
+	}
+	return fmt.Sprintf("resource %q: %s[%q]: %s", e.Resource, e.Field, e.Path, e.Message)
+}
+
+// Validate checks the configuration of every resource, data source and
+// ephemeral resource of the provider against its Terraform schema. It reports
+// all the problems found at once as a joined error of *ValidationErrors, or
+// returns nil if the configuration is valid. It should be called after the
+// resources are configured and before the code generation pipelines run.
+func (p *Provider) Validate() error {
+	var errs []error
+	for _, name := range sortedNames(p.Resources) {
+		errs = append(errs, p.validateResource(p.Resources[name])...)
+	}
+	for _, name := range sortedNames(p.DataSources) {
+		errs = append(errs, p.validateResource(p.DataSources[name].Resource)...)
+	}
+	for _, name := range sortedNames(p.EphemeralResources) {
+		errs = append(errs, p.validateResource(p.EphemeralResources[name].Resource)...)
+	}
+	return errors.Wrap(errors.Join(errs...), "invalid provider configuration")
+}
+
+func (p *Provider) validateResource(r *Resource) []error {
+	if r.TerraformResource == nil {
+		return []error{&ValidationError{Resource: r.Name, Field: "TerraformResource", Message: "Terraform schema is missing"}}
+	}
+	v := &resourceValidator{r: r}
+	v.validateReferences(p.Resources)
+	v.validateFieldPaths("Sensitive.FieldPaths", sortedNames(r.Sensitive.GetFieldPaths()))
+	v.validateFieldPaths("LateInitializer.IgnoredFields", r.LateInitializer.IgnoredFields)
+	v.validateFieldPaths("LateInitializer.ConditionalIgnoredFields", r.LateInitializer.ConditionalIgnoredFields)
+	v.validateOmittedFields()
+	v.validateServerSideApplyMergeStrategies()
+	v.validateValueFromFields()
+	return v.errs
+}
+
+type resourceValidator struct {
+	r    *Resource
+	errs []error
+}
+
+func (v *resourceValidator) addError(field, path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{
+		Resource: v.r.Name,
+		Field:    field,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// schemaAt returns the schema of the field at the given Terraform field path.
+// Any wildcard segments in the path are ignored.
+func (v *resourceValidator) schemaAt(fieldPath string) *schema.Schema {
+	parts := strings.Split(strings.ReplaceAll(fieldPath, "[*]", ""), ".")
+	filtered := parts[:0]
+	for _, p := range parts {
+		if p != "" && p != "*" {
+			filtered = append(filtered, p)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return GetSchema(v.r.TerraformResource, strings.Join(filtered, "."))
+}
+
+func (v *resourceValidator) validateFieldPaths(field string, paths []string) {
+	for _, p := range paths {
+		if v.schemaAt(p) == nil {
+			v.addError(field, p, "cannot find the field in the Terraform schema")
+		}
+	}
+}
+
+func (v *resourceValidator) validateReferences(resources map[string]*Resource) {
+	for _, p := range sortedNames(v.r.References) {
+		ref := v.r.References[p]
+		if v.schemaAt(p) == nil {
+			v.addError("References", p, "cannot find the field in the Terraform schema")
+		}
+		if ref.ExtractorTemplate != "" {
+			v.validateExtractorTemplate(p, ref)
+		}
+		if ref.External != nil {
+			v.validateExternalReference(p, ref)
+			continue
+		}
+		if ref.IsPolymorphic() {
+			v.validatePolymorphicReference(p, ref, resources)
+			continue
+		}
+		if ref.TerraformName == "" {
+			continue
+		}
+		if _, ok := resources[ref.TerraformName]; !ok {
+			v.addError("References", p, "referenced Terraform resource %q is not configured", ref.TerraformName)
+		}
+	}
+}
+
+func (v *resourceValidator) validatePolymorphicReference(p string, ref Reference, resources map[string]*Resource) {
+	if ref.TerraformName != "" || ref.Type != "" || ref.Extractor != "" { //nolint:staticcheck // still handling deprecated field behavior
+		v.addError("References", p, "terraformName, type and extractor cannot be set together with the targets of a polymorphic reference")
+	}
+	if s := v.schemaAt(p); s != nil && (s.Type == schema.TypeList || s.Type == schema.TypeSet) {
+		v.addError("References", p, "only the single-valued fields can be polymorphic references")
+	}
+	kinds := make(map[string]string, len(ref.Targets))
+	for _, t := range ref.Targets {
+		r, ok := resources[t.TerraformName]
+		if !ok {
+			v.addError("References", p, "referenced Terraform resource %q is not configured", t.TerraformName)
+			continue
+		}
+		// the kind of the chosen target must identify exactly one target.
+		if other, ok := kinds[r.Kind]; ok {
+			v.addError("References", p, "targets %q and %q of the polymorphic reference have the same kind %q", other, t.TerraformName, r.Kind)
+			continue
+		}
+		kinds[r.Kind] = t.TerraformName
+	}
+}
+
+func (v *resourceValidator) validateExternalReference(p string, ref Reference) {
+	if ref.TerraformName != "" || ref.Type != "" || ref.Extractor != "" || ref.IsPolymorphic() { //nolint:staticcheck // still handling deprecated field behavior
+		v.addError("References", p, "terraformName, type, extractor and targets cannot be set together with an external reference")
+	}
+	if ref.External.Group == "" || ref.External.Version == "" || ref.External.Kind == "" {
+		v.addError("References", p, "group, version and kind of an external reference must be set")
+	}
+}
+
+func (v *resourceValidator) validateExtractorTemplate(p string, ref Reference) {
+	if ref.Extractor != "" || ref.IsPolymorphic() || (ref.External != nil && ref.External.FieldPath != "") {
+		v.addError("References", p, "extractor, targets and the field path of an external reference cannot be set together with an extractor template")
+	}
+	// the template is carried to the resolver transformer in a marker.
+	if strings.ContainsAny(ref.ExtractorTemplate, "\r\n") {
+		v.addError("References", p, "extractor template must be a single line")
+	}
+	if _, err := ParseExtractorTemplate(ref.ExtractorTemplate); err != nil {
+		v.addError("References", p, "%s", err.Error())
+	}
+}
+
+func (v *resourceValidator) validateOmittedFields() {
+	for _, p := range v.r.ExternalName.OmittedFields {
+		current := v.r.TerraformResource.Schema
+		fields := strings.Split(p, ".")
+		for i, f := range fields {
+			s, ok := current[f]
+			if i == len(fields)-1 {
+				if !ok && !strings.HasSuffix(f, suffixOmittedPrefixField) {
+					v.addError("ExternalName.OmittedFields", p, "cannot find the field in the Terraform schema")
+				}
+				break
+			}
+			res, isBlock := (*schema.Resource)(nil), false
+			if ok {
+				res, isBlock = s.Elem.(*schema.Resource)
+			}
+			if !isBlock || res == nil {
+				v.addError("ExternalName.OmittedFields", p, "%q is not a Terraform block", strings.Join(fields[:i+1], "."))
+				break
+			}
+			current = res.Schema
+		}
+	}
+}
+
+func (v *resourceValidator) validateServerSideApplyMergeStrategies() { //nolint:gocyclo // easier to follow as a unit
+	const field = "ServerSideApplyMergeStrategies"
+	for _, p := range sortedNames(v.r.ServerSideApplyMergeStrategies) {
+		s := v.r.ServerSideApplyMergeStrategies[p]
+		sch := v.schemaAt(p)
+		if sch == nil {
+			v.addError(field, p, "cannot find the field in the Terraform schema")
+			continue
+		}
+		switch sch.Type { //nolint:exhaustive
+		case schema.TypeList, schema.TypeSet:
+			if s.ListMergeStrategy.MergeStrategy == "" || s.MapMergeStrategy != "" || s.StructMergeStrategy != "" {
+				v.addError(field, p, "field is a list and only ListMergeStrategy must be set")
+				continue
+			}
+			if s.ListMergeStrategy.MergeStrategy != ListTypeMap {
+				continue
+			}
+			keys := s.ListMergeStrategy.ListMapKeys
+			if keys.InjectedKey.Key == "" && len(keys.Keys) == 0 {
+				v.addError(field, p, "list map keys configuration is empty")
+				continue
+			}
+			el, ok := sch.Elem.(*schema.Resource)
+			if !ok {
+				v.addError(field, p, "list map keys can only be configured for object lists")
+				continue
+			}
+			for _, k := range keys.Keys {
+				if _, ok := el.Schema[k]; !ok {
+					v.addError(field, p, "list map key %q cannot be found in the element schema", k)
+				}
+			}
+			if _, ok := el.Schema[keys.InjectedKey.Key]; ok && keys.InjectedKey.Key != "" {
+				v.addError(field, p, "injected list map key %q already exists in the element schema", keys.InjectedKey.Key)
+			}
+		case schema.TypeMap:
+			if s.MapMergeStrategy == "" || s.ListMergeStrategy.MergeStrategy != "" || s.StructMergeStrategy != "" {
+				v.addError(field, p, "field is a map and only MapMergeStrategy must be set")
+			}
+		default:
+			v.addError(field, p, "merge strategies can only be configured for lists, sets and maps")
+		}
+	}
+}
+
+func (v *resourceValidator) validateValueFromFields() {
+	const field = "SchemaElementOptions.ValueFrom"
+	for _, p := range sortedNames(v.r.SchemaElementOptions) {
+		if !v.r.SchemaElementOptions.ValueFrom(p) {
+			continue
+		}
+		// the Terraform CLI-based external client does not resolve the
+		// value sources.
+		if !v.r.ShouldUseTerraformPluginSDKClient() && !v.r.ShouldUseTerraformPluginFrameworkClient() {
+			v.addError(field, p, "values can only be sourced from other objects for the resources reconciled via the Terraform plugin SDK or the Terraform Plugin Framework clients")
+		}
+		sch, ok := v.r.TerraformResource.Schema[p]
+		if !ok {
+			v.addError(field, p, "cannot find the top-level argument in the Terraform schema")
+			continue
+		}
+		switch {
+		case sch.Computed && !sch.Optional:
+			v.addError(field, p, "values of the observed attributes cannot be sourced from other objects")
+		case sch.Sensitive:
+			v.addError(field, p, "values of the sensitive arguments cannot be sourced from other objects")
+		case sch.Type != schema.TypeString && sch.Type != schema.TypeInt && sch.Type != schema.TypeFloat && sch.Type != schema.TypeBool:
+			v.addError(field, p, "only the values of the primitive arguments can be sourced from other objects")
+		}
+		if _, ok := v.r.References[p]; ok {
+			v.addError(field, p, "values of the referencing arguments cannot be sourced from other objects")
+		}
+		if _, ok := v.r.TerraformResource.Schema[p+"_from"]; ok {
+			v.addError(field, p, "the generated field %q conflicts with the argument %q", ValueFromFieldName(p), p+"_from")
+		}
+	}
+}
+
+func sortedNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for n := range m {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func newValidationTestResource(name string, opts ...ResourceOption) *Resource {
+	r := DefaultResource(name, &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}, nil, nil, opts...)
+	return r
+}
+
+func TestProviderValidate(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		resources map[string]*Resource
+		want      error
+	}{
+		"Valid": {
+			reason: "A configuration that matches the Terraform schemas is valid",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc"),
+				"test_subnet": newValidationTestResource("test_subnet", func(r *Resource) {
+					r.ExternalName = NameAsIdentifier
+					r.References["vpc_id"] = Reference{TerraformName: "test_vpc"}
+					r.Sensitive.AddFieldPath("rule[*].port", "rule[*].port")
+					r.LateInitializer.IgnoredFields = []string{"rule.port"}
+					r.ServerSideApplyMergeStrategies["rule"] = MergeStrategy{
+						ListMergeStrategy: ListMergeStrategy{
+							MergeStrategy: ListTypeMap,
+							ListMapKeys:   ListMapKeys{Keys: []string{"port"}},
+						},
+					}
+					r.ServerSideApplyMergeStrategies["tags"] = MergeStrategy{MapMergeStrategy: MapTypeGranular}
+				}),
+			},
+		},
+		"InvalidFieldPaths": {
+			reason: "All the problems in all the resources should be reported at once",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc", func(r *Resource) {
+					r.ExternalName.OmittedFields = []string{"nmae", "vpc_id.id"}
+				}),
+				"test_subnet": newValidationTestResource("test_subnet", func(r *Resource) {
+					r.References["vpcid"] = Reference{TerraformName: "test_vcp"}
+					r.Sensitive.AddFieldPath("rule.prot", "rule.prot")
+					r.LateInitializer.IgnoredFields = []string{"rules"}
+					r.LateInitializer.ConditionalIgnoredFields = []string{"tag"}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "vpcid", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "vpcid", Message: `referenced Terraform resource "test_vcp" is not configured`},
+				&ValidationError{Resource: "test_subnet", Field: "Sensitive.FieldPaths", Path: "rule.prot", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_subnet", Field: "LateInitializer.IgnoredFields", Path: "rules", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_subnet", Field: "LateInitializer.ConditionalIgnoredFields", Path: "tag", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_vpc", Field: "ExternalName.OmittedFields", Path: "nmae", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_vpc", Field: "ExternalName.OmittedFields", Path: "vpc_id.id", Message: `"vpc_id" is not a Terraform block`},
+			), "invalid provider configuration"),
+		},
+		"InvalidPolymorphicReferences": {
+			reason: "The targets of a polymorphic reference should be configured, have distinct kinds and be set for a single-valued field only",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc", func(r *Resource) {
+					r.Kind = "VPC"
+				}),
+				"test_network": newValidationTestResource("test_network", func(r *Resource) {
+					r.Kind = "VPC"
+				}),
+				"test_subnet": newValidationTestResource("test_subnet", func(r *Resource) {
+					r.Kind = "Subnet"
+					r.References["vpc_id"] = Reference{
+						Targets: []ReferenceTarget{{TerraformName: "test_vpc"}, {TerraformName: "test_network"}, {TerraformName: "test_vcp"}},
+					}
+					r.References["rule"] = Reference{
+						TerraformName: "test_vpc",
+						Targets:       []ReferenceTarget{{TerraformName: "test_vpc"}, {TerraformName: "test_subnet"}},
+					}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "rule", Message: "terraformName, type and extractor cannot be set together with the targets of a polymorphic reference"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "rule", Message: "only the single-valued fields can be polymorphic references"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "vpc_id", Message: `targets "test_vpc" and "test_network" of the polymorphic reference have the same kind "VPC"`},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "vpc_id", Message: `referenced Terraform resource "test_vcp" is not configured`},
+			), "invalid provider configuration"),
+		},
+		"InvalidExternalReferences": {
+			reason: "An external reference should have its group, version and kind set and no other target configuration",
+			resources: map[string]*Resource{
+				"test_subnet": newValidationTestResource("test_subnet", func(r *Resource) {
+					r.References["vpc_id"] = Reference{
+						External: &ExternalReference{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "VPC", FieldPath: "status.atProvider.id"},
+					}
+					r.References["name"] = Reference{
+						TerraformName: "test_vpc",
+						External:      &ExternalReference{Group: "ec2.aws.upbound.io", Kind: "VPC"},
+					}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "name", Message: "terraformName, type, extractor and targets cannot be set together with an external reference"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "name", Message: "group, version and kind of an external reference must be set"},
+			), "invalid provider configuration"),
+		},
+		"InvalidExtractorTemplates": {
+			reason: "An extractor template should be parsable, single-line and not be set together with the other extractors",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc"),
+				"test_subnet": newValidationTestResource("test_subnet", func(r *Resource) {
+					r.References["vpc_id"] = Reference{
+						TerraformName:     "test_vpc",
+						ExtractorTemplate: "arn:aws:ec2:{{ .spec.forProvider.region }}:vpc/{{ .external_name | ToLower }}",
+					}
+					r.References["name"] = Reference{
+						TerraformName:     "test_vpc",
+						Extractor:         "example.com/extractor.VPCID()",
+						ExtractorTemplate: "{{ .external_name",
+					}
+					r.References["rule.port"] = Reference{
+						External:          &ExternalReference{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "VPC", FieldPath: "status.atProvider.id"},
+						ExtractorTemplate: "{{ .status.atProvider.id }}\n{{ .external_name }}",
+					}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "name", Message: "extractor, targets and the field path of an external reference cannot be set together with an extractor template"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "name", Message: "cannot parse the extractor template: template: extractor:1: unclosed action"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "rule.port", Message: "extractor, targets and the field path of an external reference cannot be set together with an extractor template"},
+				&ValidationError{Resource: "test_subnet", Field: "References", Path: "rule.port", Message: "extractor template must be a single line"},
+			), "invalid provider configuration"),
+		},
+		"InvalidValueFromFields": {
+			reason: "Only the values of the top-level, primitive and non-referencing arguments can be sourced from other objects",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc", func(r *Resource) {
+					r.useTerraformPluginSDKClient = true
+					r.SchemaElementOptions.SetValueFrom("vpc_id")
+					r.SchemaElementOptions.SetValueFrom("tags")
+					r.SchemaElementOptions.SetValueFrom("rule.port")
+					r.SchemaElementOptions.SetValueFrom("name")
+					r.References["name"] = Reference{TerraformName: "test_vpc"}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_vpc", Field: "SchemaElementOptions.ValueFrom", Path: "name", Message: "values of the referencing arguments cannot be sourced from other objects"},
+				&ValidationError{Resource: "test_vpc", Field: "SchemaElementOptions.ValueFrom", Path: "rule.port", Message: "cannot find the top-level argument in the Terraform schema"},
+				&ValidationError{Resource: "test_vpc", Field: "SchemaElementOptions.ValueFrom", Path: "tags", Message: "only the values of the primitive arguments can be sourced from other objects"},
+			), "invalid provider configuration"),
+		},
+		"ValueFromFieldsOfCLIResource": {
+			reason: "The values of the arguments of the resources reconciled via the Terraform CLI cannot be sourced from other objects",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc", func(r *Resource) {
+					r.SchemaElementOptions.SetValueFrom("vpc_id")
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_vpc", Field: "SchemaElementOptions.ValueFrom", Path: "vpc_id", Message: "values can only be sourced from other objects for the resources reconciled via the Terraform plugin SDK or the Terraform Plugin Framework clients"},
+			), "invalid provider configuration"),
+		},
+		"InvalidMergeStrategies": {
+			reason: "Server-side apply merge strategies should match the types of the fields",
+			resources: map[string]*Resource{
+				"test_vpc": newValidationTestResource("test_vpc", func(r *Resource) {
+					r.ServerSideApplyMergeStrategies["name"] = MergeStrategy{MapMergeStrategy: MapTypeAtomic}
+					r.ServerSideApplyMergeStrategies["tags"] = MergeStrategy{ListMergeStrategy: ListMergeStrategy{MergeStrategy: ListTypeSet}}
+					r.ServerSideApplyMergeStrategies["rule"] = MergeStrategy{
+						ListMergeStrategy: ListMergeStrategy{
+							MergeStrategy: ListTypeMap,
+							ListMapKeys:   ListMapKeys{Keys: []string{"protocol"}, InjectedKey: InjectedKey{Key: "port"}},
+						},
+					}
+					r.ServerSideApplyMergeStrategies["rules"] = MergeStrategy{ListMergeStrategy: ListMergeStrategy{MergeStrategy: ListTypeAtomic}}
+				}),
+			},
+			want: errors.Wrap(errors.Join(
+				&ValidationError{Resource: "test_vpc", Field: "ServerSideApplyMergeStrategies", Path: "name", Message: "merge strategies can only be configured for lists, sets and maps"},
+				&ValidationError{Resource: "test_vpc", Field: "ServerSideApplyMergeStrategies", Path: "rule", Message: `list map key "protocol" cannot be found in the element schema`},
+				&ValidationError{Resource: "test_vpc", Field: "ServerSideApplyMergeStrategies", Path: "rule", Message: `injected list map key "port" already exists in the element schema`},
+				&ValidationError{Resource: "test_vpc", Field: "ServerSideApplyMergeStrategies", Path: "rules", Message: "cannot find the field in the Terraform schema"},
+				&ValidationError{Resource: "test_vpc", Field: "ServerSideApplyMergeStrategies", Path: "tags", Message: "field is a map and only MapMergeStrategy must be set"},
+			), "invalid provider configuration"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &Provider{Resources: tc.resources}
+			err := p.Validate()
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
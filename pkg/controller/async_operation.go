@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+)
+
+const (
+	errFmtOrphanedCreate         = "the asynchronous create operation that started at %s was interrupted and the identifier of the external resource is unknown, so the external resource may have been created without being recorded: set the %s annotation to adopt the external resource, or remove the %s annotation to create it"
+	errPersistAsyncCreate        = "cannot persist the outcome of the asynchronous create operation"
+	errUnmarshalAsyncCreateState = "cannot unmarshal the partial state recorded for the asynchronous create operation"
+
+	reasonAdoptedOrphanedCreate event.Reason = "AdoptedOrphanedCreate"
+	reasonOrphanedCreate        event.Reason = "OrphanedCreate"
+)
+
+// recordAsyncCreate records the asynchronous create operation that has just
+// been started on the managed resource, which is persisted by the managed
+// reconciler together with the other annotations after the Create call.
+// The asynchronous update and delete operations are not recorded because it's
+// safe to repeat them if they are interrupted.
+func recordAsyncCreate(mg xpresource.Managed, start time.Time) {
+	resource.SetAsyncOperation(mg, resource.AsyncOperation{
+		Type:      string(opCreate),
+		StartTime: metav1.NewTime(start),
+	})
+}
+
+// finishAsyncCreate updates the record of the asynchronous create operation
+// that has ended. If the operation has failed after the provider returned the
+// Terraform ID or a partial state of the external resource, they are recorded
+// so that the external resource can still be observed if the in-memory state
+// is lost, e.g., by a restart of the provider. Otherwise, the record is
+// removed. Only the top-level attributes of the partial state that are not
+// sensitive, as reported by the given function, and that are not nested are
+// recorded because the record is stored in an annotation.
+func finishAsyncCreate(mg xpresource.Managed, id string, state map[string]any, sensitive func(string) bool) {
+	op, err := resource.GetAsyncOperation(mg)
+	if err != nil || op == nil {
+		resource.RemoveAsyncOperation(mg)
+		return
+	}
+	attrs := make(map[string]any, len(state))
+	for k, v := range state {
+		switch v.(type) {
+		case string, bool, float64, int, int64:
+			if !sensitive(k) {
+				attrs[k] = v
+			}
+		}
+	}
+	if id == "" && len(attrs) == 0 {
+		resource.RemoveAsyncOperation(mg)
+		return
+	}
+	op.ID = id
+	op.State = nil
+	if len(attrs) > 0 {
+		// marshaling a map of scalar values cannot fail
+		op.State, _ = json.Marshal(attrs)
+	}
+	resource.SetAsyncOperation(mg, *op)
+}
+
+// asyncCreateState returns the Terraform ID and the partial state recorded
+// for the failed or interrupted asynchronous create operation of the managed
+// resource, if any, to reconstruct the Terraform state of the external
+// resource.
+func asyncCreateState(mg xpresource.Managed) (string, map[string]any, error) {
+	op, err := resource.GetAsyncOperation(mg)
+	if err != nil || op == nil || op.Type != string(opCreate) {
+		return "", nil, err
+	}
+	if len(op.State) == 0 {
+		return op.ID, nil, nil
+	}
+	state := make(map[string]any)
+	if err := json.Unmarshal(op.State, &state); err != nil {
+		return "", nil, errors.Wrap(err, errUnmarshalAsyncCreateState)
+	}
+	return op.ID, state, nil
+}
+
+// persistAsyncCreate persists the outcome of the asynchronous create
+// operation as soon as the operation finishes, without waiting for the next
+// observation of the managed resource: it records the external-name of the
+// managed resource, if known, and the record of the operation as updated by
+// finishAsyncCreate, i.e., it's removed unless the operation has failed with
+// a partial state.
+func persistAsyncCreate(ctx context.Context, kube client.Client, mg xpresource.Managed) error {
+	name := meta.GetExternalName(mg)
+	record, recorded := mg.GetAnnotations()[resource.AnnotationKeyAsyncOperation]
+	latest := mg.DeepCopyObject().(xpresource.Managed)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kube.Get(ctx, client.ObjectKeyFromObject(mg), latest); err != nil {
+			return err
+		}
+		current, ok := latest.GetAnnotations()[resource.AnnotationKeyAsyncOperation]
+		changed := false
+		switch {
+		case recorded && (!ok || current != record):
+			meta.AddAnnotations(latest, map[string]string{resource.AnnotationKeyAsyncOperation: record})
+			changed = true
+		case !recorded && ok:
+			resource.RemoveAsyncOperation(latest)
+			changed = true
+		}
+		if name != "" && meta.GetExternalName(latest) != name {
+			meta.SetExternalName(latest, name)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		return kube.Update(ctx, latest)
+	})
+	return errors.Wrap(err, errPersistAsyncCreate)
+}
+
+// endedOperationStart returns the start time of the given operation if it
+// has ended, or the zero time.
+func endedOperationStart(o *terraform.Operation) time.Time {
+	if !o.IsEnded() {
+		return time.Time{}
+	}
+	return o.StartTime()
+}
+
+// recoverAsyncCreate reconciles the record of an asynchronous create
+// operation that is no longer running. If the operation has been started
+// in this process, which has ended at the given time, the record has
+// outlived it and it's removed. Otherwise, the operation has been
+// interrupted, e.g., by a restart of the provider:
+//   - An existing external resource is adopted.
+//   - If the external resource does not exist while its identifier is known,
+//     the operation has not created it and it's safe to create it again.
+//   - If the identifier is not known, the external resource may have been
+//     created without being recorded. An error is returned instead of creating
+//     a duplicate until the record is resolved by a manual intervention.
+func recoverAsyncCreate(mg xpresource.Managed, recorder event.Recorder, lastStart time.Time, o managed.ExternalObservation) (managed.ExternalObservation, error) {
+	op, err := resource.GetAsyncOperation(mg)
+	if err != nil || op == nil || op.Type != string(opCreate) {
+		return o, err
+	}
+	started := op.StartTime.Format(time.RFC3339)
+	interrupted := lastStart.IsZero() || !op.StartTime.Time.Equal(lastStart.Truncate(time.Second))
+	switch {
+	case o.ResourceExists:
+		if interrupted {
+			recordEvent(mg, recorder, event.Normal(reasonAdoptedOrphanedCreate, fmt.Sprintf("Adopted the external resource of the interrupted asynchronous create operation that started at %s", started)))
+		}
+		// we signal an annotation update with the late-initialized flag, as
+		// done for the external-name recovered from a partial state.
+		o.ResourceLateInitialized = true
+	case !interrupted || meta.GetExternalName(mg) != "" || op.ID != "" || len(op.State) > 0:
+		// the operation has not created the external resource, which will
+		// be created again. The external resource of an interrupted
+		// operation is also known if the provider returned its Terraform ID
+		// or a partial state, from which the Terraform state has been
+		// reconstructed for the observation.
+	case meta.WasDeleted(mg):
+		recordEvent(mg, recorder, event.Warning(reasonOrphanedCreate, errors.Errorf("the asynchronous create operation that started at %s was interrupted and the identifier of the external resource is unknown, so the external resource may have been created without being recorded and it is not deleted", started)))
+	default:
+		err := errors.Errorf(errFmtOrphanedCreate, started, meta.AnnotationKeyExternalName, resource.AnnotationKeyAsyncOperation)
+		recordEvent(mg, recorder, event.Warning(reasonOrphanedCreate, err))
+		return managed.ExternalObservation{}, err
+	}
+	resource.RemoveAsyncOperation(mg)
+	return o, nil
+}
+
+func recordEvent(mg xpresource.Managed, recorder event.Recorder, e event.Event) {
+	if recorder != nil {
+		recorder.Event(mg, e)
+	}
+}
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+func TestRecoverAsyncCreate(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	started := start.Format(time.RFC3339)
+	errOrphaned := errors.Errorf(errFmtOrphanedCreate, started, meta.AnnotationKeyExternalName, resource.AnnotationKeyAsyncOperation)
+
+	type args struct {
+		recorded     bool
+		recordedID   string
+		externalName string
+		deleted      bool
+		lastStart    time.Time
+		obs          managed.ExternalObservation
+	}
+	type want struct {
+		obs      managed.ExternalObservation
+		err      error
+		recorded bool
+		events   []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRecord": {
+			reason: "The observation should not be changed if there is no record of an operation.",
+			args:   args{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+			want:   want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"EndedOperation": {
+			reason: "The record of an operation that has ended in this process should be removed.",
+			args:   args{recorded: true, lastStart: start.Add(300 * time.Millisecond), obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+			want:   want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true}},
+		},
+		"FailedOperation": {
+			reason: "The record of a failed operation that has ended in this process should be removed so that the resource is created again.",
+			args:   args{recorded: true, lastStart: start},
+		},
+		"Adopt": {
+			reason: "The existing external resource of an interrupted operation should be adopted.",
+			args:   args{recorded: true, externalName: "some-id", obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				events: []event.Reason{reasonAdoptedOrphanedCreate},
+			},
+		},
+		"NotCreated": {
+			reason: "An interrupted operation should be repeated if the external resource with a known identifier does not exist.",
+			args:   args{recorded: true, externalName: "some-name"},
+		},
+		"AdoptPartialState": {
+			reason: "The existing external resource of an interrupted operation that has returned its Terraform ID should be adopted.",
+			args:   args{recorded: true, recordedID: "some-id", obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+			want: want{
+				obs:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				events: []event.Reason{reasonAdoptedOrphanedCreate},
+			},
+		},
+		"NotCreatedPartialState": {
+			reason: "An interrupted operation should be repeated if the external resource with a recorded Terraform ID does not exist.",
+			args:   args{recorded: true, recordedID: "some-id"},
+		},
+		"Orphaned": {
+			reason: "An interrupted operation should not be repeated if the identifier of the external resource is unknown.",
+			args:   args{recorded: true},
+			want: want{
+				err:      errOrphaned,
+				recorded: true,
+				events:   []event.Reason{reasonOrphanedCreate},
+			},
+		},
+		"OrphanedDeleted": {
+			reason: "The deletion of a managed resource should not be blocked by an interrupted operation.",
+			args:   args{recorded: true, deleted: true},
+			want:   want{events: []event.Reason{reasonOrphanedCreate}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := fake.NewTerraformed()
+			if tc.args.recorded {
+				resource.SetAsyncOperation(mg, resource.AsyncOperation{Type: string(opCreate), StartTime: metav1.NewTime(start), ID: tc.args.recordedID})
+			}
+			if tc.args.externalName != "" {
+				meta.SetExternalName(mg, tc.args.externalName)
+			}
+			if tc.args.deleted {
+				now := metav1.Now()
+				mg.SetDeletionTimestamp(&now)
+			}
+			r := &driftTestRecorder{}
+			obs, err := recoverAsyncCreate(mg, r, tc.args.lastStart, tc.args.obs)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrecoverAsyncCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nrecoverAsyncCreate(...): -want observation, +got observation:\n%s", tc.reason, diff)
+			}
+			_, recorded := mg.GetAnnotations()[resource.AnnotationKeyAsyncOperation]
+			if diff := cmp.Diff(tc.want.recorded, recorded); diff != "" {
+				t.Errorf("\n%s\nrecoverAsyncCreate(...): -want recorded, +got recorded:\n%s", tc.reason, diff)
+			}
+			var reasons []event.Reason
+			for _, ev := range r.events {
+				reasons = append(reasons, ev.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, reasons); diff != "" {
+				t.Errorf("\n%s\nrecoverAsyncCreate(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPersistAsyncCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+	type args struct {
+		externalName string
+		record       string
+		latest       map[string]string
+		getErr       error
+	}
+	type want struct {
+		err     error
+		updated map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"PersistExternalName": {
+			reason: "The external-name should be persisted and the record of the operation should be removed.",
+			args: args{
+				externalName: "some-id",
+				latest:       map[string]string{resource.AnnotationKeyAsyncOperation: "{}", "some": "annotation"},
+			},
+			want: want{updated: map[string]string{meta.AnnotationKeyExternalName: "some-id", "some": "annotation"}},
+		},
+		"RemoveRecord": {
+			reason: "The record of the operation should be removed if the external-name is not known.",
+			args: args{
+				latest: map[string]string{resource.AnnotationKeyAsyncOperation: "{}"},
+			},
+			want: want{updated: map[string]string{}},
+		},
+		"PersistPartialState": {
+			reason: "The record of a failed operation with a partial state should be persisted.",
+			args: args{
+				record: `{"type":"create","startTime":"2025-01-02T03:04:05Z","id":"some-id"}`,
+				latest: map[string]string{resource.AnnotationKeyAsyncOperation: `{"type":"create","startTime":"2025-01-02T03:04:05Z"}`},
+			},
+			want: want{updated: map[string]string{resource.AnnotationKeyAsyncOperation: `{"type":"create","startTime":"2025-01-02T03:04:05Z","id":"some-id"}`}},
+		},
+		"NoChanges": {
+			reason: "The managed resource should not be updated if there is nothing to persist.",
+			args: args{
+				externalName: "some-id",
+				latest:       map[string]string{meta.AnnotationKeyExternalName: "some-id"},
+			},
+		},
+		"GetError": {
+			reason: "Errors getting the managed resource should be reported.",
+			args:   args{getErr: errBoom},
+			want:   want{err: errors.Wrap(errBoom, errPersistAsyncCreate)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := fake.NewTerraformed()
+			if tc.args.externalName != "" {
+				meta.SetExternalName(mg, tc.args.externalName)
+			}
+			if tc.args.record != "" {
+				meta.AddAnnotations(mg, map[string]string{resource.AnnotationKeyAsyncOperation: tc.args.record})
+			}
+			var updated map[string]string
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.SetAnnotations(tc.args.latest)
+					return tc.args.getErr
+				},
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					updated = obj.GetAnnotations()
+					return nil
+				},
+			}
+			err := persistAsyncCreate(t.Context(), kube, mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npersistAsyncCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\npersistAsyncCreate(...): -want updated annotations, +got updated annotations:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFinishAsyncCreate(t *testing.T) {
+	start := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	sensitive := func(name string) bool {
+		return name == "password"
+	}
+	type args struct {
+		recorded bool
+		id       string
+		state    map[string]any
+	}
+	type want struct {
+		op    *resource.AsyncOperation
+		id    string
+		state map[string]any
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoRecord": {
+			reason: "Nothing should be recorded if the operation has not been recorded.",
+			args:   args{id: "some-id"},
+		},
+		"NoPartialState": {
+			reason: "The record should be removed if the provider has not returned a partial state.",
+			args:   args{recorded: true},
+		},
+		"PartialState": {
+			reason: "The Terraform ID and the non-sensitive top-level scalar attributes of the partial state should be recorded.",
+			args: args{
+				recorded: true,
+				id:       "some-id",
+				state: map[string]any{
+					"id":       "some-id",
+					"arn":      "some-arn",
+					"enabled":  true,
+					"password": "secret",
+					"tags":     map[string]any{"key": "value"},
+					"subnets":  []any{"subnet-1"},
+				},
+			},
+			want: want{
+				op: &resource.AsyncOperation{
+					Type:      string(opCreate),
+					StartTime: start,
+					ID:        "some-id",
+					State:     json.RawMessage(`{"arn":"some-arn","enabled":true,"id":"some-id"}`),
+				},
+				id:    "some-id",
+				state: map[string]any{"arn": "some-arn", "enabled": true, "id": "some-id"},
+			},
+		},
+		"OnlyID": {
+			reason: "The Terraform ID should be recorded even if no attribute of the partial state can be recorded.",
+			args: args{
+				recorded: true,
+				id:       "some-id",
+				state:    map[string]any{"password": "secret"},
+			},
+			want: want{
+				op: &resource.AsyncOperation{
+					Type:      string(opCreate),
+					StartTime: start,
+					ID:        "some-id",
+				},
+				id: "some-id",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := fake.NewTerraformed()
+			if tc.args.recorded {
+				recordAsyncCreate(mg, start.Time)
+			}
+			finishAsyncCreate(mg, tc.args.id, tc.args.state, sensitive)
+			op, err := resource.GetAsyncOperation(mg)
+			if err != nil {
+				t.Fatalf("GetAsyncOperation(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.op, op); diff != "" {
+				t.Errorf("\n%s\nfinishAsyncCreate(...): -want record, +got record:\n%s", tc.reason, diff)
+			}
+			id, state, err := asyncCreateState(mg)
+			if err != nil {
+				t.Fatalf("asyncCreateState(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("\n%s\nasyncCreateState(...): -want ID, +got ID:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.state, state); diff != "" {
+				t.Errorf("\n%s\nasyncCreateState(...): -want state, +got state:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
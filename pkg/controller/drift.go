@@ -0,0 +1,483 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tf "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/types/name"
+)
+
+const (
+	// maxDriftedFields is the maximum number of the drifted fields reported
+	// in the Drifted condition and the drift events.
+	maxDriftedFields = 10
+	// maxDriftValueLength is the maximum length of the observed and desired
+	// values reported for a drifted field.
+	maxDriftValueLength = 64
+
+	driftValueUnknown = "(known after apply)"
+	driftValueNull    = "null"
+)
+
+// mapKey is a map key step of a Terraform attribute path.
+type mapKey string
+
+// setElement is a set element step of a Terraform attribute path, whose
+// elements are not identified by their indices.
+type setElement struct{}
+
+// driftedField is a spec field whose desired value differs from its
+// observed value.
+type driftedField struct {
+	path      string
+	observed  string
+	desired   string
+	sensitive bool
+	// values are the full observed & desired values, including the
+	// sensitive ones, which are only used for hashing and never reported.
+	values string
+}
+
+func (d driftedField) String() string {
+	switch {
+	case d.sensitive:
+		return d.path + " (sensitive value)"
+	case d.observed == "" && d.desired == "":
+		return d.path
+	default:
+		return fmt.Sprintf("%s (observed: %s, desired: %s)", d.path, d.observed, d.desired)
+	}
+}
+
+// driftMessage returns the message listing at most maxDriftedFields of the
+// drifted fields sorted by their paths.
+func driftMessage(fields []driftedField) string {
+	return "The following fields differ from the external resource: " + fieldListMessage(fields)
+}
+
+// fieldListMessage returns the list of at most maxDriftedFields of the fields
+// sorted by their paths, followed by the number of the omitted fields.
+func fieldListMessage(fields []driftedField) string {
+	slices.SortFunc(fields, func(a, b driftedField) int {
+		return strings.Compare(a.path, b.path)
+	})
+	l := make([]string, 0, min(len(fields), maxDriftedFields))
+	for i, f := range fields {
+		if i == maxDriftedFields {
+			break
+		}
+		l = append(l, f.String())
+	}
+	msg := strings.Join(l, ", ")
+	if len(fields) > maxDriftedFields {
+		msg += fmt.Sprintf(", and %d more", len(fields)-maxDriftedFields)
+	}
+	return msg
+}
+
+// recordDrift sets the Drifted condition of the managed resource listing
+// the drifted fields, and records an event when the drifted fields change.
+// A previously set Drifted condition is cleared if there is no drift.
+func recordDrift(mg xpresource.Managed, recorder event.Recorder, fields []driftedField) {
+	prev := mg.GetCondition(resource.TypeDrifted)
+	if len(fields) == 0 {
+		if prev.Status == corev1.ConditionTrue {
+			mg.SetConditions(resource.NoDriftCondition())
+		}
+		return
+	}
+	msg := driftMessage(fields)
+	if prev.Status == corev1.ConditionTrue && prev.Message == msg {
+		return
+	}
+	mg.SetConditions(resource.DriftedCondition(msg))
+	if recorder != nil {
+		recorder.Event(mg, event.Normal(event.Reason(resource.ReasonDriftDetected), msg))
+	}
+}
+
+// sdkDriftedFields returns the drifted fields in the Terraform plugin SDK
+// instance diff. The flatmap keys of the diff are translated into the spec
+// field paths, and the element counts of the lists, sets and maps are only
+// reported if none of their elements are.
+func sdkDriftedFields(cfg *config.Resource, d *tf.InstanceDiff) []driftedField {
+	if d == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(d.Attributes))
+	for k := range d.Attributes {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var fields []driftedField
+	for _, k := range keys {
+		ad := d.Attributes[k]
+		if ad == nil {
+			continue
+		}
+		flat := strings.Split(k, ".")
+		count := false
+		if last := flat[len(flat)-1]; last == "%" || last == "#" {
+			parent := strings.Join(flat[:len(flat)-1], ".") + "."
+			if slices.ContainsFunc(keys, func(o string) bool { return strings.HasPrefix(o, parent) && o != k }) {
+				continue
+			}
+			flat = flat[:len(flat)-1]
+			count = true
+		}
+		steps := sdkAttributePathSteps(cfg, flat)
+		f := driftedField{
+			path:      terraformFieldPath(cfg, steps).String(),
+			sensitive: ad.Sensitive || isSensitiveTerraformPath(cfg, steps),
+			values:    fmt.Sprintf("%q %q %t %t", ad.Old, ad.New, ad.NewComputed, ad.NewRemoved),
+		}
+		if !f.sensitive && !count {
+			f.observed = truncateDriftValue(strconv.Quote(ad.Old))
+			f.desired = truncateDriftValue(strconv.Quote(ad.New))
+			switch {
+			case ad.NewComputed:
+				f.desired = driftValueUnknown
+			case ad.NewRemoved:
+				f.desired = driftValueNull
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// sdkAttributePathSteps converts the segments of a flatmap key into the
+// attribute path steps. The segments following the maps are their keys and
+// the numeric segments following the lists are their indices.
+func sdkAttributePathSteps(cfg *config.Resource, flat []string) []any {
+	steps := make([]any, 0, len(flat))
+	var attrPath []string
+	var parent *schema.Schema
+	for _, s := range flat {
+		switch {
+		case parent != nil && parent.Type == schema.TypeMap:
+			steps = append(steps, mapKey(s))
+			parent = nil
+			continue
+		case parent != nil && parent.Type == schema.TypeSet:
+			steps = append(steps, setElement{})
+			parent = nil
+			continue
+		case parent != nil && parent.Type == schema.TypeList:
+			if i, err := strconv.Atoi(s); err == nil {
+				steps = append(steps, i)
+				parent = nil
+				continue
+			}
+		}
+		steps = append(steps, s)
+		attrPath = append(attrPath, s)
+		parent = config.GetSchema(cfg.TerraformResource, strings.Join(attrPath, "."))
+	}
+	return steps
+}
+
+// frameworkDriftedFields returns the drifted fields in the diffs between the
+// planned and the prior states of a Terraform Plugin Framework resource.
+// Only the innermost diffs are reported.
+func frameworkDriftedFields(cfg *config.Resource, diffs []tftypes.ValueDiff) []driftedField {
+	var fields []driftedField
+	for i, d := range diffs {
+		if d.Path == nil || len(d.Path.Steps()) == 0 {
+			continue
+		}
+		if slices.ContainsFunc(diffs, func(o tftypes.ValueDiff) bool {
+			n := len(d.Path.Steps())
+			return o.Path != nil && len(o.Path.Steps()) > n && tftypes.NewAttributePathWithSteps(o.Path.Steps()[:n]).Equal(d.Path)
+		}) {
+			continue
+		}
+		steps := make([]any, 0, len(d.Path.Steps()))
+		for _, s := range d.Path.Steps() {
+			switch t := s.(type) {
+			case tftypes.AttributeName:
+				steps = append(steps, string(t))
+			case tftypes.ElementKeyString:
+				steps = append(steps, mapKey(t))
+			case tftypes.ElementKeyInt:
+				steps = append(steps, int(t))
+			case tftypes.ElementKeyValue:
+				steps = append(steps, setElement{})
+			}
+		}
+		f := driftedField{
+			path:      terraformFieldPath(cfg, steps).String(),
+			sensitive: isSensitiveTerraformPath(cfg, steps),
+			values:    tfValueDump(diffs[i].Value2) + " " + tfValueDump(diffs[i].Value1),
+		}
+		if !f.sensitive {
+			f.observed = tfValueString(diffs[i].Value2)
+			f.desired = tfValueString(diffs[i].Value1)
+			// the values of the collections and the objects are not reported.
+			if f.observed == "" || f.desired == "" {
+				f.observed, f.desired = "", ""
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// cliDriftedFields returns the drifted fields in the change of a resource
+// planned by the Terraform CLI. The prior and planned values are compared
+// recursively, and only the innermost differing values are reported.
+func cliDriftedFields(cfg *config.Resource, ch *tfjson.Change) []driftedField {
+	if ch == nil {
+		return nil
+	}
+	var fields []driftedField
+	var walk func(flat []string, before, after any)
+	walk = func(flat []string, before, after any) {
+		unknown := isMarkedJSONPath(ch.AfterUnknown, flat)
+		if !unknown {
+			bm, bok := before.(map[string]any)
+			am, aok := after.(map[string]any)
+			if (bok || aok) && (bok || before == nil) && (aok || after == nil) {
+				keys := make([]string, 0, len(bm)+len(am))
+				for k := range bm {
+					keys = append(keys, k)
+				}
+				for k := range am {
+					if _, ok := bm[k]; !ok {
+						keys = append(keys, k)
+					}
+				}
+				slices.Sort(keys)
+				for _, k := range keys {
+					walk(append(slices.Clone(flat), k), bm[k], am[k])
+				}
+				return
+			}
+			bl, bok := before.([]any)
+			al, aok := after.([]any)
+			if (bok || aok) && (bok || before == nil) && (aok || after == nil) {
+				for i := range max(len(bl), len(al)) {
+					var b, a any
+					if i < len(bl) {
+						b = bl[i]
+					}
+					if i < len(al) {
+						a = al[i]
+					}
+					walk(append(slices.Clone(flat), strconv.Itoa(i)), b, a)
+				}
+				return
+			}
+			if reflect.DeepEqual(before, after) {
+				return
+			}
+		}
+		if len(flat) == 0 {
+			return
+		}
+		steps := sdkAttributePathSteps(cfg, flat)
+		f := driftedField{
+			path:      terraformFieldPath(cfg, steps).String(),
+			sensitive: isMarkedJSONPath(ch.BeforeSensitive, flat) || isMarkedJSONPath(ch.AfterSensitive, flat) || isSensitiveTerraformPath(cfg, steps),
+			values:    fmt.Sprintf("%#v %#v %t", before, after, unknown),
+		}
+		if !f.sensitive {
+			f.observed = jsonValueString(before)
+			f.desired = jsonValueString(after)
+			if unknown {
+				f.desired = driftValueUnknown
+			}
+			// the values of the collections and the objects are not reported.
+			if f.observed == "" || f.desired == "" {
+				f.observed, f.desired = "", ""
+			}
+		}
+		fields = append(fields, f)
+	}
+	walk(nil, ch.Before, ch.After)
+	return fields
+}
+
+// isMarkedJSONPath returns true if the value at the path or one of its
+// ancestors is marked with true in the JSON value, such as the
+// after_unknown and the after_sensitive values of a planned change.
+func isMarkedJSONPath(v any, flat []string) bool {
+	for i := 0; ; i++ {
+		if b, ok := v.(bool); ok {
+			return b
+		}
+		if i == len(flat) {
+			return false
+		}
+		switch t := v.(type) {
+		case map[string]any:
+			v = t[flat[i]]
+		case []any:
+			idx, err := strconv.Atoi(flat[i])
+			if err != nil || idx < 0 || idx >= len(t) {
+				return false
+			}
+			v = t[idx]
+		default:
+			return false
+		}
+	}
+}
+
+// jsonValueString returns the string representation of a primitive JSON
+// value, or an empty string for the arrays and the objects.
+func jsonValueString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return driftValueNull
+	case string:
+		return truncateDriftValue(strconv.Quote(t))
+	case float64:
+		return truncateDriftValue(strconv.FormatFloat(t, 'g', -1, 64))
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return ""
+}
+
+// tfValueDump returns the full string representation of a Terraform value.
+func tfValueDump(v *tftypes.Value) string {
+	if v == nil {
+		return driftValueNull
+	}
+	return v.String()
+}
+
+// tfValueString returns the string representation of a primitive Terraform
+// value, or an empty string for the collections and the objects.
+func tfValueString(v *tftypes.Value) string {
+	switch {
+	case v == nil || v.IsNull():
+		return driftValueNull
+	case !v.IsKnown():
+		return driftValueUnknown
+	}
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		if err := v.As(&s); err == nil {
+			return truncateDriftValue(strconv.Quote(s))
+		}
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		if err := v.As(&n); err == nil {
+			return truncateDriftValue(n.Text('g', -1))
+		}
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		if err := v.As(&b); err == nil {
+			return strconv.FormatBool(b)
+		}
+	}
+	return ""
+}
+
+func truncateDriftValue(s string) string {
+	if len(s) <= maxDriftValueLength {
+		return s
+	}
+	return s[:maxDriftValueLength] + "..."
+}
+
+// isSensitiveTerraformPath returns true if the attribute at the path or one
+// of its ancestors is sensitive.
+func isSensitiveTerraformPath(cfg *config.Resource, steps []any) bool {
+	if cfg.TerraformResource == nil {
+		return false
+	}
+	var attrPath []string
+	for _, s := range steps {
+		a, ok := s.(string)
+		if !ok {
+			continue
+		}
+		attrPath = append(attrPath, a)
+		if sch := config.GetSchema(cfg.TerraformResource, strings.Join(attrPath, ".")); sch != nil && sch.Sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// terraformFieldPath returns the path of the field corresponding to the
+// Terraform attribute path, which is under spec.forProvider for the
+// arguments and under status.atProvider for the computed-only attributes.
+func terraformFieldPath(cfg *config.Resource, steps []any) *field.Path {
+	root := field.NewPath("spec", "forProvider")
+	if len(steps) > 0 && cfg.TerraformResource != nil {
+		if top, ok := steps[0].(string); ok {
+			if sch := cfg.TerraformResource.Schema[top]; sch != nil && !sch.Optional && !sch.Required {
+				root = field.NewPath("status", "atProvider")
+			}
+		}
+	}
+	return crdFieldPath(cfg, root, steps)
+}
+
+// crdFieldPath returns the path of the field under root corresponding to the
+// Terraform attribute path, which consists of the attribute names, the map
+// keys, the list indices and the set elements. The attribute names following
+// the maps in the SDKv2 schemas are also taken as their keys. The indices of
+// the singleton lists converted to embedded objects are dropped.
+func crdFieldPath(cfg *config.Resource, root *field.Path, steps []any) *field.Path {
+	p := root
+	var tfPath []string
+	embedded, isMap := false, false
+	for _, s := range steps {
+		switch t := s.(type) {
+		case mapKey:
+			isMap = false
+			p = p.Key(string(t))
+		case string:
+			if isMap {
+				isMap = false
+				p = p.Key(t)
+				continue
+			}
+			tfPath = append(tfPath, t)
+			p = p.Child(name.NewFromSnake(t).LowerCamelComputed)
+			embedded = cfg.SchemaElementOptions.EmbeddedObject(strings.Join(tfPath, "."))
+			isMap = false
+			if sch := config.GetSchema(cfg.TerraformResource, strings.Join(tfPath, ".")); sch != nil {
+				isMap = sch.Type == schema.TypeMap
+			}
+		case int:
+			if embedded {
+				embedded = false
+				continue
+			}
+			p = p.Index(t)
+		case setElement:
+			if embedded {
+				embedded = false
+				continue
+			}
+			p = p.Key("*")
+		}
+	}
+	return p
+}
@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tf "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+func newDriftTestConfig() *config.Resource {
+	cfg := &config.Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_type": {Type: schema.TypeString, Optional: true},
+				"password":      {Type: schema.TypeString, Optional: true, Sensitive: true},
+				"tags":          {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"arn":           {Type: schema.TypeString, Computed: true},
+				"rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"port": {Type: schema.TypeInt, Optional: true},
+						},
+					},
+				},
+				"settings": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"enabled": {Type: schema.TypeBool, Optional: true},
+						},
+					},
+				},
+			},
+		},
+		SchemaElementOptions: config.SchemaElementOptions{},
+	}
+	cfg.SchemaElementOptions.SetEmbeddedObject("settings")
+	return cfg
+}
+
+func TestSDKDriftedFields(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		diff   *tf.InstanceDiff
+		want   []driftedField
+	}{
+		"NoDiff": {
+			reason: "No drifted fields should be reported for an empty diff.",
+			diff:   tf.NewInstanceDiff(),
+		},
+		"DriftedFields": {
+			reason: "The flatmap keys should be translated into the field paths, and the sensitive values should be redacted.",
+			diff: &tf.InstanceDiff{
+				Attributes: map[string]*tf.ResourceAttrDiff{
+					"instance_type":      {Old: "t3.large", New: "t3.micro"},
+					"password":           {Old: "old", New: "new"},
+					"tags.%":             {Old: "1", New: "1"},
+					"tags.env":           {Old: "dev", New: "prod"},
+					"rule.1.port":        {Old: "80", New: "443"},
+					"settings.0.enabled": {Old: "false", New: "true"},
+					"arn":                {NewComputed: true},
+				},
+			},
+			want: []driftedField{
+				{path: "status.atProvider.arn", observed: `""`, desired: driftValueUnknown},
+				{path: "spec.forProvider.instanceType", observed: `"t3.large"`, desired: `"t3.micro"`},
+				{path: "spec.forProvider.password", sensitive: true},
+				{path: "spec.forProvider.rule[1].port", observed: `"80"`, desired: `"443"`},
+				{path: "spec.forProvider.settings.enabled", observed: `"false"`, desired: `"true"`},
+				{path: "spec.forProvider.tags[env]", observed: `"dev"`, desired: `"prod"`},
+			},
+		},
+		"ElementCount": {
+			reason: "The element count of a list should be reported at the list if none of its elements are.",
+			diff: &tf.InstanceDiff{
+				Attributes: map[string]*tf.ResourceAttrDiff{
+					"rule.#": {Old: "2", New: "0"},
+				},
+			},
+			want: []driftedField{
+				{path: "spec.forProvider.rule"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := sdkDriftedFields(newDriftTestConfig(), tc.diff)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(driftedField{}), cmpopts.IgnoreFields(driftedField{}, "values"), cmpopts.SortSlices(func(a, b driftedField) bool { return a.path < b.path })); diff != "" {
+				t.Errorf("\n%s\nsdkDriftedFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFrameworkDriftedFields(t *testing.T) {
+	planned := tftypes.NewValue(tftypes.String, "t3.micro")
+	prior := tftypes.NewValue(tftypes.String, "t3.large")
+	ruleType := tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"port": tftypes.Number}}}
+	cases := map[string]struct {
+		reason string
+		diffs  []tftypes.ValueDiff
+		want   []driftedField
+	}{
+		"InnermostDiffs": {
+			reason: "Only the innermost diffs should be reported with their primitive values.",
+			diffs: []tftypes.ValueDiff{
+				{Path: tftypes.NewAttributePath().WithAttributeName("instance_type"), Value1: &planned, Value2: &prior},
+				{Path: tftypes.NewAttributePath().WithAttributeName("rule"), Value1: valuePtr(tftypes.NewValue(ruleType, nil)), Value2: valuePtr(tftypes.NewValue(ruleType, nil))},
+				{Path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"), Value1: valuePtr(tftypes.NewValue(tftypes.Number, 443)), Value2: valuePtr(tftypes.NewValue(tftypes.Number, nil))},
+				{Path: tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"), Value1: &planned, Value2: &prior},
+				{Path: tftypes.NewAttributePath().WithAttributeName("password"), Value1: &planned, Value2: &prior},
+			},
+			want: []driftedField{
+				{path: "spec.forProvider.instanceType", observed: `"t3.large"`, desired: `"t3.micro"`},
+				{path: "spec.forProvider.rule[0].port", observed: driftValueNull, desired: "443"},
+				{path: "spec.forProvider.tags[env]", observed: `"t3.large"`, desired: `"t3.micro"`},
+				{path: "spec.forProvider.password", sensitive: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := frameworkDriftedFields(newDriftTestConfig(), tc.diffs)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(driftedField{}), cmpopts.IgnoreFields(driftedField{}, "values")); diff != "" {
+				t.Errorf("\n%s\nframeworkDriftedFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCLIDriftedFields(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		change *tfjson.Change
+		want   []driftedField
+	}{
+		"NoChange": {
+			reason: "No drifted fields should be reported for a change without any differing values.",
+			change: &tfjson.Change{
+				Before: map[string]any{"instance_type": "t3.large"},
+				After:  map[string]any{"instance_type": "t3.large"},
+			},
+		},
+		"InnermostValues": {
+			reason: "Only the innermost differing values should be reported, and the sensitive and unknown values should be marked.",
+			change: &tfjson.Change{
+				Before: map[string]any{
+					"instance_type": "t3.large",
+					"password":      "old",
+					"tags":          map[string]any{"env": "dev"},
+					"rule":          []any{map[string]any{"port": float64(80)}},
+					"settings":      []any{map[string]any{"enabled": false}},
+					"arn":           "arn:old",
+				},
+				After: map[string]any{
+					"instance_type": "t3.micro",
+					"password":      "new",
+					"tags":          map[string]any{"env": "prod"},
+					"rule":          []any{map[string]any{"port": float64(443)}, map[string]any{"port": float64(22)}},
+					"settings":      []any{map[string]any{"enabled": true}},
+				},
+				AfterUnknown:    map[string]any{"arn": true},
+				BeforeSensitive: map[string]any{"password": true},
+				AfterSensitive:  map[string]any{"password": true},
+			},
+			want: []driftedField{
+				{path: "status.atProvider.arn", observed: `"arn:old"`, desired: driftValueUnknown},
+				{path: "spec.forProvider.instanceType", observed: `"t3.large"`, desired: `"t3.micro"`},
+				{path: "spec.forProvider.password", sensitive: true},
+				{path: "spec.forProvider.rule[0].port", observed: "80", desired: "443"},
+				{path: "spec.forProvider.rule[1].port", observed: driftValueNull, desired: "22"},
+				{path: "spec.forProvider.settings.enabled", observed: "false", desired: "true"},
+				{path: "spec.forProvider.tags[env]", observed: `"dev"`, desired: `"prod"`},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := cliDriftedFields(newDriftTestConfig(), tc.change)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(driftedField{}), cmpopts.IgnoreFields(driftedField{}, "values"), cmpopts.SortSlices(func(a, b driftedField) bool { return a.path < b.path })); diff != "" {
+				t.Errorf("\n%s\ncliDriftedFields(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func valuePtr(v tftypes.Value) *tftypes.Value {
+	return &v
+}
+
+type driftTestRecorder struct {
+	events []event.Event
+}
+
+func (r *driftTestRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *driftTestRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestRecordDrift(t *testing.T) {
+	fields := make([]driftedField, 0, maxDriftedFields+2)
+	for i := range maxDriftedFields + 2 {
+		fields = append(fields, driftedField{path: fmt.Sprintf("spec.forProvider.field%02d", i), observed: "1", desired: "2"})
+	}
+	msg := driftMessage(fields)
+
+	type want struct {
+		status corev1.ConditionStatus
+		msg    string
+		events int
+	}
+	cases := map[string]struct {
+		reason string
+		prev   *string
+		fields []driftedField
+		want   want
+	}{
+		"NoDriftNoCondition": {
+			reason: "No Drifted condition should be set if there has not been any drift.",
+			want:   want{status: corev1.ConditionUnknown},
+		},
+		"Drift": {
+			reason: "The Drifted condition should list a bounded number of fields and an event should be recorded.",
+			fields: fields,
+			want:   want{status: corev1.ConditionTrue, msg: msg, events: 1},
+		},
+		"SameDrift": {
+			reason: "No event should be recorded if the drifted fields have not changed.",
+			prev:   &msg,
+			fields: fields,
+			want:   want{status: corev1.ConditionTrue, msg: msg},
+		},
+		"DriftResolved": {
+			reason: "A previous Drifted condition should be cleared if there is no drift.",
+			prev:   &msg,
+			want:   want{status: corev1.ConditionFalse},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := fake.NewTerraformed()
+			if tc.prev != nil {
+				mg.SetConditions(resource.DriftedCondition(*tc.prev))
+			}
+			r := &driftTestRecorder{}
+			recordDrift(mg, r, tc.fields)
+			c := mg.GetCondition(resource.TypeDrifted)
+			got := want{status: c.Status, msg: c.Message, events: len(r.events)}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nrecordDrift(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+	if want := ", and 2 more"; msg[len(msg)-len(want):] != want {
+		t.Errorf("driftMessage(...): want the message to end with %q, got %q", want, msg)
+	}
+}
+
+func TestReplacementHash(t *testing.T) {
+	cfg := newDriftTestConfig()
+	hash := func(attrs map[string]*tf.ResourceAttrDiff) string {
+		return replacementHash(sdkDriftedFields(cfg, &tf.InstanceDiff{Attributes: attrs}))
+	}
+	long := strings.Repeat("x", 100)
+	base := hash(map[string]*tf.ResourceAttrDiff{
+		"password":      {Old: "old", New: "new", RequiresNew: true},
+		"instance_type": {Old: "t3.large", New: long + "a", RequiresNew: true},
+	})
+	cases := map[string]struct {
+		reason string
+		attrs  map[string]*tf.ResourceAttrDiff
+	}{
+		"SensitiveValue": {
+			reason: "A change in a planned sensitive value should change the hash.",
+			attrs: map[string]*tf.ResourceAttrDiff{
+				"password":      {Old: "old", New: "other", RequiresNew: true},
+				"instance_type": {Old: "t3.large", New: long + "a", RequiresNew: true},
+			},
+		},
+		"TruncatedValue": {
+			reason: "A change in a planned value beyond its reported prefix should change the hash.",
+			attrs: map[string]*tf.ResourceAttrDiff{
+				"password":      {Old: "old", New: "new", RequiresNew: true},
+				"instance_type": {Old: "t3.large", New: long + "b", RequiresNew: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := hash(tc.attrs); got == base {
+				t.Errorf("\n%s\nreplacementHash(...): want a hash different from %q", tc.reason, base)
+			}
+		})
+	}
+}
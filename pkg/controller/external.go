@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
@@ -74,6 +75,14 @@ func WithConnectorEventHandler(e *handler.EventHandler) Option {
 	}
 }
 
+// WithEventRecorder configures an event.Recorder for the Connector, which
+// records the planned changes in the plan-only reconciliation mode.
+func WithEventRecorder(r event.Recorder) Option {
+	return func(c *Connector) {
+		c.eventRecorder = r
+	}
+}
+
 // NewConnector returns a new Connector object.
 func NewConnector(kube client.Client, ws Store, sf terraform.SetupFn, cfg *config.Resource, opts ...Option) *Connector {
 	c := &Connector{
@@ -98,6 +107,7 @@ type Connector struct {
 	config            *config.Resource
 	callback          CallbackProvider
 	eventHandler      *handler.EventHandler
+	eventRecorder     event.Recorder
 	logger            logging.Logger
 }
 
@@ -120,11 +130,13 @@ func (c *Connector) Connect(ctx context.Context, mg xpresource.Managed) (managed
 	}
 	return &external{
 		workspace:         ws,
+		operation:         ws.LastOperation,
 		config:            c.config,
 		callback:          c.callback,
 		providerScheduler: ts.Scheduler,
 		providerHandle:    ws.ProviderHandle,
 		eventHandler:      c.eventHandler,
+		eventRecorder:     c.eventRecorder,
 		kube:              c.kube,
 		logger:            c.logger.WithValues("uid", mg.GetUID(), "namespace", mg.GetNamespace(), "name", mg.GetName(), "gvk", mg.GetObjectKind().GroupVersionKind().String()),
 	}, nil
@@ -132,11 +144,13 @@ func (c *Connector) Connect(ctx context.Context, mg xpresource.Managed) (managed
 
 type external struct {
 	workspace         Workspace
+	operation         *terraform.Operation
 	config            *config.Resource
 	callback          CallbackProvider
 	providerScheduler terraform.ProviderScheduler
 	providerHandle    terraform.ProviderHandle
 	eventHandler      *handler.EventHandler
+	eventRecorder     event.Recorder
 	kube              client.Client
 	logger            logging.Logger
 }
@@ -215,6 +229,8 @@ func (e *external) Observe(ctx context.Context, mg xpresource.Managed) (managed.
 		return e.Import(ctx, tr)
 	}
 
+	// the last operation of the workspace is flushed by the refresh.
+	lastStart := e.endedAsyncCreateStart(mg)
 	res, err := e.workspace.Refresh(ctx)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errRefresh)
@@ -222,15 +238,26 @@ func (e *external) Observe(ctx context.Context, mg xpresource.Managed) (managed.
 
 	switch {
 	case res.ASyncInProgress:
+		if e.operation != nil {
+			supersedeOperation(mg, e.operation, e.eventRecorder)
+		}
 		mg.SetConditions(resource.AsyncOperationOngoingCondition())
 		return managed.ExternalObservation{
 			ResourceExists:   true,
 			ResourceUpToDate: true,
 		}, nil
 	case !res.Exists:
-		return managed.ExternalObservation{
+		o, err := recoverAsyncCreate(mg, e.eventRecorder, lastStart, managed.ExternalObservation{
 			ResourceExists: false,
-		}, nil
+		})
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if resource.IsPlanOnly(mg) {
+			recordPlan(mg, e.eventRecorder, plannedAction(mg, false, false, false), nil)
+			o = planOnlyObservation(mg, o)
+		}
+		return o, nil
 	}
 	// There might be a case where async operation is finished and the status
 	// update marking it as finished didn't go through. At this point, we are
@@ -261,6 +288,13 @@ func (e *external) Observe(ctx context.Context, mg xpresource.Managed) (managed.
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, "cannot set critical annotations")
 	}
+	// the record of the async create operation, if any, is resolved now that
+	// its external resource has been observed.
+	recovered, err := recoverAsyncCreate(mg, e.eventRecorder, lastStart, managed.ExternalObservation{ResourceExists: true})
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	annotationsUpdated = annotationsUpdated || recovered.ResourceLateInitialized
 	policyHasLateInit := policySet.HasAny(xpv2.ManagementActionLateInitialize, xpv2.ManagementActionAll)
 	if annotationsUpdated && !policyHasLateInit {
 		if err := e.kube.Update(ctx, mg); err != nil {
@@ -366,11 +400,16 @@ func (e *external) Observe(ctx context.Context, mg xpresource.Managed) (managed.
 		resource.SetUpToDateCondition(mg, plan.UpToDate)
 		e.logger.Debug("Called plan on the resource.", "upToDate", plan.UpToDate)
 
-		return managed.ExternalObservation{
+		o := managed.ExternalObservation{
 			ResourceExists:    true,
 			ResourceUpToDate:  plan.UpToDate,
 			ConnectionDetails: conn,
-		}, nil
+		}
+		if resource.IsPlanOnly(mg) {
+			recordPlan(mg, e.eventRecorder, plannedAction(mg, true, !plan.UpToDate, plan.Replace), cliDriftedFields(e.config, plan.Change))
+			o = planOnlyObservation(mg, o)
+		}
+		return o, nil
 	}
 }
 
@@ -384,6 +423,9 @@ func (e *external) Create(ctx context.Context, mg xpresource.Managed) (managed.E
 		Namespace: mg.GetNamespace(),
 		Name:      mg.GetName(),
 	}
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalCreation{}, errors.New(errPlanOnly)
+	}
 	requeued, err := e.scheduleProvider(name)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrapf(err, "cannot schedule a native provider during create: %s", mg.GetUID())
@@ -393,8 +435,16 @@ func (e *external) Create(ctx context.Context, mg xpresource.Managed) (managed.E
 	}
 	defer e.stopProvider()
 	if e.config.UseAsync {
+		recordAsyncCreate(mg, time.Now())
+		// We deep-copy the managed resource to prevent a data race between
+		// the callback of the async apply and the managed reconciler.
+		mgCopy := mg.DeepCopyObject().(xpresource.Managed)
 		// TODO: check whether we need a requeue or not.
-		return managed.ExternalCreation{}, errors.Wrap(e.workspace.ApplyAsync(e.callback.Create(name, true)), errStartAsyncApply)
+		if err := e.workspace.ApplyAsync(e.asyncCreateCallback(mgCopy, e.callback.Create(name, true))); err != nil {
+			resource.RemoveAsyncOperation(mg)
+			return managed.ExternalCreation{}, errors.Wrap(err, errStartAsyncApply)
+		}
+		return managed.ExternalCreation{}, nil
 	}
 	tr, ok := mg.(resource.Terraformed)
 	if !ok {
@@ -419,11 +469,58 @@ func (e *external) Create(ctx context.Context, mg xpresource.Managed) (managed.E
 	return managed.ExternalCreation{ConnectionDetails: conn}, errors.Wrap(err, "cannot set critical annotations")
 }
 
+// asyncCreateCallback returns a callback for the async apply of the create
+// operation, which persists the outcome of the operation before calling the
+// given callback: the external-name of the external resource recorded in the
+// Terraform state of the workspace, and its Terraform ID and partial state if
+// the apply has failed.
+func (e *external) asyncCreateCallback(mg xpresource.Managed, cb terraform.CallbackFn) terraform.CallbackFn {
+	return func(err error, ctx context.Context) error {
+		var id string
+		var state map[string]any
+		if s, sErr := e.workspace.State(); sErr == nil && s.GetAttributes() != nil {
+			tfstate := map[string]any{}
+			if uErr := json.JSParser.Unmarshal(s.GetAttributes(), &tfstate); uErr == nil {
+				if _, aErr := resource.SetCriticalAnnotations(mg, e.config, tfstate, string(s.GetPrivateRaw())); aErr != nil {
+					e.logger.Debug("Cannot set the critical annotations after the async create", "error", aErr.Error())
+				}
+				if err != nil {
+					id, _ = tfstate["id"].(string)
+					state = tfstate
+				}
+			}
+		}
+		finishAsyncCreate(mg, id, state, sdkSensitiveAttribute(e.config))
+		if pErr := persistAsyncCreate(ctx, e.kube, mg); pErr != nil {
+			e.logger.Info("Async create could not be persisted", "error", pErr.Error())
+		}
+		return cb(err, ctx)
+	}
+}
+
+// endedAsyncCreateStart returns the recorded start time of the async create
+// operation of the managed resource if the last operation of the workspace
+// has ended in this process, or the zero time. The outcome of an async create
+// operation that has ended in this process has been persisted by its callback.
+func (e *external) endedAsyncCreateStart(mg xpresource.Managed) time.Time {
+	if e.operation == nil || !e.operation.IsEnded() {
+		return time.Time{}
+	}
+	op, err := resource.GetAsyncOperation(mg)
+	if err != nil || op == nil {
+		return time.Time{}
+	}
+	return op.StartTime.Time
+}
+
 func (e *external) Update(ctx context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) {
 	name := types.NamespacedName{
 		Namespace: mg.GetNamespace(),
 		Name:      mg.GetName(),
 	}
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalUpdate{}, errors.New(errPlanOnly)
+	}
 	requeued, err := e.scheduleProvider(name)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrapf(err, "cannot schedule a native provider during update: %s", mg.GetUID())
@@ -434,7 +531,7 @@ func (e *external) Update(ctx context.Context, mg xpresource.Managed) (managed.E
 	defer e.stopProvider()
 	if e.config.UseAsync {
 		// TODO: check whether we need a requeue or not.
-		return managed.ExternalUpdate{}, errors.Wrap(e.workspace.ApplyAsync(e.callback.Update(name, true)), errStartAsyncApply)
+		return managed.ExternalUpdate{}, errors.Wrap(e.workspace.ApplyAsync(e.callback.Update(name, true), terraform.WithCancelableApply(mg.GetGeneration())), errStartAsyncApply)
 	}
 	tr, ok := mg.(resource.Terraformed)
 	if !ok {
@@ -461,6 +558,9 @@ func (e *external) Delete(ctx context.Context, mg xpresource.Managed) (managed.E
 		Namespace: mg.GetNamespace(),
 		Name:      mg.GetName(),
 	}
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalDelete{}, errors.New(errPlanOnly)
+	}
 	requeued, err := e.scheduleProvider(name)
 	if err != nil {
 		return managed.ExternalDelete{}, errors.Wrapf(err, "cannot schedule a native provider during delete: %s", mg.GetUID())
@@ -8,6 +8,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
@@ -99,6 +100,14 @@ func WithTerraformPluginSDKAsyncMetricRecorder(r *metrics.MetricRecorder) Terraf
 	}
 }
 
+// WithTerraformPluginSDKAsyncEventRecorder configures an event.Recorder for
+// the TerraformPluginSDKAsyncConnector, which records the drift events.
+func WithTerraformPluginSDKAsyncEventRecorder(r event.Recorder) TerraformPluginSDKAsyncOption {
+	return func(c *TerraformPluginSDKAsyncConnector) {
+		c.eventRecorder = r
+	}
+}
+
 // WithTerraformPluginSDKAsyncManagementPolicies configures whether the client
 // should handle management policies.
 func WithTerraformPluginSDKAsyncManagementPolicies(isManagementPoliciesEnabled bool) TerraformPluginSDKAsyncOption {
@@ -117,15 +126,20 @@ type CallbackFn func(error, context.Context) error
 
 func (n *terraformPluginSDKAsyncExternal) Observe(ctx context.Context, mg xpresource.Managed) (managed.ExternalObservation, error) {
 	if n.opTracker.LastOperation.IsRunning() {
+		supersedeOperation(mg, n.opTracker.LastOperation, n.eventRecorder)
 		n.logger.WithValues("opType", n.opTracker.LastOperation.Type).Debug("ongoing async operation")
 		return managed.ExternalObservation{
 			ResourceExists:   true,
 			ResourceUpToDate: true,
 		}, nil
 	}
+	lastStart := endedOperationStart(n.opTracker.LastOperation)
 	n.opTracker.LastOperation.Clear(true)
 
 	o, err := n.terraformPluginSDKExternal.Observe(ctx, mg)
+	if err == nil {
+		o, err = recoverAsyncCreate(mg, n.eventRecorder, lastStart, o)
+	}
 	// clear any previously reported LastAsyncOperation error condition here,
 	// because there are no pending updates on the existing resource and it's
 	// not scheduled to be deleted.
@@ -138,9 +152,13 @@ func (n *terraformPluginSDKAsyncExternal) Observe(ctx context.Context, mg xpreso
 }
 
 func (n *terraformPluginSDKAsyncExternal) Create(_ context.Context, mg xpresource.Managed) (managed.ExternalCreation, error) { //nolint:contextcheck // we intentionally use a fresh context for the async operation
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalCreation{}, errors.New(errPlanOnly)
+	}
 	if !n.opTracker.LastOperation.MarkStart("create") {
 		return managed.ExternalCreation{}, errors.Errorf("%s operation that started at %s is still running", n.opTracker.LastOperation.Type, n.opTracker.LastOperation.StartTime().String())
 	}
+	recordAsyncCreate(mg, n.opTracker.LastOperation.StartTime())
 
 	ctx, cancel := context.WithDeadline(context.Background(), n.opTracker.LastOperation.StartTime().Add(defaultAsyncTimeout))
 	// We deep-copy the managed resource to prevent a data race between the
@@ -161,6 +179,20 @@ func (n *terraformPluginSDKAsyncExternal) Create(_ context.Context, mg xpresourc
 			n.opTracker.LastOperation.SetError(err)
 			n.opTracker.logger.Debug("Async create ended.", "error", err, "tfID", n.opTracker.GetTfID())
 
+			// we persist the external-name, which may have been recovered
+			// from a partial state, and the partial state itself before
+			// marking the end of the operation, so that they are not lost if
+			// the provider is restarted.
+			var id string
+			var state map[string]any
+			if ph.err != nil {
+				n.recoverExternalName(mgCopy)
+				id, state = n.partialCreateState()
+			}
+			finishAsyncCreate(mgCopy, id, state, sdkSensitiveAttribute(n.config))
+			if pErr := persistAsyncCreate(ctx, n.kube, mgCopy); pErr != nil {
+				n.opTracker.logger.Info("Async create could not be persisted", "error", pErr.Error())
+			}
 			n.opTracker.LastOperation.MarkEnd()
 			name := types.NamespacedName{
 				Namespace: mgCopy.GetNamespace(),
@@ -184,11 +216,18 @@ func (n *terraformPluginSDKAsyncExternal) Create(_ context.Context, mg xpresourc
 }
 
 func (n *terraformPluginSDKAsyncExternal) Update(_ context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) { //nolint:contextcheck // we intentionally use a fresh context for the async operation
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalUpdate{}, errors.New(errPlanOnly)
+	}
 	if !n.opTracker.LastOperation.MarkStart("update") {
 		return managed.ExternalUpdate{}, errors.Errorf("%s operation that started at %s is still running", n.opTracker.LastOperation.Type, n.opTracker.LastOperation.StartTime().String())
 	}
 
 	ctx, cancel := context.WithDeadline(context.Background(), n.opTracker.LastOperation.StartTime().Add(defaultAsyncTimeout))
+	// The update is canceled via a child context if it's superseded, so that
+	// the finishing operations can still use the parent context.
+	opCtx, opCancel := context.WithCancel(ctx)
+	n.opTracker.LastOperation.SetCancel(mg.GetGeneration(), opCancel)
 	// We deep-copy the managed resource to prevent a data race between the
 	// goroutine we are about to start below and the managed reconciler.
 	// Please see: https://github.com/crossplane/upjet/issues/472
@@ -201,9 +240,16 @@ func (n *terraformPluginSDKAsyncExternal) Update(_ context.Context, mg xpresourc
 		// if any.
 		var ph panicHandler
 		defer cancel()
+		defer opCancel()
 		defer func() { // Finishing operations
 			currentErr := n.opTracker.LastOperation.Error()
 			err := tferrors.NewAsyncUpdateFailed(ph.err)
+			// a superseded update is not reported as failed, and an immediate
+			// reconcile is requested to schedule the superseding operation.
+			if n.opTracker.LastOperation.IsSuperseded() {
+				n.opTracker.logger.Debug("Async update superseded.", "error", err)
+				err = nil
+			}
 			n.opTracker.LastOperation.SetError(err)
 			n.opTracker.logger.Debug("Async update ended.", "error", err, "tfID", n.opTracker.GetTfID())
 
@@ -223,7 +269,7 @@ func (n *terraformPluginSDKAsyncExternal) Update(_ context.Context, mg xpresourc
 		defer ph.recoverIfPanic(ctx)
 
 		n.opTracker.logger.Debug("Async update starting...", "tfID", n.opTracker.GetTfID())
-		_, ph.err = n.terraformPluginSDKExternal.Update(ctx, mgCopy)
+		_, ph.err = n.terraformPluginSDKExternal.Update(opCtx, mgCopy)
 	}()
 
 	return managed.ExternalUpdate{}, n.opTracker.LastOperation.Error()
@@ -231,6 +277,8 @@ func (n *terraformPluginSDKAsyncExternal) Update(_ context.Context, mg xpresourc
 
 func (n *terraformPluginSDKAsyncExternal) Delete(_ context.Context, mg xpresource.Managed) (managed.ExternalDelete, error) { //nolint:contextcheck // we intentionally use a fresh context for the async operation
 	switch {
+	case resource.IsPlanOnly(mg):
+		return managed.ExternalDelete{}, errors.New(errPlanOnly)
 	case n.opTracker.LastOperation.Type == "delete":
 		n.opTracker.logger.Debug("The previous delete operation is still ongoing", "tfID", n.opTracker.GetTfID())
 		return managed.ExternalDelete{}, nil
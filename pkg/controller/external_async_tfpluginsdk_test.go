@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
 	"github.com/crossplane/upjet/v2/pkg/resource/fake"
 	"github.com/crossplane/upjet/v2/pkg/terraform"
 )
@@ -80,6 +81,10 @@ func prepareTerraformPluginSDKAsyncExternal(r Resource, cfg *config.Resource, fn
 			rawConfig: rawConfig,
 			logger:    logTest,
 			opTracker: NewAsyncTracker(),
+			kube: &test.MockClient{
+				MockGet:    test.NewMockGetFn(nil),
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
 		},
 		callback: fns,
 	}
@@ -234,6 +239,69 @@ func TestAsyncTerraformPluginSDKCreate(t *testing.T) {
 	}
 }
 
+func TestAsyncTerraformPluginSDKCreatePartialState(t *testing.T) {
+	obj := newObjAsync()
+	r := mockResource{
+		ApplyFn: func(_ context.Context, _ *tf.InstanceState, _ *tf.InstanceDiff, _ interface{}) (*tf.InstanceState, diag.Diagnostics) {
+			return &tf.InstanceState{ID: "example-id", Attributes: map[string]string{"id": "example-id", "name": "example"}}, diag.Errorf("boom")
+		},
+	}
+	done := make(chan struct{})
+	ext := prepareTerraformPluginSDKAsyncExternal(r, cfgAsync, CallbackFns{
+		CreateFn: func(_ types.NamespacedName) terraform.CallbackFn {
+			return func(_ error, _ context.Context) error {
+				close(done)
+				return nil
+			}
+		},
+	})
+	var persisted map[string]string
+	ext.kube = &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.SetAnnotations(nil)
+			return nil
+		},
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			persisted = obj.GetAnnotations()
+			return nil
+		},
+	}
+	if _, err := ext.Create(t.Context(), obj); err != nil {
+		t.Fatalf("terraformPluginSDKAsyncExternal.Create(...): unexpected error: %v", err)
+	}
+	<-done
+
+	got := &fake.Terraformed{}
+	got.SetAnnotations(persisted)
+	op, err := resource.GetAsyncOperation(got)
+	if err != nil {
+		t.Fatalf("GetAsyncOperation(...): unexpected error: %v", err)
+	}
+	if op == nil {
+		t.Fatal("terraformPluginSDKAsyncExternal.Create(...): the record of the failed create has not been persisted")
+	}
+	if diff := cmp.Diff("example-id", op.ID); diff != "" {
+		t.Errorf("terraformPluginSDKAsyncExternal.Create(...): -want recorded ID, +got recorded ID:\n%s", diff)
+	}
+	if diff := cmp.Diff(`{"id":"example-id","name":"example"}`, string(op.State)); diff != "" {
+		t.Errorf("terraformPluginSDKAsyncExternal.Create(...): -want recorded state, +got recorded state:\n%s", diff)
+	}
+
+	// the Terraform state is reconstructed from the record after a restart.
+	restarted := newObjAsync()
+	restarted.SetAnnotations(map[string]string{resource.AnnotationKeyAsyncOperation: persisted[resource.AnnotationKeyAsyncOperation]})
+	store := NewOperationStore(logTest)
+	c := NewTerraformPluginSDKAsyncConnector(nil, store, func(_ context.Context, _ client.Client, _ xpresource.Managed) (terraform.Setup, error) {
+		return terraform.Setup{}, nil
+	}, cfgAsync, WithTerraformPluginSDKAsyncLogger(logTest))
+	if _, err := c.Connect(t.Context(), restarted); err != nil {
+		t.Fatalf("Connect(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("example-id", store.Tracker(restarted).GetTfState().ID); diff != "" {
+		t.Errorf("Connect(...): -want reconstructed ID, +got reconstructed ID:\n%s", diff)
+	}
+}
+
 func TestAsyncTerraformPluginSDKUpdate(t *testing.T) {
 	type args struct {
 		r   Resource
@@ -408,6 +476,54 @@ func TestAsyncTerraformPluginSDKUpdateRace(t *testing.T) {
 	<-mrDone
 }
 
+func TestAsyncTerraformPluginSDKUpdateSuperseded(t *testing.T) {
+	obj := newObjAsync()
+	obj.SetGeneration(1)
+	r := mockResource{
+		ApplyFn: func(ctx context.Context, _ *tf.InstanceState, _ *tf.InstanceDiff, _ interface{}) (*tf.InstanceState, diag.Diagnostics) {
+			<-ctx.Done()
+			return nil, diag.FromErr(ctx.Err())
+		},
+	}
+
+	type result struct {
+		err    error
+		ctxErr error
+	}
+	extDone := make(chan result, 1)
+	ext := prepareTerraformPluginSDKAsyncExternal(r, cfgAsync, CallbackFns{
+		UpdateFn: func(_ types.NamespacedName) terraform.CallbackFn {
+			return func(err error, ctx context.Context) error {
+				extDone <- result{err: err, ctxErr: ctx.Err()}
+				return nil
+			}
+		},
+	})
+	if _, err := ext.Update(t.Context(), obj); err != nil {
+		t.Fatalf("terraformPluginSDKAsyncExternal.Update(...): unexpected error: %v", err)
+	}
+
+	// A new generation of the MR supersedes the running update, which is
+	// reported as still running until it's canceled.
+	newGen := obj.DeepCopyObject().(*fake.Terraformed)
+	newGen.SetGeneration(2)
+	obs, err := ext.Observe(t.Context(), newGen)
+	if err != nil {
+		t.Fatalf("terraformPluginSDKAsyncExternal.Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, obs); diff != "" {
+		t.Errorf("terraformPluginSDKAsyncExternal.Observe(...): -want, +got:\n%s", diff)
+	}
+	// The superseded update is not reported as failed, and the callback's
+	// context is not canceled.
+	if diff := cmp.Diff(result{}, <-extDone, test.EquateErrors(), cmp.AllowUnexported(result{})); diff != "" {
+		t.Errorf("terraformPluginSDKAsyncExternal.Update(...): superseded update callback: -want, +got:\n%s", diff)
+	}
+	if !ext.opTracker.LastOperation.IsSuperseded() {
+		t.Errorf("terraformPluginSDKAsyncExternal.Update(...): the update was not superseded")
+	}
+}
+
 // TestAsyncTerraformPluginSDKDeleteRace is a guard test asserting that upjet's
 // async Delete operation does not concurrently access a managed resource's
 // status while the managed reconciler does. Current async client Delete
@@ -0,0 +1,227 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/metrics"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+	tferrors "github.com/crossplane/upjet/v2/pkg/terraform/errors"
+)
+
+// TerraformPluginFrameworkDataSourceConnector is an external client, with
+// credentials and other configuration parameters, for Terraform Plugin
+// Framework data sources. You can use
+// NewTerraformPluginFrameworkDataSourceConnector to construct.
+type TerraformPluginFrameworkDataSourceConnector struct {
+	getTerraformSetup terraform.SetupFn
+	kube              client.Client
+	config            *config.DataSource
+	logger            logging.Logger
+	metricRecorder    *metrics.MetricRecorder
+}
+
+// TerraformPluginFrameworkDataSourceOption allows you to configure
+// TerraformPluginFrameworkDataSourceConnector.
+type TerraformPluginFrameworkDataSourceOption func(connector *TerraformPluginFrameworkDataSourceConnector)
+
+// WithTerraformPluginFrameworkDataSourceLogger configures a logger for the
+// TerraformPluginFrameworkDataSourceConnector.
+func WithTerraformPluginFrameworkDataSourceLogger(l logging.Logger) TerraformPluginFrameworkDataSourceOption {
+	return func(c *TerraformPluginFrameworkDataSourceConnector) {
+		c.logger = l
+	}
+}
+
+// WithTerraformPluginFrameworkDataSourceMetricRecorder configures a
+// metrics.MetricRecorder for the TerraformPluginFrameworkDataSourceConnector.
+func WithTerraformPluginFrameworkDataSourceMetricRecorder(r *metrics.MetricRecorder) TerraformPluginFrameworkDataSourceOption {
+	return func(c *TerraformPluginFrameworkDataSourceConnector) {
+		c.metricRecorder = r
+	}
+}
+
+// NewTerraformPluginFrameworkDataSourceConnector initializes a new
+// TerraformPluginFrameworkDataSourceConnector.
+func NewTerraformPluginFrameworkDataSourceConnector(kube client.Client, sf terraform.SetupFn, cfg *config.DataSource, opts ...TerraformPluginFrameworkDataSourceOption) *TerraformPluginFrameworkDataSourceConnector {
+	c := &TerraformPluginFrameworkDataSourceConnector{
+		kube:              kube,
+		getTerraformSetup: sf,
+		config:            cfg,
+		logger:            logging.NewNopLogger(),
+	}
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+// Connect makes sure the underlying client is ready to issue requests to the
+// provider API.
+func (c *TerraformPluginFrameworkDataSourceConnector) Connect(ctx context.Context, mg xpresource.Managed) (managed.ExternalClient, error) {
+	c.metricRecorder.ObserveReconcileDelay(mg.GetObjectKind().GroupVersionKind(), metrics.NameForManaged(mg))
+	logger := c.logger.WithValues("uid", mg.GetUID(), "name", mg.GetName(), "namespace", mg.GetNamespace(), "gvk", mg.GetObjectKind().GroupVersionKind().String())
+	logger.Debug("Connecting to the service provider")
+	start := time.Now()
+	ts, err := c.getTerraformSetup(ctx, c.kube, mg)
+	metrics.ExternalAPITime.WithLabelValues("connect").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTerraformSetup)
+	}
+
+	tr, ok := mg.(resource.Terraformed)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	dataSourceSchema, err := c.getDataSourceSchema(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve data source schema")
+	}
+	params, err := getExtendedParameters(ctx, tr, meta.GetExternalName(tr), c.config.Resource, ts, false, c.kube)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the extended parameters for data source %q", client.ObjectKeyFromObject(mg))
+	}
+	// the ID of a data source is computed by the provider.
+	delete(params, "id")
+
+	tfType := dataSourceSchema.Type().TerraformType(ctx)
+	configValue, err := getDataSourceConfigTerraformValue(ctx, tfType, params, dataSourceSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get data source config TF value")
+	}
+	configDynamicValue, err := tfprotov6.NewDynamicValue(tfType, configValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot construct dynamic value for data source config")
+	}
+
+	server, err := configureFrameworkProvider(ctx, ts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not configure provider server")
+	}
+
+	return &terraformPluginFrameworkDataSourceExternal{
+		config:         c.config,
+		logger:         logger,
+		metricRecorder: c.metricRecorder,
+		server:         server,
+		tfType:         tfType,
+		configValue:    &configDynamicValue,
+	}, nil
+}
+
+// getDataSourceSchema returns the Terraform Plugin Framework-style data
+// source schema for the configured framework data source on the connector.
+func (c *TerraformPluginFrameworkDataSourceConnector) getDataSourceSchema(ctx context.Context) (dsschema.Schema, error) {
+	schemaResp := &fwdatasource.SchemaResponse{}
+	c.config.TerraformPluginFrameworkDataSource.Schema(ctx, fwdatasource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return dsschema.Schema{}, tferrors.FrameworkDiagnosticsError("could not retrieve data source schema", schemaResp.Diagnostics)
+	}
+	return schemaResp.Schema, nil
+}
+
+// getDataSourceConfigTerraformValue constructs the data source configuration
+// from the given parameters. Computed-only (read-only) attributes are
+// nullified as they cannot be configured.
+func getDataSourceConfigTerraformValue(ctx context.Context, tfType tftypes.Type, params map[string]any, sch dsschema.Schema) (tftypes.Value, error) {
+	tfConfigValue, err := tfValueFromMap(params, tfType)
+	if err != nil {
+		return tftypes.Value{}, errors.Wrap(err, "cannot construct TF value for data source config")
+	}
+	tfConfigValueClean, err := tftypes.Transform(tfConfigValue, func(path *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() || v.IsNull() {
+			return v, nil
+		}
+		attr, err := sch.AttributeAtTerraformPath(ctx, path)
+		if err != nil || attr == nil {
+			// not an attribute, could be an element or attribute of
+			// a complex type
+			return v, nil //nolint:nilerr // intentional per above explanation
+		}
+		if attr.IsComputed() && !attr.IsOptional() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	return tfConfigValueClean, errors.Wrap(err, "cannot remove read-only attributes from data source config")
+}
+
+type terraformPluginFrameworkDataSourceExternal struct {
+	config         *config.DataSource
+	logger         logging.Logger
+	metricRecorder *metrics.MetricRecorder
+	server         tfprotov6.ProviderServer
+	// the terraform value type associated with the data source schema
+	tfType tftypes.Type
+	// configured value for the data source in terraform type system
+	configValue *tfprotov6.DynamicValue
+}
+
+func (n *terraformPluginFrameworkDataSourceExternal) Observe(ctx context.Context, mg xpresource.Managed) (managed.ExternalObservation, error) {
+	n.logger.Debug("Reading the data source")
+	// there is no external resource to delete for a data source.
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	start := time.Now()
+	readResponse, err := n.server.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: n.config.Name,
+		Config:   n.configValue,
+	})
+	metrics.ExternalAPITime.WithLabelValues("read").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot read data source")
+	}
+	if fatalDiags := getFatalDiagnostics(readResponse.Diagnostics); fatalDiags != nil {
+		return managed.ExternalObservation{}, errors.Wrap(fatalDiags, "read data source request failed")
+	}
+	if readResponse.State == nil {
+		return managed.ExternalObservation{}, errors.New("data source read returned an empty state")
+	}
+	stateValue, err := readResponse.State.Unmarshal(n.tfType)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot unmarshal state value")
+	}
+	if stateValue.IsNull() {
+		return managed.ExternalObservation{}, errors.New("data source read returned an empty state")
+	}
+	conv, err := tfValueToGoValue(stateValue)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot convert data source state to JSON map")
+	}
+	return observeDataSource(mg, n.config, conv.(map[string]any), n.metricRecorder)
+}
+
+func (n *terraformPluginFrameworkDataSourceExternal) Create(_ context.Context, _ xpresource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errDataSourceReadOnly)
+}
+
+func (n *terraformPluginFrameworkDataSourceExternal) Update(_ context.Context, _ xpresource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, errors.New(errDataSourceReadOnly)
+}
+
+func (n *terraformPluginFrameworkDataSourceExternal) Delete(_ context.Context, _ xpresource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
+
+func (n *terraformPluginFrameworkDataSourceExternal) Disconnect(_ context.Context) error {
+	return nil
+}
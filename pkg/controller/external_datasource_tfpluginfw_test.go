@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+)
+
+var testFrameworkDataSourceType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"id":           tftypes.String,
+		"name":         tftypes.String,
+		"architecture": tftypes.String,
+	},
+}
+
+var _ fwdatasource.DataSource = &mockTPFDataSource{}
+
+type mockTPFDataSource struct{}
+
+func (d *mockTPFDataSource) Metadata(_ context.Context, _ fwdatasource.MetadataRequest, resp *fwdatasource.MetadataResponse) {
+	resp.TypeName = "upjet_image"
+}
+
+func (d *mockTPFDataSource) Schema(_ context.Context, _ fwdatasource.SchemaRequest, resp *fwdatasource.SchemaResponse) {
+	resp.Schema = dsschema.Schema{
+		Attributes: map[string]dsschema.Attribute{
+			// an optional ID is not removed by the read-only
+			// attribute filter but must not be configured.
+			"id": dsschema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"name": dsschema.StringAttribute{
+				Required: true,
+			},
+			"architecture": dsschema.StringAttribute{
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *mockTPFDataSource) Read(_ context.Context, _ fwdatasource.ReadRequest, _ *fwdatasource.ReadResponse) {
+}
+
+func newFrameworkDataSourceConfig() *config.DataSource {
+	return config.DefaultDataSource("upjet_image", nil, &mockTPFDataSource{})
+}
+
+func newFrameworkDataSourceState(id string) *tfprotov6.DynamicValue {
+	v, err := tfprotov6.NewDynamicValue(testFrameworkDataSourceType, tftypes.NewValue(testFrameworkDataSourceType, map[string]tftypes.Value{
+		"id":           tftypes.NewValue(tftypes.String, id),
+		"name":         tftypes.NewValue(tftypes.String, "example"),
+		"architecture": tftypes.NewValue(tftypes.String, "arm64"),
+	}))
+	if err != nil {
+		panic(err)
+	}
+	return &v
+}
+
+func TestTerraformPluginFrameworkDataSourceConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+	type args struct {
+		setupFn terraform.SetupFn
+		obj     *fake.Terraformed
+	}
+	type want struct {
+		config map[string]tftypes.Value
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Successful": {
+			reason: "The data source config should be built from the parameters without the ID and the computed attributes.",
+			args: args{
+				setupFn: func(_ context.Context, _ client.Client, _ xpresource.Managed) (terraform.Setup, error) {
+					return terraform.Setup{FrameworkProvider: &mockTPFProvider{}}, nil
+				},
+				obj: &fake.Terraformed{
+					Parameterizable: fake.Parameterizable{
+						Parameters: map[string]any{
+							"id":           "ami-stale",
+							"name":         "example",
+							"architecture": "x86_64",
+						},
+					},
+				},
+			},
+			want: want{
+				config: map[string]tftypes.Value{
+					"id":           tftypes.NewValue(tftypes.String, nil),
+					"name":         tftypes.NewValue(tftypes.String, "example"),
+					"architecture": tftypes.NewValue(tftypes.String, nil),
+				},
+			},
+		},
+		"SetupFailed": {
+			reason: "Errors getting the Terraform setup should be reported.",
+			args: args{
+				setupFn: func(_ context.Context, _ client.Client, _ xpresource.Managed) (terraform.Setup, error) {
+					return terraform.Setup{}, errBoom
+				},
+				obj: &fake.Terraformed{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetTerraformSetup),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewTerraformPluginFrameworkDataSourceConnector(nil, tc.args.setupFn, newFrameworkDataSourceConfig(), WithTerraformPluginFrameworkDataSourceLogger(logTest))
+			ec, err := c.Connect(context.TODO(), tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nConnect(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.config == nil {
+				return
+			}
+			v, err := ec.(*terraformPluginFrameworkDataSourceExternal).configValue.Unmarshal(testFrameworkDataSourceType)
+			if err != nil {
+				t.Fatalf("\n%s\nConnect(...): cannot unmarshal the data source config: %v", tc.reason, err)
+			}
+			got := map[string]tftypes.Value{}
+			if err := v.As(&got); err != nil {
+				t.Fatalf("\n%s\nConnect(...): cannot convert the data source config: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.config, got); diff != "" {
+				t.Errorf("\n%s\nConnect(...): -want config, +got config:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginFrameworkDataSourceObserve(t *testing.T) {
+	errorDiags := []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "boom",
+			Detail:   "data source cannot be read",
+		},
+	}
+	type args struct {
+		read func(context.Context, *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error)
+		obj  *fake.Terraformed
+	}
+	type want struct {
+		obs          managed.ExternalObservation
+		externalName string
+		atProvider   map[string]any
+		err          error
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Read": {
+			reason: "The state read from the data source should be reported in the observation.",
+			args: args{
+				read: func(_ context.Context, _ *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+					return &tfprotov6.ReadDataSourceResponse{State: newFrameworkDataSourceState("ami-example")}, nil
+				},
+				obj: &fake.Terraformed{},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "ami-example",
+				atProvider: map[string]any{
+					"id":           "ami-example",
+					"name":         "example",
+					"architecture": "arm64",
+				},
+			},
+		},
+		"ErrorDiagnostics": {
+			reason: "The error diagnostics returned from the read call should be reported.",
+			args: args{
+				read: func(_ context.Context, _ *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+					return &tfprotov6.ReadDataSourceResponse{Diagnostics: errorDiags}, nil
+				},
+				obj: &fake.Terraformed{},
+			},
+			want: want{
+				err: errors.Wrap(getFatalDiagnostics(errorDiags), "read data source request failed"),
+			},
+		},
+		"Deleted": {
+			reason: "A deleted data source should not be read.",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &terraformPluginFrameworkDataSourceExternal{
+				config:      newFrameworkDataSourceConfig(),
+				logger:      logTest,
+				server:      &mockTPFProviderServer{ReadDataSourceFn: tc.args.read},
+				tfType:      testFrameworkDataSourceType,
+				configValue: newFrameworkDataSourceState(""),
+			}
+			obs, err := e.Observe(context.TODO(), tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want observation, +got observation:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.obj)); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want external-name, +got external-name:\n%s", tc.reason, diff)
+			}
+			if tc.want.atProvider == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.atProvider, tc.args.obj.Observation); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want atProvider, +got atProvider:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tf "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/metrics"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+)
+
+const (
+	errDataSourceReadOnly = "data sources are read-only and cannot be created or updated"
+)
+
+// TerraformPluginSDKDataSourceConnector is an external client, with
+// credentials and other configuration parameters, for Terraform Plugin SDKv2
+// data sources. You can use NewTerraformPluginSDKDataSourceConnector to
+// construct.
+type TerraformPluginSDKDataSourceConnector struct {
+	getTerraformSetup terraform.SetupFn
+	kube              client.Client
+	config            *config.DataSource
+	logger            logging.Logger
+	metricRecorder    *metrics.MetricRecorder
+}
+
+// TerraformPluginSDKDataSourceOption allows you to configure
+// TerraformPluginSDKDataSourceConnector.
+type TerraformPluginSDKDataSourceOption func(connector *TerraformPluginSDKDataSourceConnector)
+
+// WithTerraformPluginSDKDataSourceLogger configures a logger for the
+// TerraformPluginSDKDataSourceConnector.
+func WithTerraformPluginSDKDataSourceLogger(l logging.Logger) TerraformPluginSDKDataSourceOption {
+	return func(c *TerraformPluginSDKDataSourceConnector) {
+		c.logger = l
+	}
+}
+
+// WithTerraformPluginSDKDataSourceMetricRecorder configures a
+// metrics.MetricRecorder for the TerraformPluginSDKDataSourceConnector.
+func WithTerraformPluginSDKDataSourceMetricRecorder(r *metrics.MetricRecorder) TerraformPluginSDKDataSourceOption {
+	return func(c *TerraformPluginSDKDataSourceConnector) {
+		c.metricRecorder = r
+	}
+}
+
+// NewTerraformPluginSDKDataSourceConnector initializes a new
+// TerraformPluginSDKDataSourceConnector.
+func NewTerraformPluginSDKDataSourceConnector(kube client.Client, sf terraform.SetupFn, cfg *config.DataSource, opts ...TerraformPluginSDKDataSourceOption) *TerraformPluginSDKDataSourceConnector {
+	c := &TerraformPluginSDKDataSourceConnector{
+		kube:              kube,
+		getTerraformSetup: sf,
+		config:            cfg,
+		logger:            logging.NewNopLogger(),
+	}
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+// Connect makes sure the underlying client is ready to issue requests to the
+// provider API.
+func (c *TerraformPluginSDKDataSourceConnector) Connect(ctx context.Context, mg xpresource.Managed) (managed.ExternalClient, error) {
+	c.metricRecorder.ObserveReconcileDelay(mg.GetObjectKind().GroupVersionKind(), metrics.NameForManaged(mg))
+	logger := c.logger.WithValues("uid", mg.GetUID(), "name", mg.GetName(), "namespace", mg.GetNamespace(), "gvk", mg.GetObjectKind().GroupVersionKind().String())
+	logger.Debug("Connecting to the service provider")
+	start := time.Now()
+	ts, err := c.getTerraformSetup(ctx, c.kube, mg)
+	metrics.ExternalAPITime.WithLabelValues("connect").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTerraformSetup)
+	}
+
+	tr, ok := mg.(resource.Terraformed)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	params, err := getExtendedParameters(ctx, tr, meta.GetExternalName(tr), c.config.Resource, ts, false, c.kube)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the extended parameters for data source %q", client.ObjectKeyFromObject(mg))
+	}
+	// the ID of a data source is computed by the provider.
+	delete(params, "id")
+	rawConfig, err := schema.JSONMapToStateValue(params, c.config.TerraformResource.CoreConfigSchema())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert params JSON map to cty.Value")
+	}
+
+	return &terraformPluginSDKDataSourceExternal{
+		ts:             ts,
+		config:         c.config,
+		rawConfig:      rawConfig,
+		logger:         logger,
+		metricRecorder: c.metricRecorder,
+	}, nil
+}
+
+type terraformPluginSDKDataSourceExternal struct {
+	ts             terraform.Setup
+	config         *config.DataSource
+	rawConfig      cty.Value
+	logger         logging.Logger
+	metricRecorder *metrics.MetricRecorder
+}
+
+// readDataSource reads the data source with the configured arguments in the
+// same way the Terraform Plugin SDKv2 gRPC server does: a diff is computed
+// against an empty state and then applied with the data source's read
+// function.
+func (n *terraformPluginSDKDataSourceExternal) readDataSource(ctx context.Context) (*tf.InstanceState, error) {
+	res := n.config.TerraformResource
+	rc := tf.NewResourceConfigShimmed(n.rawConfig, res.CoreConfigSchema())
+	diff, err := res.Diff(ctx, nil, rc, n.ts.Meta)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot compute the data source diff")
+	}
+	// as done by the Terraform plugin SDK's gRPC server, a data source
+	// without any planned changes is read with an empty diff so that its
+	// raw configuration is still available.
+	if diff == nil {
+		diff = &tf.InstanceDiff{}
+	}
+	diff.RawConfig = n.rawConfig
+	start := time.Now()
+	newState, diag := res.ReadDataApply(ctx, diff, n.ts.Meta)
+	metrics.ExternalAPITime.WithLabelValues("read").Observe(time.Since(start).Seconds())
+	if diag != nil && diag.HasError() {
+		return nil, errors.Errorf("failed to read the data source: %v", diag)
+	}
+	if newState == nil {
+		return nil, errors.New("data source read returned an empty state")
+	}
+	return newState, nil
+}
+
+func (n *terraformPluginSDKDataSourceExternal) Observe(ctx context.Context, mg xpresource.Managed) (managed.ExternalObservation, error) {
+	n.logger.Debug("Reading the data source")
+	// there is no external resource to delete for a data source.
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	newState, err := n.readDataSource(ctx)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	impliedType := n.config.TerraformResource.CoreConfigSchema().ImpliedType()
+	stateValue, err := newState.AttrsAsObjectValue(impliedType)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "could not convert attrs to cty value")
+	}
+	stateValueMap, err := schema.StateValueToJSONMap(stateValue, impliedType)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "could not convert instance state value to JSON")
+	}
+	return observeDataSource(mg, n.config, stateValueMap, n.metricRecorder)
+}
+
+func (n *terraformPluginSDKDataSourceExternal) Create(_ context.Context, _ xpresource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errDataSourceReadOnly)
+}
+
+func (n *terraformPluginSDKDataSourceExternal) Update(_ context.Context, _ xpresource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, errors.New(errDataSourceReadOnly)
+}
+
+func (n *terraformPluginSDKDataSourceExternal) Delete(_ context.Context, _ xpresource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
+
+func (n *terraformPluginSDKDataSourceExternal) Disconnect(_ context.Context) error {
+	return nil
+}
+
+// observeDataSource publishes the attributes read from a data source, given
+// in the Terraform state format, as the observation of the specified managed
+// resource and sets its external-name. A successfully read data source is
+// always reported as an existing, up-to-date external resource.
+func observeDataSource(mg xpresource.Managed, cfg *config.DataSource, stateValueMap map[string]any, metricRecorder *metrics.MetricRecorder) (managed.ExternalObservation, error) {
+	tr := mg.(resource.Terraformed)
+	if mg.GetCondition(xpv2.TypeReady).Status == corev1.ConditionUnknown ||
+		mg.GetCondition(xpv2.TypeReady).Status == corev1.ConditionFalse {
+		addTTR(mg)
+	}
+	mg.SetConditions(xpv2.Available())
+
+	// we get the connection details from the observed state before
+	// the conversion because the sensitive paths assume the native Terraform
+	// schema.
+	connDetails, err := resource.GetConnectionDetails(stateValueMap, tr, cfg.Resource)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot get connection details")
+	}
+	stateValueMap, err = cfg.ApplyTFConversions(stateValueMap, config.FromTerraform)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, "cannot convert the singleton lists in the observed state value map into embedded objects")
+	}
+	if err := tr.SetObservation(stateValueMap); err != nil {
+		return managed.ExternalObservation{}, errors.Errorf("could not set observation: %v", err)
+	}
+	metricRecorder.SetReconcileTime(metrics.NameForManaged(mg))
+	resource.SetUpToDateCondition(mg, true)
+
+	nameChanged := false
+	if id, ok := stateValueMap["id"].(string); ok && id != "" {
+		newName, err := cfg.ExternalName.GetExternalNameFn(stateValueMap)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrapf(err, "failed to compute the external-name from the state map of the data source with the ID %s", id)
+		}
+		nameChanged = meta.GetExternalName(mg) != newName
+		meta.SetExternalName(mg, newName)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ConnectionDetails:       connDetails,
+		ResourceLateInitialized: nameChanged,
+	}, nil
+}
@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+func newDataSourceConfig(readFn schema.ReadContextFunc) *config.DataSource {
+	return config.DefaultDataSource("upjet_image", &schema.Resource{
+		ReadContext: readFn,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"architecture": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}, nil)
+}
+
+// newRawConfigDataSourceConfig returns the configuration of a data source
+// without any changes planned for an empty configuration, which reads its
+// raw configuration.
+func newRawConfigDataSourceConfig() *config.DataSource {
+	return config.DefaultDataSource("upjet_zone", &schema.Resource{
+		ReadContext: func(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+			name := "default"
+			if v := d.GetRawConfig().GetAttr("name"); !v.IsNull() {
+				name = v.AsString()
+			}
+			d.SetId("zone-" + name)
+			return nil
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}, nil)
+}
+
+func prepareTerraformPluginSDKDataSourceExternal(cfg *config.DataSource, params map[string]any) *terraformPluginSDKDataSourceExternal {
+	rawConfig, err := schema.JSONMapToStateValue(params, cfg.TerraformResource.CoreConfigSchema())
+	if err != nil {
+		panic(err)
+	}
+	return &terraformPluginSDKDataSourceExternal{
+		config:    cfg,
+		rawConfig: rawConfig,
+		logger:    logTest,
+	}
+}
+
+func TestTerraformPluginSDKDataSourceObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	readFn := func(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+		d.SetId("ami-" + d.Get("name").(string))
+		if err := d.Set("architecture", "arm64"); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+	type args struct {
+		cfg    *config.DataSource
+		params map[string]any
+		obj    *fake.Terraformed
+	}
+	type want struct {
+		obs          managed.ExternalObservation
+		externalName string
+		atProvider   map[string]any
+		err          error
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Read": {
+			args: args{
+				cfg:    newDataSourceConfig(readFn),
+				params: map[string]any{"name": "example"},
+				obj:    &fake.Terraformed{},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "ami-example",
+				atProvider: map[string]any{
+					"id":           "ami-example",
+					"name":         "example",
+					"architecture": "arm64",
+				},
+			},
+		},
+		"ReadRawConfigWithoutChanges": {
+			args: args{
+				cfg:    newRawConfigDataSourceConfig(),
+				params: map[string]any{},
+				obj:    &fake.Terraformed{},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+				externalName: "zone-default",
+				atProvider: map[string]any{
+					"id":   "zone-default",
+					"name": nil,
+				},
+			},
+		},
+		"ReadFailed": {
+			args: args{
+				cfg: newDataSourceConfig(func(_ context.Context, _ *schema.ResourceData, _ any) diag.Diagnostics {
+					return diag.FromErr(errBoom)
+				}),
+				params: map[string]any{"name": "example"},
+				obj:    &fake.Terraformed{},
+			},
+			want: want{
+				err: errors.Errorf("failed to read the data source: %v", diag.FromErr(errBoom)),
+			},
+		},
+		"Deleted": {
+			args: args{
+				cfg: newDataSourceConfig(readFn),
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							DeletionTimestamp: &metav1.Time{Time: time.Now()},
+						},
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := prepareTerraformPluginSDKDataSourceExternal(tc.args.cfg, tc.args.params)
+			obs, err := e.Observe(context.TODO(), tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nObserve(...): -want error, +got error:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want observation, +got observation:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.obj)); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want external-name, +got external-name:\n%s", name, diff)
+			}
+			if tc.want.atProvider == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.atProvider, tc.args.obj.Observation); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want atProvider, +got atProvider:\n%s", name, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginSDKDataSourceCreate(t *testing.T) {
+	e := prepareTerraformPluginSDKDataSourceExternal(newDataSourceConfig(nil), map[string]any{"name": "example"})
+	_, err := e.Create(context.TODO(), &fake.Terraformed{})
+	if diff := cmp.Diff(errors.New(errDataSourceReadOnly), err, test.EquateErrors()); diff != "" {
+		t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+	}
+}
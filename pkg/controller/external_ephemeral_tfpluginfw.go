@@ -0,0 +1,448 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	fwephemeral "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/metrics"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+	tferrors "github.com/crossplane/upjet/v2/pkg/terraform/errors"
+)
+
+const (
+	// defaultEphemeralResourceRenewBefore is the default duration before the
+	// renewal deadline of an ephemeral resource at which it's renewed.
+	defaultEphemeralResourceRenewBefore = time.Minute
+
+	// minEphemeralResourceRenewalRequeue is the minimum delay with which an
+	// ephemeral resource is requeued for its renewal, so that an ephemeral
+	// resource with a lifetime shorter than the renewal period is not
+	// renewed continuously.
+	minEphemeralResourceRenewalRequeue = 10 * time.Second
+
+	// prefixEphemeralResourceResult is the prefix of the connection detail
+	// keys holding the ephemeral resource result attributes. It's the same
+	// prefix used for the sensitive Terraform attributes.
+	prefixEphemeralResourceResult = "attribute."
+)
+
+// ephemeralResourceInstance is an opened instance of an ephemeral resource.
+type ephemeralResourceInstance struct {
+	// private is the provider-private data of the opened instance, which is
+	// needed to renew or close it.
+	private []byte
+	// renewAt is the time at which the instance must be renewed. A zero
+	// value means the instance does not need to be renewed.
+	renewAt time.Time
+	// configHash is the hash of the configuration the instance was opened
+	// with.
+	configHash string
+	// connectionDetails is the result of the opened instance as
+	// connection details.
+	connectionDetails managed.ConnectionDetails
+}
+
+// ephemeralResourceStore keeps the opened ephemeral resource instances in
+// memory, keyed by the UIDs of their managed resources. The provider-private
+// data of the instances are not persisted, so after a restart the ephemeral
+// resources are reopened.
+type ephemeralResourceStore struct {
+	mu        sync.Mutex
+	instances map[types.UID]*ephemeralResourceInstance
+}
+
+func (s *ephemeralResourceStore) get(uid types.UID) *ephemeralResourceInstance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.instances[uid]
+}
+
+func (s *ephemeralResourceStore) set(uid types.UID, i *ephemeralResourceInstance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances[uid] = i
+}
+
+func (s *ephemeralResourceStore) remove(uid types.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.instances, uid)
+}
+
+// TerraformPluginFrameworkEphemeralResourceConnector is an external client,
+// with credentials and other configuration parameters, for Terraform Plugin
+// Framework ephemeral resources. You can use
+// NewTerraformPluginFrameworkEphemeralResourceConnector to construct.
+type TerraformPluginFrameworkEphemeralResourceConnector struct {
+	getTerraformSetup terraform.SetupFn
+	kube              client.Client
+	config            *config.EphemeralResource
+	logger            logging.Logger
+	metricRecorder    *metrics.MetricRecorder
+	renewBefore       time.Duration
+	store             *ephemeralResourceStore
+}
+
+// TerraformPluginFrameworkEphemeralResourceOption allows you to configure
+// TerraformPluginFrameworkEphemeralResourceConnector.
+type TerraformPluginFrameworkEphemeralResourceOption func(connector *TerraformPluginFrameworkEphemeralResourceConnector)
+
+// WithTerraformPluginFrameworkEphemeralResourceLogger configures a logger for
+// the TerraformPluginFrameworkEphemeralResourceConnector.
+func WithTerraformPluginFrameworkEphemeralResourceLogger(l logging.Logger) TerraformPluginFrameworkEphemeralResourceOption {
+	return func(c *TerraformPluginFrameworkEphemeralResourceConnector) {
+		c.logger = l
+	}
+}
+
+// WithTerraformPluginFrameworkEphemeralResourceMetricRecorder configures a
+// metrics.MetricRecorder for the
+// TerraformPluginFrameworkEphemeralResourceConnector.
+func WithTerraformPluginFrameworkEphemeralResourceMetricRecorder(r *metrics.MetricRecorder) TerraformPluginFrameworkEphemeralResourceOption {
+	return func(c *TerraformPluginFrameworkEphemeralResourceConnector) {
+		c.metricRecorder = r
+	}
+}
+
+// WithTerraformPluginFrameworkEphemeralResourceRenewBefore configures how long
+// before its renewal deadline an ephemeral resource is renewed. The managed
+// reconciler should be configured with the PollIntervalHook of the connector
+// so that the ephemeral resource is observed in this period.
+func WithTerraformPluginFrameworkEphemeralResourceRenewBefore(d time.Duration) TerraformPluginFrameworkEphemeralResourceOption {
+	return func(c *TerraformPluginFrameworkEphemeralResourceConnector) {
+		c.renewBefore = d
+	}
+}
+
+// NewTerraformPluginFrameworkEphemeralResourceConnector initializes a new
+// TerraformPluginFrameworkEphemeralResourceConnector.
+func NewTerraformPluginFrameworkEphemeralResourceConnector(kube client.Client, sf terraform.SetupFn, cfg *config.EphemeralResource, opts ...TerraformPluginFrameworkEphemeralResourceOption) *TerraformPluginFrameworkEphemeralResourceConnector {
+	c := &TerraformPluginFrameworkEphemeralResourceConnector{
+		kube:              kube,
+		getTerraformSetup: sf,
+		config:            cfg,
+		logger:            logging.NewNopLogger(),
+		renewBefore:       defaultEphemeralResourceRenewBefore,
+		store: &ephemeralResourceStore{
+			instances: make(map[types.UID]*ephemeralResourceInstance),
+		},
+	}
+	for _, f := range opts {
+		f(c)
+	}
+	return c
+}
+
+// PollIntervalHook returns a managed.PollIntervalHook that requeues an
+// opened ephemeral resource at the time it's due to be renewed if it's
+// earlier than the next poll. The given jitter, if any, is applied to the
+// poll interval as done by managed.WithPollJitterHook.
+func (c *TerraformPluginFrameworkEphemeralResourceConnector) PollIntervalHook(jitter time.Duration) managed.PollIntervalHook {
+	return func(mg xpresource.Managed, pollInterval time.Duration) time.Duration {
+		if jitter != 0 {
+			pollInterval += time.Duration((rand.Float64() - 0.5) * 2 * float64(jitter)) //nolint:gosec // No need for secure randomness.
+		}
+		i := c.store.get(mg.GetUID())
+		if i == nil || i.renewAt.IsZero() {
+			return pollInterval
+		}
+		return min(pollInterval, max(time.Until(i.renewAt.Add(-c.renewBefore)), minEphemeralResourceRenewalRequeue))
+	}
+}
+
+// Connect makes sure the underlying client is ready to issue requests to the
+// provider API.
+func (c *TerraformPluginFrameworkEphemeralResourceConnector) Connect(ctx context.Context, mg xpresource.Managed) (managed.ExternalClient, error) {
+	c.metricRecorder.ObserveReconcileDelay(mg.GetObjectKind().GroupVersionKind(), metrics.NameForManaged(mg))
+	logger := c.logger.WithValues("uid", mg.GetUID(), "name", mg.GetName(), "namespace", mg.GetNamespace(), "gvk", mg.GetObjectKind().GroupVersionKind().String())
+	logger.Debug("Connecting to the service provider")
+	start := time.Now()
+	ts, err := c.getTerraformSetup(ctx, c.kube, mg)
+	metrics.ExternalAPITime.WithLabelValues("connect").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTerraformSetup)
+	}
+
+	tr, ok := mg.(resource.Terraformed)
+	if !ok {
+		return nil, errors.New(errUnexpectedObject)
+	}
+	schemaResp := &fwephemeral.SchemaResponse{}
+	c.config.TerraformPluginFrameworkEphemeralResource.Schema(ctx, fwephemeral.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, tferrors.FrameworkDiagnosticsError("could not retrieve ephemeral resource schema", schemaResp.Diagnostics)
+	}
+	params, err := getExtendedParameters(ctx, tr, meta.GetExternalName(tr), c.config.Resource, ts, false, c.kube)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the extended parameters for ephemeral resource %q", client.ObjectKeyFromObject(mg))
+	}
+	// ephemeral resources do not have an ID.
+	delete(params, "id")
+	configHash, err := hashEphemeralResourceConfig(params)
+	if err != nil {
+		return nil, err
+	}
+
+	tfType := schemaResp.Schema.Type().TerraformType(ctx)
+	configValue, err := protov6DynamicValueFromMap(params, tfType)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot construct dynamic value for ephemeral resource config")
+	}
+
+	server, err := configureFrameworkProvider(ctx, ts)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not configure provider server")
+	}
+
+	return &terraformPluginFrameworkEphemeralResourceExternal{
+		config:         c.config,
+		logger:         logger,
+		metricRecorder: c.metricRecorder,
+		renewBefore:    c.renewBefore,
+		store:          c.store,
+		server:         server,
+		tfType:         tfType,
+		configValue:    configValue,
+		configHash:     configHash,
+	}, nil
+}
+
+// hashEphemeralResourceConfig returns a hash of the given ephemeral resource
+// configuration, which is used to detect configuration changes requiring the
+// ephemeral resource to be reopened.
+func hashEphemeralResourceConfig(params map[string]any) (string, error) {
+	// json.Marshal sorts the map keys, so the result is deterministic.
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot marshal the ephemeral resource config")
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+type terraformPluginFrameworkEphemeralResourceExternal struct {
+	config         *config.EphemeralResource
+	logger         logging.Logger
+	metricRecorder *metrics.MetricRecorder
+	renewBefore    time.Duration
+	store          *ephemeralResourceStore
+	server         tfprotov6.ProviderServer
+	// the terraform value type associated with the ephemeral resource schema
+	tfType tftypes.Type
+	// configured value for the ephemeral resource in terraform type system
+	configValue *tfprotov6.DynamicValue
+	configHash  string
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) Observe(_ context.Context, mg xpresource.Managed) (managed.ExternalObservation, error) {
+	n.logger.Debug("Observing the ephemeral resource")
+	i := n.store.get(mg.GetUID())
+	// if the ephemeral resource has not been opened yet, or its
+	// provider-private data has been lost, it's (re)opened.
+	if i == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	mg.SetConditions(xpv2.Available())
+	upToDate := i.configHash == n.configHash && !n.renewalDue(i)
+	if upToDate {
+		n.metricRecorder.SetReconcileTime(metrics.NameForManaged(mg))
+	}
+	resource.SetUpToDateCondition(mg, upToDate)
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: i.connectionDetails,
+	}, nil
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) renewalDue(i *ephemeralResourceInstance) bool {
+	return !i.renewAt.IsZero() && !time.Now().Add(n.renewBefore).Before(i.renewAt)
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) open(ctx context.Context, mg xpresource.Managed) (managed.ConnectionDetails, error) {
+	start := time.Now()
+	openResponse, err := n.server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: n.config.Name,
+		Config:   n.configValue,
+	})
+	metrics.ExternalAPITime.WithLabelValues("open").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot open ephemeral resource")
+	}
+	if fatalDiags := getFatalDiagnostics(openResponse.Diagnostics); fatalDiags != nil {
+		return nil, errors.Wrap(fatalDiags, "open ephemeral resource request failed")
+	}
+	if openResponse.Result == nil {
+		return nil, errors.New("open ephemeral resource request returned an empty result")
+	}
+	resultValue, err := openResponse.Result.Unmarshal(n.tfType)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot unmarshal ephemeral resource result")
+	}
+	result, err := tfValueToGoValue(resultValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert ephemeral resource result to JSON map")
+	}
+	resultMap, _ := result.(map[string]any)
+	conn, err := n.getConnectionDetails(resultMap)
+	if err != nil {
+		return nil, err
+	}
+	n.store.set(mg.GetUID(), &ephemeralResourceInstance{
+		private:           openResponse.Private,
+		renewAt:           openResponse.RenewAt,
+		configHash:        n.configHash,
+		connectionDetails: conn,
+	})
+	return conn, nil
+}
+
+// getConnectionDetails returns the result attributes of the ephemeral
+// resource together with the configured additional connection details.
+// Non-string attributes are JSON encoded.
+func (n *terraformPluginFrameworkEphemeralResourceExternal) getConnectionDetails(result map[string]any) (managed.ConnectionDetails, error) {
+	conn := managed.ConnectionDetails{}
+	for k, v := range result {
+		if v == nil {
+			continue
+		}
+		if !n.isResultAttribute(k) {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			conn[prefixEphemeralResourceResult+k] = []byte(s)
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal the ephemeral resource result attribute %q", k)
+		}
+		conn[prefixEphemeralResourceResult+k] = b
+	}
+	add, err := n.config.Sensitive.AdditionalConnectionDetailsFn(result)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get additional connection details")
+	}
+	for k, v := range add {
+		if _, ok := conn[k]; ok {
+			return nil, errors.Errorf("additional connection detail key %q cannot override a result attribute", k)
+		}
+		conn[k] = v
+	}
+	return conn, nil
+}
+
+// isResultAttribute returns true if the given top-level attribute of the
+// ephemeral resource is computed by the provider.
+func (n *terraformPluginFrameworkEphemeralResourceExternal) isResultAttribute(name string) bool {
+	if n.config.TerraformResource == nil {
+		return true
+	}
+	s, ok := n.config.TerraformResource.Schema[name]
+	return ok && s.Computed
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) close(ctx context.Context, i *ephemeralResourceInstance) error {
+	start := time.Now()
+	closeResponse, err := n.server.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: n.config.Name,
+		Private:  i.private,
+	})
+	metrics.ExternalAPITime.WithLabelValues("close").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return errors.Wrap(err, "cannot close ephemeral resource")
+	}
+	return errors.Wrap(getFatalDiagnostics(closeResponse.Diagnostics), "close ephemeral resource request failed")
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) Create(ctx context.Context, mg xpresource.Managed) (managed.ExternalCreation, error) {
+	n.logger.Debug("Opening the ephemeral resource")
+	conn, err := n.open(ctx, mg)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	return managed.ExternalCreation{ConnectionDetails: conn}, nil
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) Update(ctx context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) {
+	i := n.store.get(mg.GetUID())
+	if i == nil {
+		return managed.ExternalUpdate{}, errors.New("ephemeral resource has not been opened")
+	}
+	if i.configHash == n.configHash {
+		n.logger.Debug("Renewing the ephemeral resource", "renewAt", i.renewAt)
+		start := time.Now()
+		renewResponse, err := n.server.RenewEphemeralResource(ctx, &tfprotov6.RenewEphemeralResourceRequest{
+			TypeName: n.config.Name,
+			Private:  i.private,
+		})
+		metrics.ExternalAPITime.WithLabelValues("renew").Observe(time.Since(start).Seconds())
+		if err == nil {
+			err = getFatalDiagnostics(renewResponse.Diagnostics)
+		}
+		if err == nil {
+			n.store.set(mg.GetUID(), &ephemeralResourceInstance{
+				private:           renewResponse.Private,
+				renewAt:           renewResponse.RenewAt,
+				configHash:        i.configHash,
+				connectionDetails: i.connectionDetails,
+			})
+			return managed.ExternalUpdate{}, nil
+		}
+		// the ephemeral resource may have already expired, so we reopen it.
+		n.logger.Info("Cannot renew the ephemeral resource, reopening it", "error", err.Error())
+	}
+	n.logger.Debug("Reopening the ephemeral resource")
+	if err := n.close(ctx, i); err != nil {
+		n.logger.Info("Cannot close the previous ephemeral resource instance", "error", err.Error())
+	}
+	conn, err := n.open(ctx, mg)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	return managed.ExternalUpdate{ConnectionDetails: conn}, nil
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) Delete(ctx context.Context, mg xpresource.Managed) (managed.ExternalDelete, error) {
+	n.logger.Debug("Closing the ephemeral resource")
+	i := n.store.get(mg.GetUID())
+	if i == nil {
+		return managed.ExternalDelete{}, nil
+	}
+	if err := n.close(ctx, i); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+	n.store.remove(mg.GetUID())
+	return managed.ExternalDelete{}, nil
+}
+
+func (n *terraformPluginFrameworkEphemeralResourceExternal) Disconnect(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,431 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+const (
+	testEphemeralResourceUID        = types.UID("ephemeral-uid")
+	testEphemeralResourceConfigHash = "config-hash"
+)
+
+var testEphemeralResourceType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"name":   tftypes.String,
+		"token":  tftypes.String,
+		"scopes": tftypes.List{ElementType: tftypes.String},
+	},
+}
+
+func newEphemeralResourceConfig() *config.EphemeralResource {
+	return config.DefaultEphemeralResource("upjet_token", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"token": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"scopes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}, nil)
+}
+
+func newEphemeralResourceResult(token string) *tfprotov6.DynamicValue {
+	v, err := tfprotov6.NewDynamicValue(testEphemeralResourceType, tftypes.NewValue(testEphemeralResourceType, map[string]tftypes.Value{
+		"name":  tftypes.NewValue(tftypes.String, "example"),
+		"token": tftypes.NewValue(tftypes.String, token),
+		"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "read"),
+		}),
+	}))
+	if err != nil {
+		panic(err)
+	}
+	return &v
+}
+
+func newEphemeralResourceObject() *fake.Terraformed {
+	return &fake.Terraformed{
+		Managed: xpfake.Managed{
+			ObjectMeta: metav1.ObjectMeta{
+				UID: testEphemeralResourceUID,
+			},
+		},
+	}
+}
+
+func prepareTerraformPluginFrameworkEphemeralResourceExternal(server tfprotov6.ProviderServer, i *ephemeralResourceInstance) *terraformPluginFrameworkEphemeralResourceExternal {
+	store := &ephemeralResourceStore{
+		instances: make(map[types.UID]*ephemeralResourceInstance),
+	}
+	if i != nil {
+		store.set(testEphemeralResourceUID, i)
+	}
+	return &terraformPluginFrameworkEphemeralResourceExternal{
+		config:      newEphemeralResourceConfig(),
+		logger:      logTest,
+		renewBefore: time.Minute,
+		store:       store,
+		server:      server,
+		tfType:      testEphemeralResourceType,
+		configHash:  testEphemeralResourceConfigHash,
+	}
+}
+
+func TestTerraformPluginFrameworkEphemeralResourceObserve(t *testing.T) {
+	conn := managed.ConnectionDetails{"attribute.token": []byte("secret")}
+	type want struct {
+		obs managed.ExternalObservation
+	}
+	cases := map[string]struct {
+		instance *ephemeralResourceInstance
+		want
+	}{
+		"NotOpened": {
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			instance: &ephemeralResourceInstance{
+				renewAt:           time.Now().Add(time.Hour),
+				configHash:        testEphemeralResourceConfigHash,
+				connectionDetails: conn,
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: conn,
+				},
+			},
+		},
+		"NoRenewal": {
+			instance: &ephemeralResourceInstance{
+				configHash:        testEphemeralResourceConfigHash,
+				connectionDetails: conn,
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: conn,
+				},
+			},
+		},
+		"RenewalDue": {
+			instance: &ephemeralResourceInstance{
+				renewAt:           time.Now().Add(30 * time.Second),
+				configHash:        testEphemeralResourceConfigHash,
+				connectionDetails: conn,
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: conn,
+				},
+			},
+		},
+		"ConfigChanged": {
+			instance: &ephemeralResourceInstance{
+				configHash:        "old-hash",
+				connectionDetails: conn,
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: conn,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := prepareTerraformPluginFrameworkEphemeralResourceExternal(&mockTPFProviderServer{}, tc.instance)
+			obs, err := e.Observe(context.TODO(), newEphemeralResourceObject())
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): unexpected error: %v", name, err)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want observation, +got observation:\n%s", name, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginFrameworkEphemeralResourcePollIntervalHook(t *testing.T) {
+	pollInterval := 10 * time.Minute
+	type want struct {
+		// the requeue delay is expected to be in (min, max] as it's
+		// computed relative to the current time.
+		min, max time.Duration
+	}
+	cases := map[string]struct {
+		reason   string
+		instance *ephemeralResourceInstance
+		want     want
+	}{
+		"NotOpened": {
+			reason: "An ephemeral resource that has not been opened should be requeued with the poll interval.",
+			want:   want{min: pollInterval - time.Nanosecond, max: pollInterval},
+		},
+		"NoRenewal": {
+			reason:   "An ephemeral resource that does not need to be renewed should be requeued with the poll interval.",
+			instance: &ephemeralResourceInstance{},
+			want:     want{min: pollInterval - time.Nanosecond, max: pollInterval},
+		},
+		"RenewalAfterNextPoll": {
+			reason:   "An ephemeral resource to be renewed after the next poll should be requeued with the poll interval.",
+			instance: &ephemeralResourceInstance{renewAt: time.Now().Add(time.Hour)},
+			want:     want{min: pollInterval - time.Nanosecond, max: pollInterval},
+		},
+		"RenewalBeforeNextPoll": {
+			reason:   "An ephemeral resource to be renewed before the next poll should be requeued when its renewal is due.",
+			instance: &ephemeralResourceInstance{renewAt: time.Now().Add(5 * time.Minute)},
+			want:     want{min: 4*time.Minute - time.Second, max: 4 * time.Minute},
+		},
+		"RenewalOverdue": {
+			reason:   "An ephemeral resource whose renewal is overdue should be requeued after the minimum delay.",
+			instance: &ephemeralResourceInstance{renewAt: time.Now().Add(30 * time.Second)},
+			want:     want{min: minEphemeralResourceRenewalRequeue - time.Nanosecond, max: minEphemeralResourceRenewalRequeue},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewTerraformPluginFrameworkEphemeralResourceConnector(nil, nil, newEphemeralResourceConfig())
+			if tc.instance != nil {
+				c.store.set(testEphemeralResourceUID, tc.instance)
+			}
+			got := c.PollIntervalHook(0)(newEphemeralResourceObject(), pollInterval)
+			if got <= tc.want.min || got > tc.want.max {
+				t.Errorf("\n%s\nPollIntervalHook(...): want a requeue delay in (%s, %s], got %s", tc.reason, tc.want.min, tc.want.max, got)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginFrameworkEphemeralResourceCreate(t *testing.T) {
+	renewAt := time.Now().Add(time.Hour)
+	type want struct {
+		creation managed.ExternalCreation
+		instance *ephemeralResourceInstance
+		err      error
+	}
+	cases := map[string]struct {
+		server *mockTPFProviderServer
+		want
+	}{
+		"Opened": {
+			server: &mockTPFProviderServer{
+				OpenEphemeralResourceFn: func(_ context.Context, _ *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+					return &tfprotov6.OpenEphemeralResourceResponse{
+						Result:  newEphemeralResourceResult("secret"),
+						Private: []byte("private"),
+						RenewAt: renewAt,
+					}, nil
+				},
+			},
+			want: want{
+				creation: managed.ExternalCreation{
+					ConnectionDetails: managed.ConnectionDetails{
+						"attribute.token":  []byte("secret"),
+						"attribute.scopes": []byte(`["read"]`),
+					},
+				},
+				instance: &ephemeralResourceInstance{
+					private:    []byte("private"),
+					renewAt:    renewAt,
+					configHash: testEphemeralResourceConfigHash,
+					connectionDetails: managed.ConnectionDetails{
+						"attribute.token":  []byte("secret"),
+						"attribute.scopes": []byte(`["read"]`),
+					},
+				},
+			},
+		},
+		"OpenFailed": {
+			server: &mockTPFProviderServer{
+				OpenEphemeralResourceFn: func(_ context.Context, _ *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, "cannot open ephemeral resource"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := prepareTerraformPluginFrameworkEphemeralResourceExternal(tc.server, nil)
+			creation, err := e.Create(context.TODO(), newEphemeralResourceObject())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nCreate(...): -want error, +got error:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.creation, creation); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want creation, +got creation:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.instance, e.store.get(testEphemeralResourceUID), cmp.AllowUnexported(ephemeralResourceInstance{})); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want instance, +got instance:\n%s", name, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginFrameworkEphemeralResourceUpdate(t *testing.T) {
+	renewAt := time.Now().Add(time.Hour)
+	conn := managed.ConnectionDetails{"attribute.token": []byte("secret")}
+	openFn := func(_ context.Context, _ *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+		return &tfprotov6.OpenEphemeralResourceResponse{
+			Result:  newEphemeralResourceResult("new-secret"),
+			Private: []byte("new-private"),
+		}, nil
+	}
+	reopened := managed.ConnectionDetails{
+		"attribute.token":  []byte("new-secret"),
+		"attribute.scopes": []byte(`["read"]`),
+	}
+	type want struct {
+		update   managed.ExternalUpdate
+		instance *ephemeralResourceInstance
+	}
+	cases := map[string]struct {
+		server   *mockTPFProviderServer
+		instance *ephemeralResourceInstance
+		want
+	}{
+		"Renewed": {
+			server: &mockTPFProviderServer{
+				RenewEphemeralResourceFn: func(_ context.Context, req *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+					if string(req.Private) != "private" {
+						return nil, errors.Errorf("unexpected private data: %s", req.Private)
+					}
+					return &tfprotov6.RenewEphemeralResourceResponse{
+						Private: []byte("renewed-private"),
+						RenewAt: renewAt,
+					}, nil
+				},
+			},
+			instance: &ephemeralResourceInstance{
+				private:           []byte("private"),
+				configHash:        testEphemeralResourceConfigHash,
+				connectionDetails: conn,
+			},
+			want: want{
+				instance: &ephemeralResourceInstance{
+					private:           []byte("renewed-private"),
+					renewAt:           renewAt,
+					configHash:        testEphemeralResourceConfigHash,
+					connectionDetails: conn,
+				},
+			},
+		},
+		"RenewFailed": {
+			server: &mockTPFProviderServer{
+				RenewEphemeralResourceFn: func(_ context.Context, _ *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+					return nil, errBoom
+				},
+				OpenEphemeralResourceFn: openFn,
+			},
+			instance: &ephemeralResourceInstance{
+				private:           []byte("private"),
+				configHash:        testEphemeralResourceConfigHash,
+				connectionDetails: conn,
+			},
+			want: want{
+				update: managed.ExternalUpdate{ConnectionDetails: reopened},
+				instance: &ephemeralResourceInstance{
+					private:           []byte("new-private"),
+					configHash:        testEphemeralResourceConfigHash,
+					connectionDetails: reopened,
+				},
+			},
+		},
+		"ConfigChanged": {
+			server: &mockTPFProviderServer{
+				RenewEphemeralResourceFn: func(_ context.Context, _ *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+					return nil, errors.New("ephemeral resource should not be renewed")
+				},
+				OpenEphemeralResourceFn: openFn,
+			},
+			instance: &ephemeralResourceInstance{
+				private:           []byte("private"),
+				configHash:        "old-hash",
+				connectionDetails: conn,
+			},
+			want: want{
+				update: managed.ExternalUpdate{ConnectionDetails: reopened},
+				instance: &ephemeralResourceInstance{
+					private:           []byte("new-private"),
+					configHash:        testEphemeralResourceConfigHash,
+					connectionDetails: reopened,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := prepareTerraformPluginFrameworkEphemeralResourceExternal(tc.server, tc.instance)
+			update, err := e.Update(context.TODO(), newEphemeralResourceObject())
+			if err != nil {
+				t.Fatalf("\n%s\nUpdate(...): unexpected error: %v", name, err)
+			}
+			if diff := cmp.Diff(tc.want.update, update); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want update, +got update:\n%s", name, diff)
+			}
+			if diff := cmp.Diff(tc.want.instance, e.store.get(testEphemeralResourceUID), cmp.AllowUnexported(ephemeralResourceInstance{})); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want instance, +got instance:\n%s", name, diff)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginFrameworkEphemeralResourceDelete(t *testing.T) {
+	closed := false
+	e := prepareTerraformPluginFrameworkEphemeralResourceExternal(&mockTPFProviderServer{
+		CloseEphemeralResourceFn: func(_ context.Context, req *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+			closed = string(req.Private) == "private"
+			return &tfprotov6.CloseEphemeralResourceResponse{}, nil
+		},
+	}, &ephemeralResourceInstance{
+		private:    []byte("private"),
+		configHash: testEphemeralResourceConfigHash,
+	})
+	if _, err := e.Delete(context.TODO(), newEphemeralResourceObject()); err != nil {
+		t.Fatalf("Delete(...): unexpected error: %v", err)
+	}
+	if !closed {
+		t.Error("Delete(...): ephemeral resource was not closed")
+	}
+	if i := e.store.get(testEphemeralResourceUID); i != nil {
+		t.Errorf("Delete(...): ephemeral resource instance was not removed from the store: %v", i)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	xpmeta "github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
@@ -17,9 +18,13 @@ import (
 	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	k8sexec "k8s.io/utils/exec"
+	testingexec "k8s.io/utils/exec/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
@@ -50,20 +55,26 @@ var (
 		resource.AnnotationKeyPrivateRawAttribute: "",
 		xpmeta.AnnotationKeyExternalName:          "some-id",
 	}
+	examplePlanOnlyAnnotations = map[string]string{
+		resource.AnnotationKeyPrivateRawAttribute: "",
+		xpmeta.AnnotationKeyExternalName:          "some-id",
+		resource.AnnotationKeyReconciliationMode:  resource.ReconciliationModePlanOnly,
+	}
 )
 
 type WorkspaceFns struct {
-	ApplyAsyncFn   func(callback terraform.CallbackFn) error
+	ApplyAsyncFn   func(callback terraform.CallbackFn, opts ...terraform.ApplyAsyncOption) error
 	ApplyFn        func(ctx context.Context) (terraform.ApplyResult, error)
 	DestroyAsyncFn func(callback terraform.CallbackFn) error
 	DestroyFn      func(ctx context.Context) error
 	RefreshFn      func(ctx context.Context) (terraform.RefreshResult, error)
 	ImportFn       func(ctx context.Context, tr resource.Terraformed) (terraform.ImportResult, error)
 	PlanFn         func(ctx context.Context) (terraform.PlanResult, error)
+	StateFn        func() (*json.StateV4, error)
 }
 
-func (c WorkspaceFns) ApplyAsync(callback terraform.CallbackFn) error {
-	return c.ApplyAsyncFn(callback)
+func (c WorkspaceFns) ApplyAsync(callback terraform.CallbackFn, opts ...terraform.ApplyAsyncOption) error {
+	return c.ApplyAsyncFn(callback, opts...)
 }
 
 func (c WorkspaceFns) Apply(ctx context.Context) (terraform.ApplyResult, error) {
@@ -86,6 +97,10 @@ func (c WorkspaceFns) Plan(ctx context.Context) (terraform.PlanResult, error) {
 	return c.PlanFn(ctx)
 }
 
+func (c WorkspaceFns) State() (*json.StateV4, error) {
+	return c.StateFn()
+}
+
 func (c WorkspaceFns) Import(ctx context.Context, tr resource.Terraformed) (terraform.ImportResult, error) {
 	return c.ImportFn(ctx, tr)
 }
@@ -201,6 +216,7 @@ func TestObserve(t *testing.T) {
 	type want struct {
 		obs       managed.ExternalObservation
 		condition *xpv2.Condition
+		events    []event.Event
 		err       error
 	}
 	cases := map[string]struct {
@@ -562,6 +578,173 @@ func TestObserve(t *testing.T) {
 				condition: available(),
 			},
 		},
+		"InterruptedAsyncCreateOrphaned": {
+			reason: "It should not create the resource again if an interrupted async create has not recorded the identifier of the external resource",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								resource.AnnotationKeyAsyncOperation: `{"type":"create","startTime":"2025-01-02T03:04:05Z"}`,
+							},
+						},
+						Manageable: xpfake.Manageable{
+							Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+						},
+					},
+				},
+				w: WorkspaceFns{
+					RefreshFn: func(_ context.Context) (terraform.RefreshResult, error) {
+						return terraform.RefreshResult{Exists: false}, nil
+					},
+				},
+			},
+			want: want{
+				err:    errors.Errorf(errFmtOrphanedCreate, "2025-01-02T03:04:05Z", xpmeta.AnnotationKeyExternalName, resource.AnnotationKeyAsyncOperation),
+				events: []event.Event{event.Warning(reasonOrphanedCreate, errors.Errorf(errFmtOrphanedCreate, "2025-01-02T03:04:05Z", xpmeta.AnnotationKeyExternalName, resource.AnnotationKeyAsyncOperation))},
+			},
+		},
+		"InterruptedAsyncCreateNotCreated": {
+			reason: "It should create the resource again if it does not exist while an interrupted async create has recorded its Terraform ID",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								resource.AnnotationKeyAsyncOperation: `{"type":"create","startTime":"2025-01-02T03:04:05Z","id":"some-id"}`,
+							},
+						},
+						Manageable: xpfake.Manageable{
+							Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+						},
+					},
+				},
+				w: WorkspaceFns{
+					RefreshFn: func(_ context.Context) (terraform.RefreshResult, error) {
+						return terraform.RefreshResult{Exists: false}, nil
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"PlanOnlyCreate": {
+			reason: "A non-existent resource should be reported as up-to-date with a planned creation in the plan-only mode",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								resource.AnnotationKeyReconciliationMode: resource.ReconciliationModePlanOnly,
+							},
+						},
+						Manageable: xpfake.Manageable{
+							Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+						},
+					},
+				},
+				w: WorkspaceFns{
+					RefreshFn: func(_ context.Context) (terraform.RefreshResult, error) {
+						return terraform.RefreshResult{Exists: false}, nil
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				condition: planned(resource.ReasonPlannedCreate, "Terraform plans to create the external resource"),
+				events:    []event.Event{event.Normal(reasonChangesPlanned, "Terraform plans to create the external resource")},
+			},
+		},
+		"PlanOnlyReplace": {
+			reason: "A resource to be replaced should be reported as up-to-date with a planned replacement and its attribute diff in the plan-only mode",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: examplePlanOnlyAnnotations,
+						},
+						ConditionedStatus: xpv2.ConditionedStatus{
+							Conditions: []xpv2.Condition{xpv2.Available()},
+						},
+						Manageable: xpfake.Manageable{
+							Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+						},
+					},
+				},
+				w: WorkspaceFns{
+					RefreshFn: func(_ context.Context) (terraform.RefreshResult, error) {
+						return terraform.RefreshResult{
+							Exists: true,
+							State:  exampleState,
+						}, nil
+					},
+					PlanFn: func(_ context.Context) (terraform.PlanResult, error) {
+						return terraform.PlanResult{
+							Exists:  true,
+							Replace: true,
+							Change: &tfjson.Change{
+								Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+								Before:  map[string]any{"id": "some-id", "param": "oldval"},
+								After:   map[string]any{"param": "paramval"},
+								AfterUnknown: map[string]any{
+									"id": true,
+								},
+							},
+						}, nil
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				condition: planned(resource.ReasonPlannedReplace, `Terraform plans to replace the external resource. The following fields would be changed: spec.forProvider.id (observed: "some-id", desired: (known after apply)), spec.forProvider.param (observed: "oldval", desired: "paramval")`),
+				events: []event.Event{
+					event.Normal(reasonChangesPlanned, `Terraform plans to replace the external resource. The following fields would be changed: spec.forProvider.id (observed: "some-id", desired: (known after apply)), spec.forProvider.param (observed: "oldval", desired: "paramval")`),
+				},
+			},
+		},
+		"PlanOnlyNoChanges": {
+			reason: "An up-to-date resource should have no planned changes in the plan-only mode",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: examplePlanOnlyAnnotations,
+						},
+						ConditionedStatus: xpv2.ConditionedStatus{
+							Conditions: []xpv2.Condition{xpv2.Available()},
+						},
+						Manageable: xpfake.Manageable{
+							Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+						},
+					},
+				},
+				w: WorkspaceFns{
+					RefreshFn: func(_ context.Context) (terraform.RefreshResult, error) {
+						return terraform.RefreshResult{
+							Exists: true,
+							State:  exampleState,
+						}, nil
+					},
+					PlanFn: func(_ context.Context) (terraform.PlanResult, error) {
+						return terraform.PlanResult{Exists: true, UpToDate: true}, nil
+					},
+				},
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				condition: noChangesPlanned(),
+			},
+		},
 		"AnnotationsUpdatedManuallyManagementPolicyNoLateInitError": {
 			reason: "Should handle the error of updating annotations manually if they are not up-to-date and the policy is not late-init",
 			args: args{
@@ -593,7 +776,8 @@ func TestObserve(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{workspace: tc.w, config: config.DefaultResource("upjet_resource", nil, nil, nil), kube: tc.args.client, logger: logging.NewNopLogger()}
+			recorder := &driftTestRecorder{}
+			e := &external{workspace: tc.w, config: config.DefaultResource("upjet_resource", nil, nil, nil), kube: tc.args.client, eventRecorder: recorder, logger: logging.NewNopLogger()}
 			observation, err := e.Observe(context.TODO(), tc.args.obj)
 			if diff := cmp.Diff(tc.want.obs, observation); diff != "" {
 				t.Errorf("\n%s\nObserve(...): -want observation, +got observation:\n%s", tc.reason, diff)
@@ -606,6 +790,9 @@ func TestObserve(t *testing.T) {
 					t.Errorf("\n%s\nObserve(...): -want condition, +got condition:\n%s", tc.reason, diff)
 				}
 			}
+			if diff := cmp.Diff(tc.want.events, recorder.events); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
 		})
 	}
 }
@@ -615,6 +802,16 @@ func available() *xpv2.Condition {
 	return &c
 }
 
+func planned(action xpv2.ConditionReason, msg string) *xpv2.Condition {
+	c := resource.PlannedCondition(action, msg)
+	return &c
+}
+
+func noChangesPlanned() *xpv2.Condition {
+	c := resource.NoChangesPlannedCondition()
+	return &c
+}
+
 func TestCreate(t *testing.T) {
 	type args struct {
 		w   Workspace
@@ -652,7 +849,7 @@ func TestCreate(t *testing.T) {
 				},
 				obj: &fake.Terraformed{},
 				w: WorkspaceFns{
-					ApplyAsyncFn: func(_ terraform.CallbackFn) error {
+					ApplyAsyncFn: func(_ terraform.CallbackFn, _ ...terraform.ApplyAsyncOption) error {
 						return errBoom
 					},
 				},
@@ -688,6 +885,79 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateAsyncPartialState(t *testing.T) {
+	cfg := config.DefaultResource("upjet_resource", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":     {Type: schema.TypeString, Required: true},
+			"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		},
+	}, nil, nil)
+	cfg.UseAsync = true
+	cfg.ExternalName = config.IdentifierFromProvider
+	var persisted map[string]string
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.SetAnnotations(nil)
+			return nil
+		},
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			persisted = obj.GetAnnotations()
+			return nil
+		},
+	}
+	var callbackErr error
+	e := &external{
+		workspace: WorkspaceFns{
+			ApplyAsyncFn: func(callback terraform.CallbackFn, _ ...terraform.ApplyAsyncOption) error {
+				// the apply fails after the external resource is recorded in
+				// the Terraform state of the workspace.
+				return callback(errBoom, context.TODO())
+			},
+			StateFn: func() (*json.StateV4, error) {
+				return &json.StateV4{Resources: []json.ResourceStateV4{{Instances: []json.InstanceObjectStateV4{{
+					AttributesRaw: []byte(`{"id":"some-id","name":"example","password":"secret"}`),
+				}}}}}, nil
+			},
+		},
+		callback: CallbackFns{
+			CreateFn: func(_ types.NamespacedName) terraform.CallbackFn {
+				return func(err error, _ context.Context) error {
+					callbackErr = err
+					return nil
+				}
+			},
+		},
+		config: cfg,
+		kube:   kube,
+		logger: logging.NewNopLogger(),
+	}
+	mg := &fake.Terraformed{}
+	if _, err := e.Create(context.TODO(), mg); err != nil {
+		t.Fatalf("Create(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(errBoom, callbackErr, test.EquateErrors()); diff != "" {
+		t.Errorf("Create(...): -want callback error, +got callback error:\n%s", diff)
+	}
+	if _, ok := mg.GetAnnotations()[resource.AnnotationKeyAsyncOperation]; !ok {
+		t.Error("Create(...): the async create operation has not been recorded")
+	}
+	got := &fake.Terraformed{}
+	got.SetAnnotations(persisted)
+	op, err := resource.GetAsyncOperation(got)
+	if err != nil || op == nil {
+		t.Fatalf("Create(...): the record of the failed async create has not been persisted: %v", err)
+	}
+	if diff := cmp.Diff("some-id", op.ID); diff != "" {
+		t.Errorf("Create(...): -want recorded ID, +got recorded ID:\n%s", diff)
+	}
+	if diff := cmp.Diff(`{"name":"example"}`, string(op.State)); diff != "" {
+		t.Errorf("Create(...): -want recorded state, +got recorded state:\n%s", diff)
+	}
+	if diff := cmp.Diff("some-id", persisted[xpmeta.AnnotationKeyExternalName]); diff != "" {
+		t.Errorf("Create(...): -want persisted external-name, +got persisted external-name:\n%s", diff)
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	type args struct {
 		w   Workspace
@@ -725,7 +995,7 @@ func TestUpdate(t *testing.T) {
 				},
 				obj: &fake.Terraformed{},
 				w: WorkspaceFns{
-					ApplyAsyncFn: func(_ terraform.CallbackFn) error {
+					ApplyAsyncFn: func(_ terraform.CallbackFn, _ ...terraform.ApplyAsyncOption) error {
 						return errBoom
 					},
 				},
@@ -761,6 +1031,73 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+// cancelableExec runs the Terraform commands until their contexts are
+// canceled.
+type cancelableExec struct {
+	*testingexec.FakeExec
+}
+
+func (e cancelableExec) CommandContext(ctx context.Context, _ string, _ ...string) k8sexec.Cmd {
+	return &testingexec.FakeCmd{
+		CombinedOutputScript: []testingexec.FakeAction{
+			func() ([]byte, []byte, error) {
+				<-ctx.Done()
+				return nil, nil, ctx.Err()
+			},
+		},
+	}
+}
+
+func TestAsyncUpdateSupersede(t *testing.T) {
+	ws := terraform.NewWorkspace(t.TempDir(), terraform.WithExecutor(cancelableExec{FakeExec: &testingexec.FakeExec{}}), terraform.WithFilterFn(func(s string) string { return s }))
+	errs := make(chan error, 1)
+	recorder := &driftTestRecorder{}
+	e := &external{
+		workspace: ws,
+		operation: ws.LastOperation,
+		callback: CallbackFns{
+			UpdateFn: func(_ types.NamespacedName) terraform.CallbackFn {
+				return func(err error, _ context.Context) error {
+					errs <- err
+					return nil
+				}
+			},
+		},
+		config:        &config.Resource{UseAsync: true},
+		eventRecorder: recorder,
+		logger:        logging.NewNopLogger(),
+	}
+	mg := &fake.Terraformed{
+		Managed: xpfake.Managed{
+			ObjectMeta: metav1.ObjectMeta{Generation: 1},
+			Manageable: xpfake.Manageable{
+				Policy: xpv2.ManagementPolicies{xpv2.ManagementActionAll},
+			},
+		},
+	}
+	if _, err := e.Update(context.TODO(), mg); err != nil {
+		t.Fatalf("Update(...): unexpected error: %v", err)
+	}
+
+	// a newer generation of the managed resource supersedes the running
+	// async apply of the update.
+	mg.SetGeneration(2)
+	obs, err := e.Observe(context.TODO(), mg)
+	if err != nil {
+		t.Fatalf("Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, obs); diff != "" {
+		t.Errorf("Observe(...): -want observation, +got observation:\n%s", diff)
+	}
+	if diff := cmp.Diff(nil, <-errs, test.EquateErrors()); diff != "" {
+		t.Errorf("Update(...): -want callback error, +got callback error:\n%s", diff)
+	}
+	want := []event.Event{event.Normal(reasonSupersededAsyncOperation, "Canceled the asynchronous apply operation started for generation 1, which has been superseded by generation 2 of the managed resource")}
+	if diff := cmp.Diff(want, recorder.events); diff != "" {
+		t.Errorf("Observe(...): -want events, +got events:\n%s", diff)
+	}
+}
+
 func TestDelete(t *testing.T) {
 	type args struct {
 		w   Workspace
@@ -813,6 +1150,29 @@ func TestDelete(t *testing.T) {
 				err: errors.Wrap(errBoom, errDestroy),
 			},
 		},
+		"PlanOnly": {
+			reason: "It should refuse to destroy the resource in the plan-only mode",
+			args: args{
+				obj: &fake.Terraformed{
+					Managed: xpfake.Managed{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								resource.AnnotationKeyReconciliationMode: resource.ReconciliationModePlanOnly,
+							},
+						},
+					},
+				},
+				cfg: &config.Resource{},
+				w: WorkspaceFns{
+					DestroyFn: func(_ context.Context) error {
+						return errBoom
+					},
+				},
+			},
+			want: want{
+				err: errors.New(errPlanOnly),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
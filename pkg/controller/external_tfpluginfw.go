@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
@@ -47,6 +48,7 @@ type TerraformPluginFrameworkConnector struct {
 	config                      *config.Resource
 	logger                      logging.Logger
 	metricRecorder              *metrics.MetricRecorder
+	eventRecorder               event.Recorder
 	operationTrackerStore       *OperationTrackerStore
 	isManagementPoliciesEnabled bool
 }
@@ -69,6 +71,14 @@ func WithTerraformPluginFrameworkMetricRecorder(r *metrics.MetricRecorder) Terra
 	}
 }
 
+// WithTerraformPluginFrameworkEventRecorder configures an event.Recorder for
+// the TerraformPluginFrameworkConnector, which records the drift events.
+func WithTerraformPluginFrameworkEventRecorder(r event.Recorder) TerraformPluginFrameworkConnectorOption {
+	return func(c *TerraformPluginFrameworkConnector) {
+		c.eventRecorder = r
+	}
+}
+
 // WithTerraformPluginFrameworkManagementPolicies configures whether the client should
 // handle management policies.
 func WithTerraformPluginFrameworkManagementPolicies(isManagementPoliciesEnabled bool) TerraformPluginFrameworkConnectorOption {
@@ -94,14 +104,17 @@ func NewTerraformPluginFrameworkConnector(kube client.Client, sf terraform.Setup
 
 type terraformPluginFrameworkExternalClient struct {
 	ts              terraform.Setup
+	kube            client.Client
 	config          *config.Resource
 	logger          logging.Logger
 	metricRecorder  *metrics.MetricRecorder
+	eventRecorder   event.Recorder
 	opTracker       *AsyncTracker
 	resource        fwresource.Resource
 	server          tfprotov6.ProviderServer
 	params          map[string]any
 	planResponse    *tfprotov6.PlanResourceChangeResponse
+	plannedDiff     []tftypes.ValueDiff
 	plannedIdentity *tfprotov6.ResourceIdentityData
 	resourceSchema  rschema.Schema
 	// the terraform value type associated with the resource schema
@@ -140,6 +153,9 @@ func getFrameworkExtendedParameters(ctx context.Context, tr resource.Terraformed
 	if err = resource.GetSensitiveParameters(ctx, &APISecretClient{kube: kube}, tr, params, tr.GetConnectionDetailsMapping()); err != nil {
 		return nil, errors.Wrap(err, "cannot store sensitive parameters into params")
 	}
+	if err = resource.GetValueFromParameters(ctx, kube, cfg, tr, params); err != nil {
+		return nil, errors.Wrap(err, "cannot store the parameters sourced from other objects into params")
+	}
 	cfg.ExternalName.SetIdentifierArgumentFn(params, externalName)
 	if cfg.TerraformConfigurationInjector != nil {
 		m, err := getJSONMap(tr)
@@ -233,6 +249,14 @@ func (c *TerraformPluginFrameworkConnector) Connect(ctx context.Context, mg xpre
 		if copyParams {
 			tfState = copyParameters(tfState, params)
 		}
+		// the partial state returned by the provider before a failed or
+		// interrupted asynchronous create identifies the external resource
+		// which may have been created.
+		_, partial, err := asyncCreateState(mg)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(tfState, partial)
 
 		tfStateDynamicValue, err := protov6DynamicValueFromMap(tfState, resourceTfValueType)
 		if err != nil {
@@ -241,16 +265,18 @@ func (c *TerraformPluginFrameworkConnector) Connect(ctx context.Context, mg xpre
 		opTracker.SetReconstructedFrameworkTFState(tfStateDynamicValue)
 	}
 
-	configuredProviderServer, err := c.configureProvider(ctx, ts)
+	configuredProviderServer, err := configureFrameworkProvider(ctx, ts)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not configure provider server")
 	}
 
 	return &terraformPluginFrameworkExternalClient{
 		ts:                           ts,
+		kube:                         c.kube,
 		config:                       c.config,
 		logger:                       logger,
 		metricRecorder:               c.metricRecorder,
+		eventRecorder:                c.eventRecorder,
 		opTracker:                    opTracker,
 		resource:                     c.config.TerraformPluginFrameworkResource,
 		server:                       configuredProviderServer,
@@ -273,12 +299,12 @@ func (c *TerraformPluginFrameworkConnector) getResourceSchema(ctx context.Contex
 	return schemaResp.Schema, nil
 }
 
-// configureProvider returns a configured Terraform protocol v5 provider server
-// with the preconfigured provider instance in the terraform setup.
-// The provider instance used should be already preconfigured
+// configureFrameworkProvider returns a configured Terraform protocol v6
+// provider server with the preconfigured provider instance in the terraform
+// setup. The provider instance used should be already preconfigured
 // at the terraform setup layer with the relevant provider meta if needed
 // by the provider implementation.
-func (c *TerraformPluginFrameworkConnector) configureProvider(ctx context.Context, ts terraform.Setup) (tfprotov6.ProviderServer, error) {
+func configureFrameworkProvider(ctx context.Context, ts terraform.Setup) (tfprotov6.ProviderServer, error) {
 	if ts.FrameworkProvider == nil {
 		return nil, fmt.Errorf("cannot retrieve framework provider")
 	}
@@ -364,6 +390,12 @@ func (c *TerraformPluginFrameworkConnector) getResourceConfigTerraformValue(ctx
 //     computed-only. Such a case is taken as a previously set value being
 //     unset and is thus, not filtered.
 func (n *terraformPluginFrameworkExternalClient) filteredDiffExists(ctx context.Context, rawDiff []tftypes.ValueDiff) bool {
+	return len(n.filteredDiff(ctx, rawDiff)) > 0
+}
+
+// filteredDiff returns the diffs in the raw diff that are not filtered out as
+// explained in filteredDiffExists.
+func (n *terraformPluginFrameworkExternalClient) filteredDiff(ctx context.Context, rawDiff []tftypes.ValueDiff) []tftypes.ValueDiff {
 	filteredDiff := make([]tftypes.ValueDiff, 0)
 	for _, diff := range rawDiff {
 		// Keep diffs where the planned value is non-null and known.
@@ -384,7 +416,7 @@ func (n *terraformPluginFrameworkExternalClient) filteredDiffExists(ctx context.
 		}
 		filteredDiff = append(filteredDiff, diff)
 	}
-	return len(filteredDiff) > 0
+	return filteredDiff
 }
 
 // isUnderComputedOnlyAttribute returns true if the attribute itself or
@@ -455,7 +487,8 @@ func (n *terraformPluginFrameworkExternalClient) getDiffPlanResponse(ctx context
 		n.plannedIdentity = planResponse.PlannedIdentity
 	}
 
-	return planResponse, n.filteredDiffExists(ctx, rawDiff), nil
+	n.plannedDiff = n.filteredDiff(ctx, rawDiff)
+	return planResponse, len(n.plannedDiff) > 0, nil
 }
 
 // filterRequiresReplace checks the TF plan response for fields that require/force resource
@@ -524,6 +557,37 @@ func (n *terraformPluginFrameworkExternalClient) recoverExternalName(mg xpresour
 	return changed
 }
 
+// partialCreateState returns the Terraform ID, if the resource has one, and
+// the state of the external resource returned by the provider before a create
+// call failed, if any.
+func (n *terraformPluginFrameworkExternalClient) partialCreateState() (string, map[string]any) {
+	if !n.opTracker.HasFrameworkTFState() {
+		return "", nil
+	}
+	tfStateValue, err := n.opTracker.GetFrameworkTFState().Unmarshal(n.resourceValueTerraformType)
+	if err != nil || tfStateValue.IsNull() {
+		return "", nil
+	}
+	tfStateGoValue, err := tfValueToGoValue(tfStateValue)
+	if err != nil {
+		return "", nil
+	}
+	tfStateMap, ok := tfStateGoValue.(map[string]any)
+	if !ok {
+		return "", nil
+	}
+	id, _ := tfStateMap["id"].(string)
+	return id, tfStateMap
+}
+
+// isSensitiveAttribute reports whether the given top-level attribute of the
+// Terraform resource is sensitive. The attributes not found in the schema
+// are reported as sensitive.
+func (n *terraformPluginFrameworkExternalClient) isSensitiveAttribute(name string) bool {
+	attr, ok := n.resourceSchema.GetAttributes()[name]
+	return !ok || attr.IsSensitive()
+}
+
 // hasResourceNotFoundDiagnostic checks whether supplied TF ReadResource diagnostics
 // corresponds to a non-existent resource, and should be ignored.
 func (n *terraformPluginFrameworkExternalClient) hasResourceNotFoundDiagnostic(diags []*tfprotov6.Diagnostic) (shouldSupress bool) {
@@ -559,6 +623,11 @@ func (n *terraformPluginFrameworkExternalClient) Observe(ctx context.Context, mg
 			ResourceExists: false,
 		}, nil
 	}
+	if !resource.IsPlanOnly(mg) {
+		if err := n.destroyPendingReplaced(ctx, mg); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
 
 	readRequest := &tfprotov6.ReadResourceRequest{
 		TypeName:     n.config.Name,
@@ -747,6 +816,7 @@ func (n *terraformPluginFrameworkExternalClient) Observe(ctx context.Context, mg
 		if !specUpdateRequired {
 			resource.SetUpToDateCondition(mg, !hasDiff)
 		}
+		recordDrift(mg, n.eventRecorder, frameworkDriftedFields(n.config, n.plannedDiff))
 		if nameChanged, err := n.setExternalName(mg, stateValueMap); err != nil {
 			return managed.ExternalObservation{}, errors.Wrapf(err, "failed to set the external-name of the managed resource during observe")
 		} else {
@@ -754,16 +824,25 @@ func (n *terraformPluginFrameworkExternalClient) Observe(ctx context.Context, mg
 		}
 	}
 
-	return managed.ExternalObservation{
+	o := managed.ExternalObservation{
 		ResourceExists:          resourceExists,
 		ResourceUpToDate:        !hasDiff,
 		ConnectionDetails:       connDetails,
 		ResourceLateInitialized: specUpdateRequired,
-	}, nil
+	}
+	if resource.IsPlanOnly(mg) {
+		requiresReplace, _ := n.planRequiresReplace()
+		recordPlan(mg, n.eventRecorder, plannedAction(mg, resourceExists, hasDiff, requiresReplace), frameworkDriftedFields(n.config, n.plannedDiff))
+		o = planOnlyObservation(mg, o)
+	}
+	return o, nil
 }
 
 func (n *terraformPluginFrameworkExternalClient) Create(ctx context.Context, mg xpresource.Managed) (managed.ExternalCreation, error) { //nolint:gocyclo // easier to follow as a unit
 	n.logger.Debug("Creating the external resource")
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalCreation{}, errors.New(errPlanOnly)
+	}
 
 	tfConfigDynamicVal, err := tfprotov6.NewDynamicValue(n.resourceValueTerraformType, n.resourceTerraformConfigValue.Copy())
 	if err != nil {
@@ -875,11 +954,123 @@ func (n *terraformPluginFrameworkExternalClient) planRequiresReplace() (bool, st
 
 }
 
+// requiresReplaceFields returns the changed fields that require replacing the
+// external resource.
+func (n *terraformPluginFrameworkExternalClient) requiresReplaceFields() []driftedField {
+	var diffs []tftypes.ValueDiff
+	for _, p := range n.planResponse.RequiresReplace {
+		matched := false
+		for _, d := range n.plannedDiff {
+			if d.Path != nil && len(d.Path.Steps()) >= len(p.Steps()) && tftypes.NewAttributePathWithSteps(d.Path.Steps()[:len(p.Steps())]).Equal(p) {
+				diffs = append(diffs, d)
+				matched = true
+			}
+		}
+		if !matched {
+			// report the attribute path without its values
+			diffs = append(diffs, tftypes.ValueDiff{Path: p})
+		}
+	}
+	return frameworkDriftedFields(n.config, diffs)
+}
+
+// replace replaces the external resource by destroying it and then creating
+// its replacement, or by creating the replacement first if
+// createBeforeDestroy is set.
+func (n *terraformPluginFrameworkExternalClient) replace(ctx context.Context, mg xpresource.Managed, createBeforeDestroy bool, r replacementRecorder) (managed.ExternalUpdate, error) { //nolint:gocyclo // easier to follow as a unit
+	replaced := n.opTracker.GetFrameworkTFState()
+	var replacedIdentity *tfprotov6.ResourceIdentityData
+	if n.supportsIdentity() {
+		replacedIdentity = n.opTracker.GetFrameworkIdentity()
+	}
+	restore := func() {
+		if !createBeforeDestroy {
+			return
+		}
+		n.opTracker.SetFrameworkTFState(replaced)
+		if n.supportsIdentity() {
+			n.opTracker.SetFrameworkIdentity(replacedIdentity)
+		}
+	}
+	if !createBeforeDestroy {
+		if _, err := n.destroy(ctx, replaced); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "cannot destroy the replaced external resource")
+		}
+		r.normal(reasonDestroyedReplacedResource, "Destroyed the external resource being replaced")
+	} else if err := recordReplaced(ctx, n.kube, mg, replaced); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	nullStateValue := tftypes.NewValue(n.resourceValueTerraformType, nil)
+	nullState, err := tfprotov6.NewDynamicValue(n.resourceValueTerraformType, nullStateValue)
+	if err != nil {
+		restore()
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot create nil dynamic value")
+	}
+	n.opTracker.SetFrameworkTFState(&nullState)
+	if n.supportsIdentity() {
+		n.opTracker.SetFrameworkIdentity(nil)
+	}
+	planResponse, _, err := n.getDiffPlanResponse(ctx, nullStateValue)
+	if err != nil {
+		restore()
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot plan the replacement of the external resource")
+	}
+	n.planResponse = planResponse
+	c, err := n.Create(ctx, mg)
+	if err != nil {
+		restore()
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot create the replacement of the external resource")
+	}
+	r.normal(reasonCreatedReplacement, "Created the replacement of the external resource")
+	if err := persistReplacement(ctx, n.kube, mg); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrapf(err, errFmtPersistReplacement, meta.GetExternalName(mg))
+	}
+
+	if createBeforeDestroy {
+		if _, err := n.destroy(ctx, replaced); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "created the replacement but cannot destroy the replaced external resource")
+		}
+		r.normal(reasonDestroyedReplacedResource, "Destroyed the replaced external resource")
+		resource.RemoveReplacedResource(mg)
+		if err := persistAnnotations(ctx, n.kube, mg, resource.AnnotationKeyReplacedResource); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	r.normal(reasonReplacedExternalResource, "Replaced the external resource")
+	return managed.ExternalUpdate{ConnectionDetails: c.ConnectionDetails}, nil
+}
+
+// destroyPendingReplaced destroys the replaced external resource whose
+// destruction failed after its replacement had been created.
+func (n *terraformPluginFrameworkExternalClient) destroyPendingReplaced(ctx context.Context, mg xpresource.Managed) error {
+	return destroyPendingReplaced(ctx, n.kube, replacementRecorder{mg: mg, recorder: n.eventRecorder}, func(state []byte) error {
+		s := &tfprotov6.DynamicValue{}
+		if err := json.Unmarshal(state, s); err != nil {
+			return errors.Wrap(err, "cannot unmarshal the state of the replaced external resource")
+		}
+		_, err := n.destroy(ctx, s)
+		return err
+	})
+}
+
 func (n *terraformPluginFrameworkExternalClient) Update(ctx context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) { //nolint:gocyclo // easier to follow as a unit
 	n.logger.Debug("Updating the external resource")
-	// refuse plans that require replace for XRM compliance
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalUpdate{}, errors.New(errPlanOnly)
+	}
+	// refuse plans that require replace for XRM compliance unless the
+	// replacement policy allows replacing the external resource
 	if isReplace, fields := n.planRequiresReplace(); isReplace {
-		return managed.ExternalUpdate{}, errors.Errorf("diff contains fields that require resource replacement: %s", fields)
+		policy, err := resource.GetReplacementPolicy(mg, n.config)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, "cannot get the replacement policy")
+		}
+		r := replacementRecorder{mg: mg, recorder: n.eventRecorder}
+		if err := r.admitReplacement(policy, n.requiresReplaceFields(), errors.Errorf("diff contains fields that require resource replacement: %s", fields)); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		return n.replace(ctx, mg, policy == config.ReplacementPolicyCreateBeforeDestroy, r)
 	}
 
 	tfConfigDynamicVal, err := tfprotov6.NewDynamicValue(n.resourceValueTerraformType, n.resourceTerraformConfigValue.Copy())
@@ -950,22 +1141,21 @@ func (n *terraformPluginFrameworkExternalClient) Update(ctx context.Context, mg
 	return managed.ExternalUpdate{}, nil
 }
 
-func (n *terraformPluginFrameworkExternalClient) Delete(ctx context.Context, _ xpresource.Managed) (managed.ExternalDelete, error) {
-	n.logger.Debug("Deleting the external resource")
-
+// destroy destroys the external resource with the given prior state.
+func (n *terraformPluginFrameworkExternalClient) destroy(ctx context.Context, priorState *tfprotov6.DynamicValue) (*tfprotov6.ApplyResourceChangeResponse, error) {
 	tfConfigDynamicVal, err := tfprotov6.NewDynamicValue(n.resourceValueTerraformType, n.resourceTerraformConfigValue.Copy())
 	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, "cannot construct dynamic value for TF Config")
+		return nil, errors.Wrap(err, "cannot construct dynamic value for TF Config")
 	}
 	// set an empty planned state, this corresponds to deleting
 	plannedState, err := tfprotov6.NewDynamicValue(n.resourceValueTerraformType, tftypes.NewValue(n.resourceValueTerraformType, nil))
 	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, "cannot set the planned state for deletion")
+		return nil, errors.Wrap(err, "cannot set the planned state for deletion")
 	}
 
 	applyRequest := &tfprotov6.ApplyResourceChangeRequest{
 		TypeName:     n.config.Name,
-		PriorState:   n.opTracker.GetFrameworkTFState(),
+		PriorState:   priorState,
 		PlannedState: &plannedState,
 		Config:       &tfConfigDynamicVal,
 		// PlannedIdentity is intentionally nil for delete: the planned state is
@@ -976,11 +1166,24 @@ func (n *terraformPluginFrameworkExternalClient) Delete(ctx context.Context, _ x
 	start := time.Now()
 	applyResponse, err := n.server.ApplyResourceChange(ctx, applyRequest)
 	if err != nil {
-		return managed.ExternalDelete{}, errors.Wrap(err, "cannot delete resource")
+		return nil, errors.Wrap(err, "cannot delete resource")
 	}
 	metrics.ExternalAPITime.WithLabelValues("delete").Observe(time.Since(start).Seconds())
 	if fatalDiags := getFatalDiagnostics(applyResponse.Diagnostics); fatalDiags != nil {
-		return managed.ExternalDelete{}, errors.Wrap(fatalDiags, "resource deletion call returned error diags")
+		return nil, errors.Wrap(fatalDiags, "resource deletion call returned error diags")
+	}
+	return applyResponse, nil
+}
+
+func (n *terraformPluginFrameworkExternalClient) Delete(ctx context.Context, mg xpresource.Managed) (managed.ExternalDelete, error) {
+	n.logger.Debug("Deleting the external resource")
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalDelete{}, errors.New(errPlanOnly)
+	}
+
+	applyResponse, err := n.destroy(ctx, n.opTracker.GetFrameworkTFState())
+	if err != nil {
+		return managed.ExternalDelete{}, err
 	}
 	n.opTracker.SetFrameworkTFState(applyResponse.NewState)
 	if n.supportsIdentity() {
@@ -6,8 +6,11 @@ package controller
 
 import (
 	"context"
+	"maps"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
@@ -23,9 +26,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
+	upjetresource "github.com/crossplane/upjet/v2/pkg/resource"
 	"github.com/crossplane/upjet/v2/pkg/resource/fake"
 	"github.com/crossplane/upjet/v2/pkg/terraform"
 )
@@ -154,6 +159,10 @@ func prepareTPFExternalWithTestConfig(testConfig testConfiguration) *terraformPl
 		// metricRecorder:             nil,
 		opTracker: NewAsyncTracker(),
 		resource:  testConfig.r,
+		kube: &test.MockClient{
+			MockGet:    test.NewMockGetFn(nil),
+			MockUpdate: test.NewMockUpdateFn(nil),
+		},
 		server: &mockTPFProviderServer{
 			ReadResourceFn: func(ctx context.Context, request *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
 				if testConfig.capturedReadRequest != nil {
@@ -495,6 +504,167 @@ func TestTPFUpdate(t *testing.T) {
 	}
 }
 
+func TestTPFReplace(t *testing.T) {
+	errBoom := errors.New("boom")
+	type want struct {
+		calls        []string
+		events       []event.Reason
+		externalName string
+		replaced     string
+		err          error
+	}
+	cases := map[string]struct {
+		reason     string
+		policy     config.ReplacementPolicy
+		updateErr  error
+		destroyErr error
+		want       want
+	}{
+		"Replace": {
+			reason: "The external resource should be destroyed and then re-created, and the external-name of the replacement should be persisted.",
+			policy: config.ReplacementPolicyReplace,
+			want: want{
+				calls:        []string{"destroy", "create"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonDestroyedReplacedResource, reasonCreatedReplacement, reasonReplacedExternalResource},
+				externalName: "new-id",
+			},
+		},
+		"CreateBeforeDestroy": {
+			reason: "The replacement should be created and its external-name should be persisted before destroying the replaced external resource.",
+			policy: config.ReplacementPolicyCreateBeforeDestroy,
+			want: want{
+				calls:        []string{"create", "destroy"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonCreatedReplacement, reasonDestroyedReplacedResource, reasonReplacedExternalResource},
+				externalName: "new-id",
+			},
+		},
+		"PersistFailed": {
+			reason:    "Errors persisting the external-name of the replacement should be reported without destroying the replaced external resource, which stays recorded.",
+			policy:    config.ReplacementPolicyCreateBeforeDestroy,
+			updateErr: errBoom,
+			want: want{
+				calls:        []string{"create"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonCreatedReplacement},
+				externalName: "old-id",
+				replaced:     "old-id",
+				err:          errors.Wrapf(errors.Wrap(errBoom, errPersistAnnotations), errFmtPersistReplacement, "new-id"),
+			},
+		},
+		"DestroyFailed": {
+			reason:     "The replaced external resource should stay recorded if it cannot be destroyed after its replacement has been created.",
+			policy:     config.ReplacementPolicyCreateBeforeDestroy,
+			destroyErr: errBoom,
+			want: want{
+				calls:        []string{"create", "destroy"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonCreatedReplacement},
+				externalName: "new-id",
+				replaced:     "old-id",
+				err:          errors.Wrap(errors.Wrap(errBoom, "cannot delete resource"), "created the replacement but cannot destroy the replaced external resource"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			testConfig := testConfiguration{
+				r:   newMockBaseTPFResource(),
+				cfg: newBaseUpjetConfig(),
+				obj: newBaseObject(),
+				currentStateMap: map[string]any{
+					"name": "example",
+					"id":   "old-id",
+				},
+				plannedStateMap: map[string]any{
+					"name": "changed",
+				},
+				params: map[string]any{
+					"name": "changed",
+				},
+				newStateMap: map[string]any{
+					"name": "changed",
+					"id":   "new-id",
+				},
+			}
+			e := prepareTPFExternalWithTestConfig(testConfig)
+			configValue, err := tfValueFromMap(testConfig.params, e.resourceValueTerraformType)
+			if err != nil {
+				t.Fatalf("cannot prepare the config value: %v", err)
+			}
+			e.resourceTerraformConfigValue = configValue
+			replacedState, err := protov6DynamicValueFromMap(testConfig.currentStateMap, e.resourceValueTerraformType)
+			if err != nil {
+				t.Fatalf("cannot prepare the replaced state: %v", err)
+			}
+			e.opTracker.SetFrameworkTFState(replacedState)
+			e.planResponse.RequiresReplace = []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")}
+			newState, err := protov6DynamicValueFromMap(testConfig.newStateMap, e.resourceValueTerraformType)
+			if err != nil {
+				t.Fatalf("cannot prepare the new state: %v", err)
+			}
+			var calls []string
+			e.server.(*mockTPFProviderServer).ApplyResourceChangeFn = func(_ context.Context, request *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+				planned, err := request.PlannedState.Unmarshal(e.resourceValueTerraformType)
+				if err != nil {
+					return nil, err
+				}
+				if planned.IsNull() {
+					calls = append(calls, "destroy")
+					if tc.destroyErr != nil {
+						return nil, tc.destroyErr
+					}
+					return &tfprotov6.ApplyResourceChangeResponse{NewState: request.PlannedState}, nil
+				}
+				calls = append(calls, "create")
+				return &tfprotov6.ApplyResourceChangeResponse{NewState: newState}, nil
+			}
+			recorder := &driftTestRecorder{}
+			e.eventRecorder = recorder
+			persisted := map[string]string{meta.AnnotationKeyExternalName: "old-id"}
+			e.kube = &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.SetAnnotations(maps.Clone(persisted))
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					if tc.updateErr != nil && meta.GetExternalName(obj) == "new-id" {
+						return tc.updateErr
+					}
+					persisted = maps.Clone(obj.GetAnnotations())
+					return nil
+				},
+			}
+			o := testConfig.obj
+			o.SetAnnotations(map[string]string{upjetresource.AnnotationKeyReplacementPolicy: string(tc.policy)})
+			meta.SetExternalName(&o, "old-id")
+			_, err = e.Update(context.TODO(), &o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+			var events []event.Reason
+			for _, ev := range recorder.events {
+				events = append(events, ev.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, events); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, persisted[meta.AnnotationKeyExternalName]); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want persisted external-name, +got persisted external-name:\n%s", tc.reason, diff)
+			}
+			var replaced string
+			if rr, err := upjetresource.GetReplacedResource(&metav1.ObjectMeta{Annotations: persisted}); err != nil {
+				t.Fatalf("cannot get the persisted replaced resource: %v", err)
+			} else if rr != nil {
+				replaced = rr.ExternalName
+			}
+			if diff := cmp.Diff(tc.want.replaced, replaced); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want persisted replaced resource, +got persisted replaced resource:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestTPFDelete(t *testing.T) {
 
 	type want struct {
@@ -1053,16 +1223,19 @@ var _ tfprotov6.ProviderServer = &mockTPFProviderServer{}
 var _ provider.Provider = &mockTPFProvider{}
 
 type mockTPFProviderServer struct {
-	GetMetadataFn          func(ctx context.Context, request *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error)
-	GetProviderSchemaFn    func(ctx context.Context, request *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error)
-	ConfigureProviderFn    func(ctx context.Context, request *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error)
-	StopProviderFn         func(ctx context.Context, request *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error)
-	UpgradeResourceStateFn func(ctx context.Context, request *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error)
-	ReadResourceFn         func(ctx context.Context, request *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error)
-	PlanResourceChangeFn   func(ctx context.Context, request *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error)
-	ApplyResourceChangeFn  func(ctx context.Context, request *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error)
-	ImportResourceStateFn  func(ctx context.Context, request *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error)
-	ReadDataSourceFn       func(ctx context.Context, request *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error)
+	GetMetadataFn            func(ctx context.Context, request *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error)
+	GetProviderSchemaFn      func(ctx context.Context, request *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error)
+	ConfigureProviderFn      func(ctx context.Context, request *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error)
+	StopProviderFn           func(ctx context.Context, request *tfprotov6.StopProviderRequest) (*tfprotov6.StopProviderResponse, error)
+	UpgradeResourceStateFn   func(ctx context.Context, request *tfprotov6.UpgradeResourceStateRequest) (*tfprotov6.UpgradeResourceStateResponse, error)
+	ReadResourceFn           func(ctx context.Context, request *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error)
+	PlanResourceChangeFn     func(ctx context.Context, request *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error)
+	ApplyResourceChangeFn    func(ctx context.Context, request *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error)
+	ImportResourceStateFn    func(ctx context.Context, request *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error)
+	ReadDataSourceFn         func(ctx context.Context, request *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error)
+	OpenEphemeralResourceFn  func(ctx context.Context, request *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error)
+	RenewEphemeralResourceFn func(ctx context.Context, request *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error)
+	CloseEphemeralResourceFn func(ctx context.Context, request *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error)
 }
 
 func (m *mockTPFProviderServer) ValidateProviderConfig(ctx context.Context, request *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
@@ -1107,19 +1280,25 @@ func (m *mockTPFProviderServer) ValidateEphemeralResourceConfig(_ context.Contex
 	panic("implement me")
 }
 
-func (m *mockTPFProviderServer) OpenEphemeralResource(_ context.Context, _ *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mockTPFProviderServer) OpenEphemeralResource(ctx context.Context, request *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	if m.OpenEphemeralResourceFn == nil {
+		return &tfprotov6.OpenEphemeralResourceResponse{}, nil
+	}
+	return m.OpenEphemeralResourceFn(ctx, request)
 }
 
-func (m *mockTPFProviderServer) RenewEphemeralResource(_ context.Context, _ *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mockTPFProviderServer) RenewEphemeralResource(ctx context.Context, request *tfprotov6.RenewEphemeralResourceRequest) (*tfprotov6.RenewEphemeralResourceResponse, error) {
+	if m.RenewEphemeralResourceFn == nil {
+		return &tfprotov6.RenewEphemeralResourceResponse{}, nil
+	}
+	return m.RenewEphemeralResourceFn(ctx, request)
 }
 
-func (m *mockTPFProviderServer) CloseEphemeralResource(_ context.Context, _ *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mockTPFProviderServer) CloseEphemeralResource(ctx context.Context, request *tfprotov6.CloseEphemeralResourceRequest) (*tfprotov6.CloseEphemeralResourceResponse, error) {
+	if m.CloseEphemeralResourceFn == nil {
+		return &tfprotov6.CloseEphemeralResourceResponse{}, nil
+	}
+	return m.CloseEphemeralResourceFn(ctx, request)
 }
 
 func (m *mockTPFProviderServer) GetMetadata(_ context.Context, _ *tfprotov6.GetMetadataRequest) (*tfprotov6.GetMetadataResponse, error) {
@@ -1173,9 +1352,11 @@ func (m *mockTPFProviderServer) ImportResourceState(_ context.Context, _ *tfprot
 	panic("implement me")
 }
 
-func (m *mockTPFProviderServer) ReadDataSource(_ context.Context, _ *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
-	// TODO implement me
-	panic("implement me")
+func (m *mockTPFProviderServer) ReadDataSource(ctx context.Context, request *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	if m.ReadDataSourceFn == nil {
+		return &tfprotov6.ReadDataSourceResponse{}, nil
+	}
+	return m.ReadDataSourceFn(ctx, request)
 }
 
 type mockTPFProvider struct {
@@ -7,9 +7,11 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
@@ -38,6 +40,7 @@ type TerraformPluginSDKConnector struct {
 	config                      *config.Resource
 	logger                      logging.Logger
 	metricRecorder              *metrics.MetricRecorder
+	eventRecorder               event.Recorder
 	operationTrackerStore       *OperationTrackerStore
 	isManagementPoliciesEnabled bool
 }
@@ -60,6 +63,14 @@ func WithTerraformPluginSDKMetricRecorder(r *metrics.MetricRecorder) TerraformPl
 	}
 }
 
+// WithTerraformPluginSDKEventRecorder configures an event.Recorder for the
+// TerraformPluginSDKConnector, which records the drift events.
+func WithTerraformPluginSDKEventRecorder(r event.Recorder) TerraformPluginSDKOption {
+	return func(c *TerraformPluginSDKConnector) {
+		c.eventRecorder = r
+	}
+}
+
 // WithTerraformPluginSDKManagementPolicies configures whether the client should
 // handle management policies.
 func WithTerraformPluginSDKManagementPolicies(isManagementPoliciesEnabled bool) TerraformPluginSDKOption {
@@ -112,6 +123,7 @@ type Resource interface {
 
 type terraformPluginSDKExternal struct {
 	ts                          terraform.Setup
+	kube                        client.Client
 	resourceSchema              Resource
 	config                      *config.Resource
 	instanceDiff                *tf.InstanceDiff
@@ -119,6 +131,7 @@ type terraformPluginSDKExternal struct {
 	rawConfig                   cty.Value
 	logger                      logging.Logger
 	metricRecorder              *metrics.MetricRecorder
+	eventRecorder               event.Recorder
 	opTracker                   *AsyncTracker
 	isManagementPoliciesEnabled bool
 }
@@ -144,6 +157,9 @@ func getExtendedParameters(ctx context.Context, tr resource.Terraformed, externa
 	if err = resource.GetSensitiveParameters(ctx, &APISecretClient{kube: kube}, tr, params, tr.GetConnectionDetailsMapping()); err != nil {
 		return nil, errors.Wrap(err, "cannot store sensitive parameters into params")
 	}
+	if err = resource.GetValueFromParameters(ctx, kube, cfg, tr, params); err != nil {
+		return nil, errors.Wrap(err, "cannot store the parameters sourced from other objects into params")
+	}
 	cfg.ExternalName.SetIdentifierArgumentFn(params, externalName)
 	if cfg.TerraformConfigurationInjector != nil {
 		m, err := getJSONMap(tr)
@@ -290,6 +306,17 @@ func (c *TerraformPluginSDKConnector) Connect(ctx context.Context, mg xpresource
 		if copyParams {
 			tfState = copyParameters(tfState, params)
 		}
+		// the Terraform ID and the partial state returned by the provider
+		// before a failed or interrupted asynchronous create identify the
+		// external resource which may have been created.
+		id, partial, err := asyncCreateState(mg)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(tfState, partial)
+		if id != "" {
+			tfState["id"] = id
+		}
 
 		tfStateCtyValue, err := schema.JSONMapToStateValue(tfState, schemaBlock)
 		if err != nil {
@@ -317,12 +344,14 @@ func (c *TerraformPluginSDKConnector) Connect(ctx context.Context, mg xpresource
 
 	return &terraformPluginSDKExternal{
 		ts:                          ts,
+		kube:                        c.kube,
 		resourceSchema:              c.config.TerraformResource,
 		config:                      c.config,
 		params:                      params,
 		rawConfig:                   rawConfig,
 		logger:                      logger,
 		metricRecorder:              c.metricRecorder,
+		eventRecorder:               c.eventRecorder,
 		opTracker:                   opTracker,
 		isManagementPoliciesEnabled: c.isManagementPoliciesEnabled,
 	}, nil
@@ -504,6 +533,11 @@ func (n *terraformPluginSDKExternal) Observe(ctx context.Context, mg xpresource.
 			ResourceExists: false,
 		}, nil
 	}
+	if !resource.IsPlanOnly(mg) {
+		if err := n.destroyPendingReplaced(ctx, mg); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
 
 	start := time.Now()
 	newState, diag := n.resourceSchema.RefreshWithoutUpgrade(ctx, n.opTracker.GetTfState(), n.ts.Meta)
@@ -623,6 +657,7 @@ func (n *terraformPluginSDKExternal) Observe(ctx context.Context, mg xpresource.
 		if !specUpdateRequired {
 			resource.SetUpToDateCondition(mg, !hasDiff)
 		}
+		recordDrift(mg, n.eventRecorder, sdkDriftedFields(n.config, n.instanceDiff))
 		// check for an external-name change
 		if nameChanged, err := n.setExternalName(mg, stateValueMap); err != nil {
 			return managed.ExternalObservation{}, errors.Wrapf(err, "failed to set the external-name of the managed resource during observe")
@@ -631,12 +666,17 @@ func (n *terraformPluginSDKExternal) Observe(ctx context.Context, mg xpresource.
 		}
 	}
 
-	return managed.ExternalObservation{
+	o := managed.ExternalObservation{
 		ResourceExists:          resourceExists,
 		ResourceUpToDate:        !hasDiff,
 		ConnectionDetails:       connDetails,
 		ResourceLateInitialized: specUpdateRequired,
-	}, nil
+	}
+	if resource.IsPlanOnly(mg) {
+		recordPlan(mg, n.eventRecorder, plannedAction(mg, resourceExists, hasDiff, n.instanceDiff.RequiresNew()), sdkDriftedFields(n.config, n.instanceDiff))
+		o = planOnlyObservation(mg, o)
+	}
+	return o, nil
 }
 
 // sets the external-name on the MR. Returns `true`
@@ -656,8 +696,54 @@ func (n *terraformPluginSDKExternal) setExternalName(mg xpresource.Managed, stat
 	return oldName != newName, nil
 }
 
+// recoverExternalName tries to extract the external-name from the (partial)
+// TF state in the cache and sets it to the runtime MR object. Returns whether
+// the external-name is changed.
+func (n *terraformPluginSDKExternal) recoverExternalName(mg xpresource.Managed) (isChanged bool) {
+	s := n.opTracker.GetTfState()
+	if s == nil || s.ID == "" || meta.GetExternalName(mg) != "" {
+		return false
+	}
+	stateValueMap, _, err := n.fromInstanceStateToJSONMap(s)
+	if err != nil {
+		return false
+	}
+	changed, err := n.setExternalName(mg, stateValueMap)
+	if err != nil {
+		return false
+	}
+	return changed
+}
+
+// partialCreateState returns the Terraform ID and the state of the external
+// resource returned by the provider before a create call failed, if any.
+func (n *terraformPluginSDKExternal) partialCreateState() (string, map[string]any) {
+	s := n.opTracker.GetTfState()
+	if s == nil || s.ID == "" {
+		return "", nil
+	}
+	stateValueMap, _, err := n.fromInstanceStateToJSONMap(s)
+	if err != nil {
+		return s.ID, nil
+	}
+	return s.ID, stateValueMap
+}
+
+// sdkSensitiveAttribute returns a function reporting whether the given
+// top-level attribute of the Terraform resource is sensitive. The attributes
+// not found in the schema are reported as sensitive.
+func sdkSensitiveAttribute(cfg *config.Resource) func(string) bool {
+	return func(name string) bool {
+		s, ok := cfg.TerraformResource.Schema[name]
+		return !ok || s.Sensitive
+	}
+}
+
 func (n *terraformPluginSDKExternal) Create(ctx context.Context, mg xpresource.Managed) (managed.ExternalCreation, error) { //nolint:gocyclo // easier to follow as a unit
 	n.logger.Debug("Creating the external resource")
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalCreation{}, errors.New(errPlanOnly)
+	}
 	start := time.Now()
 	newState, diag := n.resourceSchema.Apply(ctx, n.opTracker.GetTfState(), n.instanceDiff, n.ts.Meta)
 	metrics.ExternalAPITime.WithLabelValues("create").Observe(time.Since(start).Seconds())
@@ -756,7 +842,113 @@ func (n *terraformPluginSDKExternal) assertNoForceNew() error {
 	return nil
 }
 
+// requiresNewDiff returns the instance diff of the attributes whose changes
+// require replacing the external resource.
+func (n *terraformPluginSDKExternal) requiresNewDiff() *tf.InstanceDiff {
+	d := tf.NewInstanceDiff()
+	for k, ad := range n.instanceDiff.Attributes {
+		if ad != nil && ad.RequiresNew {
+			d.Attributes[k] = ad
+		}
+	}
+	return d
+}
+
+// replace replaces the external resource by destroying it and then creating
+// its replacement, or by creating the replacement first if
+// createBeforeDestroy is set.
+func (n *terraformPluginSDKExternal) replace(ctx context.Context, mg xpresource.Managed, createBeforeDestroy bool, r replacementRecorder) (managed.ExternalUpdate, error) {
+	replaced := n.opTracker.GetTfState()
+	if replaced == nil {
+		return managed.ExternalUpdate{}, errors.New("cannot replace the external resource without its Terraform state")
+	}
+	if !createBeforeDestroy {
+		if err := n.destroyReplaced(ctx, replaced); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		r.normal(reasonDestroyedReplacedResource, "Destroyed the external resource being replaced")
+	} else if err := recordReplaced(ctx, n.kube, mg, sdkReplacedState{ID: replaced.ID, Attributes: replaced.Attributes}); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	s := &tf.InstanceState{
+		Meta:         replaced.Meta,
+		ProviderMeta: replaced.ProviderMeta,
+		RawPlan:      n.rawConfig,
+		RawConfig:    n.rawConfig,
+	}
+	d, err := n.getResourceDataDiff(mg.(resource.Terraformed), ctx, s, false)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot compute the instance diff of the replacement")
+	}
+	n.instanceDiff = d
+	n.opTracker.SetTfState(s)
+	c, err := n.Create(ctx, mg)
+	if err != nil {
+		if createBeforeDestroy {
+			n.opTracker.SetTfState(replaced)
+		}
+		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot create the replacement of the external resource")
+	}
+	r.normal(reasonCreatedReplacement, "Created the replacement of the external resource")
+	if err := persistReplacement(ctx, n.kube, mg); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrapf(err, errFmtPersistReplacement, meta.GetExternalName(mg))
+	}
+
+	if createBeforeDestroy {
+		if err := n.destroyReplaced(ctx, replaced); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, "created the replacement but cannot destroy the replaced external resource with the Terraform ID %q", replaced.ID)
+		}
+		r.normal(reasonDestroyedReplacedResource, "Destroyed the replaced external resource")
+		resource.RemoveReplacedResource(mg)
+		if err := persistAnnotations(ctx, n.kube, mg, resource.AnnotationKeyReplacedResource); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	r.normal(reasonReplacedExternalResource, "Replaced the external resource")
+	return managed.ExternalUpdate{ConnectionDetails: c.ConnectionDetails}, nil
+}
+
+// sdkReplacedState is the state of the external resource being replaced
+// recorded in the managed resource, which is sufficient for destroying it.
+type sdkReplacedState struct {
+	ID         string            `json:"id"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// destroyPendingReplaced destroys the replaced external resource whose
+// destruction failed after its replacement had been created.
+func (n *terraformPluginSDKExternal) destroyPendingReplaced(ctx context.Context, mg xpresource.Managed) error {
+	return destroyPendingReplaced(ctx, n.kube, replacementRecorder{mg: mg, recorder: n.eventRecorder}, func(state []byte) error {
+		rs := sdkReplacedState{}
+		if err := json.JSParser.Unmarshal(state, &rs); err != nil {
+			return errors.Wrap(err, "cannot unmarshal the state of the replaced external resource")
+		}
+		return n.destroyReplaced(ctx, &tf.InstanceState{ID: rs.ID, Attributes: rs.Attributes})
+	})
+}
+
+// destroyReplaced destroys the external resource with the given state, which
+// is being replaced.
+func (n *terraformPluginSDKExternal) destroyReplaced(ctx context.Context, s *tf.InstanceState) error {
+	d := tf.NewInstanceDiff()
+	d.Destroy = true
+	if n.instanceDiff != nil {
+		d.Meta = n.instanceDiff.Meta
+	}
+	start := time.Now()
+	_, diag := n.resourceSchema.Apply(ctx, s, d, n.ts.Meta)
+	metrics.ExternalAPITime.WithLabelValues("delete").Observe(time.Since(start).Seconds())
+	if diag != nil && diag.HasError() {
+		return errors.Errorf("failed to delete the replaced resource: %v", diag)
+	}
+	return nil
+}
+
 func (n *terraformPluginSDKExternal) Update(ctx context.Context, mg xpresource.Managed) (managed.ExternalUpdate, error) { //nolint:gocyclo
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalUpdate{}, errors.New(errPlanOnly)
+	}
 	if n.config.UpdateLoopPrevention != nil {
 		preventResult, err := n.config.UpdateLoopPrevention.UpdateLoopPreventionFunc(n.instanceDiff, mg)
 		if err != nil {
@@ -770,7 +962,15 @@ func (n *terraformPluginSDKExternal) Update(ctx context.Context, mg xpresource.M
 	n.logger.Debug("Updating the external resource")
 
 	if err := n.assertNoForceNew(); err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, "refuse to update the external resource because the following update requires replacing it")
+		policy, perr := resource.GetReplacementPolicy(mg, n.config)
+		if perr != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(perr, "cannot get the replacement policy")
+		}
+		r := replacementRecorder{mg: mg, recorder: n.eventRecorder}
+		if err := r.admitReplacement(policy, sdkDriftedFields(n.config, n.requiresNewDiff()), errors.Wrap(err, "refuse to update the external resource because the following update requires replacing it")); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		return n.replace(ctx, mg, policy == config.ReplacementPolicyCreateBeforeDestroy, r)
 	}
 
 	start := time.Now()
@@ -807,8 +1007,11 @@ func (n *terraformPluginSDKExternal) Update(ctx context.Context, mg xpresource.M
 	return managed.ExternalUpdate{}, nil
 }
 
-func (n *terraformPluginSDKExternal) Delete(ctx context.Context, _ xpresource.Managed) (managed.ExternalDelete, error) {
+func (n *terraformPluginSDKExternal) Delete(ctx context.Context, mg xpresource.Managed) (managed.ExternalDelete, error) {
 	n.logger.Debug("Deleting the external resource")
+	if resource.IsPlanOnly(mg) {
+		return managed.ExternalDelete{}, errors.New(errPlanOnly)
+	}
 	if n.instanceDiff == nil {
 		n.instanceDiff = tf.NewInstanceDiff()
 	}
@@ -6,10 +6,13 @@ package controller
 
 import (
 	"context"
+	"maps"
 	"testing"
 	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
@@ -18,10 +21,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tf "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
 	"github.com/crossplane/upjet/v2/pkg/resource/fake"
 	"github.com/crossplane/upjet/v2/pkg/terraform"
 )
@@ -100,6 +105,10 @@ func prepareTerraformPluginSDKExternal(r Resource, cfg *config.Resource) *terraf
 		rawConfig: rawConfig,
 		logger:    logTest,
 		opTracker: NewAsyncTracker(),
+		kube: &test.MockClient{
+			MockGet:    test.NewMockGetFn(nil),
+			MockUpdate: test.NewMockUpdateFn(nil),
+		},
 	}
 }
 
@@ -465,6 +474,249 @@ func TestTerraformPluginSDKUpdate(t *testing.T) {
 	}
 }
 
+func TestTerraformPluginSDKReplace(t *testing.T) {
+	replacementDiff := func() *tf.InstanceDiff {
+		return &tf.InstanceDiff{
+			Attributes: map[string]*tf.ResourceAttrDiff{
+				"name": {Old: "example", New: "changed", RequiresNew: true},
+			},
+		}
+	}
+	fields := sdkDriftedFields(cfg, replacementDiff())
+	hash := replacementHash(fields)
+	type want struct {
+		calls        []string
+		events       []event.Reason
+		externalName string
+		replaced     string
+		err          error
+	}
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		destroyErr  bool
+		want        want
+	}{
+		"Deny": {
+			reason: "The update should be refused if no replacement policy is set.",
+			want: want{
+				events: []event.Reason{reasonReplacementDenied},
+				err:    errors.Wrap(errors.Errorf("cannot change the value of the argument %q from %q to %q", "name", "example", "changed"), "refuse to update the external resource because the following update requires replacing it"),
+			},
+		},
+		"UnknownPolicy": {
+			reason:      "An unknown replacement policy should be reported.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: "Recreate"},
+			want: want{
+				err: errors.Wrap(errors.Errorf("unknown replacement policy %q", "Recreate"), "cannot get the replacement policy"),
+			},
+		},
+		"Replace": {
+			reason:      "The external resource should be destroyed and then re-created.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyReplace)},
+			want: want{
+				calls:        []string{"destroy", "create"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonDestroyedReplacedResource, reasonCreatedReplacement, reasonReplacedExternalResource},
+				externalName: "new-id",
+			},
+		},
+		"CreateBeforeDestroy": {
+			reason:      "The replacement should be created before destroying the replaced external resource.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyCreateBeforeDestroy)},
+			want: want{
+				calls:        []string{"create", "destroy"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonCreatedReplacement, reasonDestroyedReplacedResource, reasonReplacedExternalResource},
+				externalName: "new-id",
+			},
+		},
+		"DestroyFailed": {
+			reason:      "The replaced external resource should stay recorded if it cannot be destroyed after its replacement has been created.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyCreateBeforeDestroy)},
+			destroyErr:  true,
+			want: want{
+				calls:        []string{"create", "destroy"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonCreatedReplacement},
+				externalName: "new-id",
+				replaced:     "old-id",
+				err:          errors.Wrapf(errors.Errorf("failed to delete the replaced resource: %v", diag.Errorf("boom")), "created the replacement but cannot destroy the replaced external resource with the Terraform ID %q", "old-id"),
+			},
+		},
+		"NotApproved": {
+			reason:      "The replacement should be refused if it has not been approved.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyRequireApproval), resource.AnnotationKeyApprovedReplacement: "stale"},
+			want: want{
+				events: []event.Reason{reasonReplacementApprovalRequired},
+				err:    errors.Errorf(errFmtReplacementNotApproved, resource.AnnotationKeyApprovedReplacement, hash, fieldListMessage(fields)),
+			},
+		},
+		"Approved": {
+			reason:      "The approved replacement should destroy and then re-create the external resource.",
+			annotations: map[string]string{resource.AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyRequireApproval), resource.AnnotationKeyApprovedReplacement: hash},
+			want: want{
+				calls:        []string{"destroy", "create"},
+				events:       []event.Reason{reasonReplacingExternalResource, reasonDestroyedReplacedResource, reasonCreatedReplacement, reasonReplacedExternalResource},
+				externalName: "new-id",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			r := mockResource{
+				ApplyFn: func(_ context.Context, s *tf.InstanceState, d *tf.InstanceDiff, _ interface{}) (*tf.InstanceState, diag.Diagnostics) {
+					if d.Destroy {
+						calls = append(calls, "destroy")
+						if tc.destroyErr {
+							return nil, diag.Errorf("boom")
+						}
+						return nil, nil
+					}
+					calls = append(calls, "create")
+					return &tf.InstanceState{ID: "new-id"}, nil
+				},
+			}
+			recorder := &driftTestRecorder{}
+			e := prepareTerraformPluginSDKExternal(r, cfg)
+			e.eventRecorder = recorder
+			persisted := maps.Clone(tc.annotations)
+			if persisted == nil {
+				persisted = map[string]string{}
+			}
+			persisted[meta.AnnotationKeyExternalName] = "old-id"
+			e.kube = &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.SetAnnotations(maps.Clone(persisted))
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					persisted = maps.Clone(obj.GetAnnotations())
+					return nil
+				},
+			}
+			e.instanceDiff = replacementDiff()
+			e.opTracker.SetTfState(&tf.InstanceState{ID: "old-id", Attributes: map[string]string{"id": "old-id", "name": "example"}})
+			o := obj
+			o.SetAnnotations(maps.Clone(persisted))
+			_, err := e.Update(t.Context(), &o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+			var events []event.Reason
+			for _, ev := range recorder.events {
+				events = append(events, ev.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, events); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+			want := tc.want.externalName
+			if want == "" {
+				want = "old-id"
+			}
+			if diff := cmp.Diff(want, persisted[meta.AnnotationKeyExternalName]); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want persisted external-name, +got persisted external-name:\n%s", tc.reason, diff)
+			}
+			var replaced string
+			if rr, err := resource.GetReplacedResource(&metav1.ObjectMeta{Annotations: persisted}); err != nil {
+				t.Fatalf("cannot get the persisted replaced resource: %v", err)
+			} else if rr != nil {
+				replaced = rr.ExternalName
+			}
+			if diff := cmp.Diff(tc.want.replaced, replaced); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want persisted replaced resource, +got persisted replaced resource:\n%s", tc.reason, diff)
+			}
+			if _, ok := persisted[resource.AnnotationKeyApprovedReplacement]; ok && tc.want.externalName != "" {
+				t.Errorf("\n%s\nUpdate(...): the approval of the replacement should be removed with the external-name of the replacement", tc.reason)
+			}
+		})
+	}
+}
+
+func TestTerraformPluginSDKDestroyPendingReplaced(t *testing.T) {
+	type want struct {
+		calls    []string
+		replaced bool
+		err      error
+	}
+	cases := map[string]struct {
+		reason     string
+		record     *resource.ReplacedResource
+		destroyErr bool
+		want       want
+	}{
+		"NoRecord": {
+			reason: "Nothing should be destroyed if there is no replaced external resource recorded.",
+		},
+		"Pending": {
+			reason: "The recorded replaced external resource should be destroyed and its record should be removed.",
+			record: &resource.ReplacedResource{ExternalName: "old-id", State: []byte(`{"id":"old-id","attributes":{"id":"old-id"}}`)},
+			want: want{
+				calls: []string{"destroy old-id"},
+			},
+		},
+		"NotReplaced": {
+			reason: "A record with the current external-name should be removed without destroying the external resource.",
+			record: &resource.ReplacedResource{ExternalName: "new-id", State: []byte(`{"id":"new-id"}`)},
+		},
+		"DestroyFailed": {
+			reason:     "The record should be kept if the replaced external resource cannot be destroyed.",
+			record:     &resource.ReplacedResource{ExternalName: "old-id", State: []byte(`{"id":"old-id"}`)},
+			destroyErr: true,
+			want: want{
+				calls:    []string{"destroy old-id"},
+				replaced: true,
+				err:      errors.Wrapf(errors.Errorf("failed to delete the replaced resource: %v", diag.Errorf("boom")), errFmtDestroyReplaced, "old-id"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			r := mockResource{
+				ApplyFn: func(_ context.Context, s *tf.InstanceState, d *tf.InstanceDiff, _ interface{}) (*tf.InstanceState, diag.Diagnostics) {
+					calls = append(calls, "destroy "+s.ID)
+					if tc.destroyErr {
+						return nil, diag.Errorf("boom")
+					}
+					return nil, nil
+				},
+			}
+			e := prepareTerraformPluginSDKExternal(r, cfg)
+			o := obj
+			o.SetAnnotations(nil)
+			meta.SetExternalName(&o, "new-id")
+			if tc.record != nil {
+				if err := resource.SetReplacedResource(&o, *tc.record); err != nil {
+					t.Fatalf("cannot record the replaced resource: %v", err)
+				}
+			}
+			persisted := maps.Clone(o.GetAnnotations())
+			e.kube = &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.SetAnnotations(maps.Clone(persisted))
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					persisted = maps.Clone(obj.GetAnnotations())
+					return nil
+				},
+			}
+			err := e.destroyPendingReplaced(t.Context(), &o)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ndestroyPendingReplaced(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\ndestroyPendingReplaced(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+			if _, ok := persisted[resource.AnnotationKeyReplacedResource]; ok != tc.want.replaced {
+				t.Errorf("\n%s\ndestroyPendingReplaced(...): -want persisted record %t, +got %t", tc.reason, tc.want.replaced, ok)
+			}
+		})
+	}
+}
+
 func TestTerraformPluginSDKDelete(t *testing.T) {
 	type args struct {
 		r   Resource
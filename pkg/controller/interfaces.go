@@ -11,6 +11,7 @@ import (
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/resource/json"
 	"github.com/crossplane/upjet/v2/pkg/terraform"
 )
 
@@ -20,13 +21,14 @@ import (
 
 // Workspace is the set of methods that are needed for the controller to work.
 type Workspace interface {
-	ApplyAsync(terraform.CallbackFn) error
+	ApplyAsync(terraform.CallbackFn, ...terraform.ApplyAsyncOption) error
 	Apply(context.Context) (terraform.ApplyResult, error)
 	DestroyAsync(terraform.CallbackFn) error
 	Destroy(context.Context) error
 	Refresh(context.Context) (terraform.RefreshResult, error)
 	Import(context.Context, resource.Terraformed) (terraform.ImportResult, error)
 	Plan(context.Context) (terraform.PlanResult, error)
+	State() (*json.StateV4, error)
 }
 
 // ProviderSharer shares a native provider process with the receiver.
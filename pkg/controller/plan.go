@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/upjet/v2/pkg/resource"
+)
+
+const (
+	errPlanOnly = "refuse to change the external resource in the plan-only reconciliation mode"
+
+	reasonChangesPlanned event.Reason = "ChangesPlanned"
+)
+
+// plannedAction returns the action planned for the external resource as the
+// reason of the Planned condition.
+func plannedAction(mg xpresource.Managed, exists, hasDiff, replace bool) xpv2.ConditionReason {
+	switch {
+	case meta.WasDeleted(mg):
+		if !exists {
+			return resource.ReasonNoChanges
+		}
+		return resource.ReasonPlannedDelete
+	case !exists:
+		return resource.ReasonPlannedCreate
+	case replace:
+		return resource.ReasonPlannedReplace
+	case hasDiff:
+		return resource.ReasonPlannedUpdate
+	default:
+		return resource.ReasonNoChanges
+	}
+}
+
+// planMessage returns the message describing the planned action and listing
+// at most maxDriftedFields of the fields to be changed.
+func planMessage(action xpv2.ConditionReason, fields []driftedField) string {
+	msg := "Terraform plans to " + strings.ToLower(string(action)) + " the external resource"
+	if len(fields) == 0 {
+		return msg
+	}
+	return msg + ". The following fields would be changed: " + fieldListMessage(fields)
+}
+
+// recordPlan sets the Planned condition of the managed resource with the
+// planned action and the fields to be changed, and records an event when the
+// planned changes change. The fields are not reported for a planned deletion.
+func recordPlan(mg xpresource.Managed, recorder event.Recorder, action xpv2.ConditionReason, fields []driftedField) {
+	prev := mg.GetCondition(resource.TypePlanned)
+	if action == resource.ReasonNoChanges {
+		if prev.Status != corev1.ConditionFalse {
+			mg.SetConditions(resource.NoChangesPlannedCondition())
+		}
+		return
+	}
+	if action == resource.ReasonPlannedDelete {
+		fields = nil
+	}
+	msg := planMessage(action, fields)
+	if prev.Status == corev1.ConditionTrue && prev.Reason == action && prev.Message == msg {
+		return
+	}
+	mg.SetConditions(resource.PlannedCondition(action, msg))
+	if recorder != nil {
+		recorder.Event(mg, event.Normal(reasonChangesPlanned, msg))
+	}
+}
+
+// planOnlyObservation returns the observation reporting an up-to-date
+// external resource so that the managed reconciler does not create or update
+// it in the plan-only reconciliation mode. A non-existent resource is still
+// reported as such if the managed resource is being deleted so that its
+// finalizer can be removed. An existing resource is reported as such, and the
+// deletion of the managed resource is refused by the Delete call with
+// errPlanOnly, which keeps the finalizer and retries the deletion with
+// backoff until the plan-only mode is disabled.
+func planOnlyObservation(mg xpresource.Managed, o managed.ExternalObservation) managed.ExternalObservation {
+	if meta.WasDeleted(mg) && !o.ResourceExists {
+		return o
+	}
+	o.ResourceExists = true
+	o.ResourceUpToDate = true
+	return o
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+func TestRecordPlan(t *testing.T) {
+	fields := []driftedField{
+		{path: "spec.forProvider.tags[env]", observed: `"dev"`, desired: `"prod"`},
+		{path: "spec.forProvider.instanceType", observed: `"t3.large"`, desired: `"t3.micro"`},
+	}
+	updateMsg := `Terraform plans to update the external resource. The following fields would be changed: spec.forProvider.instanceType (observed: "t3.large", desired: "t3.micro"), spec.forProvider.tags[env] (observed: "dev", desired: "prod")`
+
+	type args struct {
+		deleted bool
+		exists  bool
+		hasDiff bool
+		replace bool
+		prev    *xpv2.Condition
+	}
+	type want struct {
+		status corev1.ConditionStatus
+		reason xpv2.ConditionReason
+		msg    string
+		events int
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Create": {
+			reason: "A non-existent resource should be planned to be created.",
+			args:   args{hasDiff: true},
+			want:   want{status: corev1.ConditionTrue, reason: resource.ReasonPlannedCreate, msg: "Terraform plans to create the external resource. The following fields would be changed: " + fieldListMessage(fields), events: 1},
+		},
+		"Update": {
+			reason: "An existing resource with a diff should be planned to be updated.",
+			args:   args{exists: true, hasDiff: true},
+			want:   want{status: corev1.ConditionTrue, reason: resource.ReasonPlannedUpdate, msg: updateMsg, events: 1},
+		},
+		"SameUpdate": {
+			reason: "No event should be recorded if the planned changes have not changed.",
+			args:   args{exists: true, hasDiff: true, prev: planned(resource.ReasonPlannedUpdate, updateMsg)},
+			want:   want{status: corev1.ConditionTrue, reason: resource.ReasonPlannedUpdate, msg: updateMsg},
+		},
+		"Replace": {
+			reason: "An existing resource with a diff requiring a new resource should be planned to be replaced.",
+			args:   args{exists: true, hasDiff: true, replace: true},
+			want:   want{status: corev1.ConditionTrue, reason: resource.ReasonPlannedReplace, msg: "Terraform plans to replace the external resource. The following fields would be changed: " + fieldListMessage(fields), events: 1},
+		},
+		"Delete": {
+			reason: "An existing resource should be planned to be deleted without the changed fields if the managed resource is being deleted.",
+			args:   args{deleted: true, exists: true, hasDiff: true},
+			want:   want{status: corev1.ConditionTrue, reason: resource.ReasonPlannedDelete, msg: "Terraform plans to delete the external resource", events: 1},
+		},
+		"NoChanges": {
+			reason: "An up-to-date resource should have no planned changes.",
+			args:   args{exists: true, prev: planned(resource.ReasonPlannedUpdate, updateMsg)},
+			want:   want{status: corev1.ConditionFalse, reason: resource.ReasonNoChanges},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := fake.NewTerraformed()
+			if tc.args.deleted {
+				now := metav1.Now()
+				mg.SetDeletionTimestamp(&now)
+			}
+			if tc.args.prev != nil {
+				mg.SetConditions(*tc.args.prev)
+			}
+			var planFields []driftedField
+			if tc.args.hasDiff {
+				planFields = append(planFields, fields...)
+			}
+			r := &driftTestRecorder{}
+			recordPlan(mg, r, plannedAction(mg, tc.args.exists, tc.args.hasDiff, tc.args.replace), planFields)
+			c := mg.GetCondition(resource.TypePlanned)
+			got := want{status: c.Status, reason: c.Reason, msg: c.Message, events: len(r.events)}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nrecordPlan(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
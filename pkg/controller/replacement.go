@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+)
+
+const (
+	errFmtReplacementNotApproved = "refuse to replace the external resource because the replacement has not been approved, annotate the managed resource with %s=%s to approve replacing it: %s"
+	errFmtPersistReplacement     = "created the replacement of the external resource with the external-name %q but cannot record it"
+	errFmtDestroyReplaced        = "created the replacement but cannot destroy the replaced external resource with the external-name %q"
+	errPersistAnnotations        = "cannot persist the annotations of the managed resource"
+	errRecordReplaced            = "cannot record the external resource being replaced"
+
+	reasonReplacementDenied           event.Reason = "ReplacementDenied"
+	reasonReplacementApprovalRequired event.Reason = "ReplacementApprovalRequired"
+	reasonReplacingExternalResource   event.Reason = "ReplacingExternalResource"
+	reasonCreatedReplacement          event.Reason = "CreatedReplacement"
+	reasonDestroyedReplacedResource   event.Reason = "DestroyedReplacedResource"
+	reasonReplacedExternalResource    event.Reason = "ReplacedExternalResource"
+)
+
+// replacementHash returns the hash of the planned replacement identified by
+// the fields requiring the replacement, which is used for approving it. The
+// full planned values of the fields, including the sensitive ones, are
+// hashed so that an approval does not apply to a different replacement.
+func replacementHash(fields []driftedField) string {
+	l := make([]string, 0, len(fields))
+	for _, f := range fields {
+		l = append(l, fmt.Sprintf("%s %q", f.path, f.values))
+	}
+	slices.Sort(l)
+	h := sha256.Sum256([]byte(strings.Join(l, "\n")))
+	return hex.EncodeToString(h[:8])
+}
+
+// replacementRecorder records the events for the steps of the replacement of
+// an external resource.
+type replacementRecorder struct {
+	mg       xpresource.Managed
+	recorder event.Recorder
+}
+
+func (r replacementRecorder) normal(reason event.Reason, msg string) {
+	if r.recorder != nil {
+		r.recorder.Event(r.mg, event.Normal(reason, msg))
+	}
+}
+
+func (r replacementRecorder) warning(reason event.Reason, err error) {
+	if r.recorder != nil {
+		r.recorder.Event(r.mg, event.Warning(reason, err))
+	}
+}
+
+// admitReplacement returns an error if the replacement policy does not allow
+// the replacement of the external resource due to the changes in the given
+// fields. A replacement under the ReplacementPolicyRequireApproval policy is
+// only allowed if the managed resource has been annotated with the hash of
+// the planned replacement.
+func (r replacementRecorder) admitReplacement(policy config.ReplacementPolicy, fields []driftedField, denied error) error {
+	switch policy {
+	case config.ReplacementPolicyReplace, config.ReplacementPolicyCreateBeforeDestroy:
+	case config.ReplacementPolicyRequireApproval:
+		h := replacementHash(fields)
+		if r.mg.GetAnnotations()[resource.AnnotationKeyApprovedReplacement] != h {
+			err := errors.Errorf(errFmtReplacementNotApproved, resource.AnnotationKeyApprovedReplacement, h, fieldListMessage(fields))
+			r.warning(reasonReplacementApprovalRequired, err)
+			return err
+		}
+	default:
+		r.warning(reasonReplacementDenied, denied)
+		return denied
+	}
+	r.normal(reasonReplacingExternalResource, fmt.Sprintf("Replacing the external resource with the %s policy because the following fields require replacement: %s", policy, fieldListMessage(fields)))
+	return nil
+}
+
+// persistAnnotations persists the given annotations of the managed resource
+// as they are in memory, removing the ones the managed resource does not
+// have. The external-name of the replacement of the external resource is
+// persisted in this way since the managed reconciler does not persist it
+// after an update. The resource version of the managed resource is updated
+// so that its status can still be persisted by the managed reconciler.
+func persistAnnotations(ctx context.Context, kube client.Client, mg xpresource.Managed, keys ...string) error {
+	want := mg.GetAnnotations()
+	latest := mg.DeepCopyObject().(xpresource.Managed)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kube.Get(ctx, client.ObjectKeyFromObject(mg), latest); err != nil {
+			return err
+		}
+		changed := false
+		for _, k := range keys {
+			v, ok := want[k]
+			cur, curOK := latest.GetAnnotations()[k]
+			switch {
+			case ok && (!curOK || cur != v):
+				meta.AddAnnotations(latest, map[string]string{k: v})
+				changed = true
+			case !ok && curOK:
+				meta.RemoveAnnotations(latest, k)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return kube.Update(ctx, latest)
+	})
+	if err != nil {
+		return errors.Wrap(err, errPersistAnnotations)
+	}
+	mg.SetResourceVersion(latest.GetResourceVersion())
+	return nil
+}
+
+// persistReplacement persists the external-name of the replacement of the
+// external resource and the record of the replaced external resource. The
+// approval of the replacement is removed in the same update so that it does
+// not approve a later replacement.
+func persistReplacement(ctx context.Context, kube client.Client, mg xpresource.Managed) error {
+	meta.RemoveAnnotations(mg, resource.AnnotationKeyApprovedReplacement)
+	return persistAnnotations(ctx, kube, mg, meta.AnnotationKeyExternalName, resource.AnnotationKeyReplacedResource, resource.AnnotationKeyApprovedReplacement)
+}
+
+// recordReplaced records the external resource with the given serialized
+// Terraform state as being replaced in the managed resource before its
+// replacement is created, so that it can still be destroyed if its
+// destruction fails after the external-name of the replacement has been
+// persisted.
+func recordReplaced(ctx context.Context, kube client.Client, mg xpresource.Managed, state any) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrap(err, errRecordReplaced)
+	}
+	if err := resource.SetReplacedResource(mg, resource.ReplacedResource{ExternalName: meta.GetExternalName(mg), State: raw}); err != nil {
+		return errors.Wrap(err, errRecordReplaced)
+	}
+	return errors.Wrap(persistAnnotations(ctx, kube, mg, resource.AnnotationKeyReplacedResource), errRecordReplaced)
+}
+
+// destroyPendingReplaced destroys the replaced external resource recorded in
+// the managed resource, whose destruction failed after its replacement had
+// been created, and removes the record once it has been destroyed. A record
+// with the current external-name of the managed resource belongs to a
+// replacement that has not been created, and is removed without destroying
+// anything. The destroy function is called with the serialized Terraform
+// state of the replaced external resource.
+func destroyPendingReplaced(ctx context.Context, kube client.Client, r replacementRecorder, destroy func(state []byte) error) error {
+	rr, err := resource.GetReplacedResource(r.mg)
+	if err != nil || rr == nil {
+		return err
+	}
+	if rr.ExternalName != meta.GetExternalName(r.mg) {
+		if err := destroy(rr.State); err != nil {
+			return errors.Wrapf(err, errFmtDestroyReplaced, rr.ExternalName)
+		}
+		r.normal(reasonDestroyedReplacedResource, "Destroyed the replaced external resource")
+	}
+	resource.RemoveReplacedResource(r.mg)
+	return persistAnnotations(ctx, kube, r.mg, resource.AnnotationKeyReplacedResource)
+}
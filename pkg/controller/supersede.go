@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+
+	"github.com/crossplane/upjet/v2/pkg/metrics"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+)
+
+const (
+	reasonSupersededAsyncOperation event.Reason = "SupersededAsyncOperation"
+
+	supersedeCauseGeneration = "generation"
+	supersedeCauseDeletion   = "deletion"
+)
+
+// supersedeOperation cancels the running asynchronous operation if it has
+// been superseded by a newer generation or by the deletion of the managed
+// resource, so that the superseding operation can be scheduled without
+// waiting for the superseded one to run to completion. Only the cancelable
+// operations, i.e., the updates, are superseded. The context of the operation
+// is canceled, which may or may not be honored by the Terraform provider,
+// or which kills the Terraform CLI process of the operation.
+func supersedeOperation(mg xpresource.Managed, op *terraform.Operation, recorder event.Recorder) {
+	var cause, by string
+	switch {
+	case meta.WasDeleted(mg):
+		cause, by = supersedeCauseDeletion, "the deletion of the managed resource"
+	case mg.GetGeneration() != op.Generation():
+		cause, by = supersedeCauseGeneration, fmt.Sprintf("generation %d of the managed resource", mg.GetGeneration())
+	default:
+		return
+	}
+	opType, gen := op.Type, op.Generation()
+	if !op.Supersede() {
+		return
+	}
+	gvk := mg.GetObjectKind().GroupVersionKind()
+	metrics.SupersededOperations.WithLabelValues(gvk.Group, gvk.Version, gvk.Kind, opType, cause).Inc()
+	recordEvent(mg, recorder, event.Normal(reasonSupersededAsyncOperation, fmt.Sprintf("Canceled the asynchronous %s operation started for generation %d, which has been superseded by %s", opType, gen, by)))
+}
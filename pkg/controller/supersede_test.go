@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+	"github.com/crossplane/upjet/v2/pkg/terraform"
+)
+
+func TestSupersedeOperation(t *testing.T) {
+	type args struct {
+		cancelable bool
+		generation int64
+		deleted    bool
+	}
+	type want struct {
+		canceled bool
+		events   []event.Reason
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"SameGeneration": {
+			reason: "An operation should not be superseded by the generation it has been started for.",
+			args:   args{cancelable: true, generation: 1},
+		},
+		"NewGeneration": {
+			reason: "An operation should be superseded by a new generation.",
+			args:   args{cancelable: true, generation: 2},
+			want:   want{canceled: true, events: []event.Reason{reasonSupersededAsyncOperation}},
+		},
+		"Deleted": {
+			reason: "An operation should be superseded by the deletion of the managed resource.",
+			args:   args{cancelable: true, generation: 1, deleted: true},
+			want:   want{canceled: true, events: []event.Reason{reasonSupersededAsyncOperation}},
+		},
+		"NotCancelable": {
+			reason: "An operation that is not cancelable should not be superseded.",
+			args:   args{generation: 2},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			op := &terraform.Operation{}
+			op.MarkStart("update")
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			if tc.args.cancelable {
+				op.SetCancel(1, cancel)
+			}
+			mg := fake.NewTerraformed()
+			mg.SetGeneration(tc.args.generation)
+			if tc.args.deleted {
+				now := metav1.Now()
+				mg.SetDeletionTimestamp(&now)
+			}
+			r := &driftTestRecorder{}
+			supersedeOperation(mg, op, r)
+			if diff := cmp.Diff(tc.want.canceled, ctx.Err() != nil); diff != "" {
+				t.Errorf("\n%s\nsupersedeOperation(...): -want canceled, +got canceled:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.canceled, op.IsSuperseded()); diff != "" {
+				t.Errorf("\n%s\nsupersedeOperation(...): -want superseded, +got superseded:\n%s", tc.reason, diff)
+			}
+			var reasons []event.Reason
+			for _, ev := range r.events {
+				reasons = append(reasons, ev.Reason)
+			}
+			if diff := cmp.Diff(tc.want.events, reasons); diff != "" {
+				t.Errorf("\n%s\nsupersedeOperation(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
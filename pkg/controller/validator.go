@@ -0,0 +1,257 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfdiag "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	tf "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource"
+	tferrors "github.com/crossplane/upjet/v2/pkg/terraform/errors"
+)
+
+const (
+	// summaryMissingRequiredArgument is the summary of the Terraform plugin
+	// SDK diagnostics reporting the missing required arguments.
+	summaryMissingRequiredArgument = "Missing required argument"
+	// summaryMissingRequiredAttribute is the summary of the Terraform Plugin
+	// Framework diagnostics reporting the missing required attributes.
+	summaryMissingRequiredAttribute = "Missing Configuration for Required Attribute"
+)
+
+// detailsMissingArguments are the details of the Terraform diagnostics
+// reporting the missing arguments of the ExactlyOneOf, AtLeastOneOf and
+// RequiredWith constraints and their Plugin Framework counterparts.
+var detailsMissingArguments = []string{"must be specified", "must be configured"}
+
+// SchemaValidator is a validating admission webhook that runs the
+// validations of the Terraform schema of a resource against the spec of its
+// managed resources on create and update. The spec is converted to the
+// Terraform configuration as it's done before reconciling, and the SDKv2
+// resources are validated with their ValidateFunc and ValidateDiagFunc
+// validators while the Plugin Framework resources are validated via the
+// ValidateResourceConfig RPC. As the references, the sensitive arguments and
+// the values sourced from other objects are only resolved while reconciling,
+// missing arguments are not reported.
+type SchemaValidator[T resource.Terraformed] struct {
+	config *config.Resource
+}
+
+// NewSchemaValidator returns a new SchemaValidator for the managed resources
+// of the specified resource configuration.
+func NewSchemaValidator[T resource.Terraformed](cfg *config.Resource) *SchemaValidator[T] {
+	return &SchemaValidator[T]{config: cfg}
+}
+
+// ValidateCreate validates the spec of the managed resource being created.
+func (v *SchemaValidator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	return nil, v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates the spec of the managed resource being updated.
+// The managed resources being deleted and the updates not changing the
+// parameters, such as the ones of the annotations by the controller, are
+// not validated, so that a previously admitted resource is not stuck.
+func (v *SchemaValidator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	if newObj.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+	oldParams, err := oldObj.GetMergedParameters(true)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get the parameters of the old object")
+	}
+	newParams, err := newObj.GetMergedParameters(true)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get the parameters of the new object")
+	}
+	if reflect.DeepEqual(oldParams, newParams) {
+		return nil, nil
+	}
+	return nil, v.validate(ctx, newObj)
+}
+
+// ValidateDelete does not validate the managed resources being deleted.
+func (v *SchemaValidator[T]) ValidateDelete(_ context.Context, _ T) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *SchemaValidator[T]) validate(ctx context.Context, tr T) error {
+	params, err := tr.GetMergedParameters(true)
+	if err != nil {
+		return errors.Wrap(err, "cannot get merged parameters")
+	}
+	params, err = v.config.ApplyTFConversions(params, config.ToTerraform)
+	if err != nil {
+		return errors.Wrap(err, "cannot apply tf conversions")
+	}
+	forProvider, err := tr.GetParameters()
+	if err != nil {
+		return errors.Wrap(err, "cannot get parameters")
+	}
+	var errs field.ErrorList
+	switch {
+	case v.config.TerraformPluginFrameworkResource != nil:
+		errs, err = v.validateFramework(ctx, params, forProvider)
+	case v.config.TerraformResource != nil:
+		errs = v.validateSDK(params, forProvider)
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot validate the Terraform configuration")
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return kerrors.NewInvalid(tr.GetObjectKind().GroupVersionKind().GroupKind(), tr.GetName(), errs)
+}
+
+func (v *SchemaValidator[T]) validateSDK(params, forProvider map[string]any) field.ErrorList {
+	var errs field.ErrorList
+	for _, d := range v.config.TerraformResource.Validate(tf.NewResourceConfigRaw(params)) {
+		if d.Severity != tfdiag.Error || isMissingArgumentDiagnostic(d.Summary, d.Detail) {
+			continue
+		}
+		var steps []any
+		for _, s := range d.AttributePath {
+			switch t := s.(type) {
+			case cty.GetAttrStep:
+				steps = append(steps, t.Name)
+			case cty.IndexStep:
+				switch {
+				case t.Key.Type() == cty.String:
+					steps = append(steps, t.Key.AsString())
+				case t.Key.Type() == cty.Number:
+					i, _ := t.Key.AsBigFloat().Int64()
+					steps = append(steps, int(i))
+				}
+			}
+		}
+		errs = append(errs, v.fieldError(steps, forProvider, d.Summary, d.Detail))
+	}
+	return errs
+}
+
+func (v *SchemaValidator[T]) validateFramework(ctx context.Context, params, forProvider map[string]any) (field.ErrorList, error) {
+	res := v.config.TerraformPluginFrameworkResource
+	schemaResp := &fwresource.SchemaResponse{}
+	res.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, tferrors.FrameworkDiagnosticsError("could not retrieve resource schema", schemaResp.Diagnostics)
+	}
+	tfConfig, err := protov6DynamicValueFromMap(params, schemaResp.Schema.Type().TerraformType(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot construct dynamic value for TF resource config")
+	}
+	// the resource is served by a provider of its own, which is neither
+	// configured nor given any provider data, as only the configuration of
+	// the resource is validated.
+	providerTypeName, _, _ := strings.Cut(v.config.Name, "_")
+	metadataResp := &fwresource.MetadataResponse{}
+	res.Metadata(ctx, fwresource.MetadataRequest{ProviderTypeName: providerTypeName}, metadataResp)
+	server := providerserver.NewProtocol6(&validationProvider{typeName: providerTypeName, resource: res})()
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: metadataResp.TypeName,
+		Config:   tfConfig,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot validate the resource config")
+	}
+	var errs field.ErrorList
+	for _, d := range resp.Diagnostics {
+		if d.Severity != tfprotov6.DiagnosticSeverityError || isMissingArgumentDiagnostic(d.Summary, d.Detail) {
+			continue
+		}
+		var steps []any
+		if d.Attribute != nil {
+			for _, s := range d.Attribute.Steps() {
+				switch t := s.(type) {
+				case tftypes.AttributeName:
+					steps = append(steps, string(t))
+				case tftypes.ElementKeyString:
+					steps = append(steps, string(t))
+				case tftypes.ElementKeyInt:
+					steps = append(steps, int(t))
+				}
+			}
+		}
+		errs = append(errs, v.fieldError(steps, forProvider, d.Summary, d.Detail))
+	}
+	return errs, nil
+}
+
+// isMissingArgumentDiagnostic returns true if the diagnostic reports
+// missing arguments, which may still be resolved while reconciling.
+func isMissingArgumentDiagnostic(summary, detail string) bool {
+	if summary == summaryMissingRequiredArgument || summary == summaryMissingRequiredAttribute {
+		return true
+	}
+	for _, d := range detailsMissingArguments {
+		if strings.Contains(detail, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldError returns a field error at the spec field corresponding to the
+// given Terraform attribute path.
+func (v *SchemaValidator[T]) fieldError(steps []any, forProvider map[string]any, summary, detail string) *field.Error {
+	msg := summary
+	if detail != "" {
+		msg = fmt.Sprintf("%s: %s", summary, detail)
+	}
+	root := field.NewPath("spec", "forProvider")
+	if len(steps) == 0 {
+		return field.Invalid(root, field.OmitValueType{}, msg)
+	}
+	// the arguments only set in spec.initProvider are reported there.
+	if top, ok := steps[0].(string); ok {
+		if _, ok := forProvider[top]; !ok {
+			root = field.NewPath("spec", "initProvider")
+		}
+	}
+	return field.Invalid(crdFieldPath(v.config, root, steps), field.OmitValueType{}, msg)
+}
+
+// validationProvider is a Plugin Framework provider serving a single
+// resource for validating its configurations.
+type validationProvider struct {
+	typeName string
+	resource fwresource.Resource
+}
+
+func (p *validationProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = p.typeName
+}
+
+func (p *validationProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, _ *fwprovider.SchemaResponse) {
+}
+
+func (p *validationProvider) Configure(_ context.Context, _ fwprovider.ConfigureRequest, _ *fwprovider.ConfigureResponse) {
+}
+
+func (p *validationProvider) DataSources(_ context.Context) []func() fwdatasource.DataSource {
+	return nil
+}
+
+func (p *validationProvider) Resources(_ context.Context) []func() fwresource.Resource {
+	return []func() fwresource.Resource{func() fwresource.Resource { return p.resource }}
+}
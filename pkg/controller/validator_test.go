@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/go-cty/cty"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/resource/fake"
+)
+
+func validateInstanceType(i any, p cty.Path) diag.Diagnostics {
+	if i.(string) == "t3.micro" {
+		return nil
+	}
+	return diag.Diagnostics{{Severity: diag.Error, Summary: "Invalid instance type", Detail: "only t3.micro is allowed", AttributePath: p}}
+}
+
+type instanceTypeValidator struct{}
+
+func (instanceTypeValidator) Description(_ context.Context) string {
+	return "only t3.micro is allowed"
+}
+
+func (v instanceTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (instanceTypeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.ValueString() == "t3.micro" {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid instance type", "only t3.micro is allowed")
+}
+
+// validatedTPFResource is a Plugin Framework resource with attribute
+// validators.
+type validatedTPFResource struct{}
+
+func (r *validatedTPFResource) Metadata(_ context.Context, req fwresource.MetadataRequest, resp *fwresource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance"
+}
+
+func (r *validatedTPFResource) Schema(_ context.Context, _ fwresource.SchemaRequest, resp *fwresource.SchemaResponse) {
+	resp.Schema = rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"name": rschema.StringAttribute{Required: true},
+			"instance_type": rschema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{instanceTypeValidator{}},
+			},
+			"rule": rschema.ListNestedAttribute{
+				Optional: true,
+				NestedObject: rschema.NestedAttributeObject{
+					Attributes: map[string]rschema.Attribute{
+						"instance_type": rschema.StringAttribute{
+							Optional:   true,
+							Validators: []validator.String{instanceTypeValidator{}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *validatedTPFResource) Create(_ context.Context, _ fwresource.CreateRequest, _ *fwresource.CreateResponse) {
+}
+
+func (r *validatedTPFResource) Read(_ context.Context, _ fwresource.ReadRequest, _ *fwresource.ReadResponse) {
+}
+
+func (r *validatedTPFResource) Update(_ context.Context, _ fwresource.UpdateRequest, _ *fwresource.UpdateResponse) {
+}
+
+func (r *validatedTPFResource) Delete(_ context.Context, _ fwresource.DeleteRequest, _ *fwresource.DeleteResponse) {
+}
+
+func TestSchemaValidatorFramework(t *testing.T) {
+	cfg := &config.Resource{
+		Name:                             "upjet_instance",
+		TerraformPluginFrameworkResource: &validatedTPFResource{},
+	}
+	gk := fake.GroupVersion.WithKind(fake.Kind).GroupKind()
+
+	cases := map[string]struct {
+		reason string
+		obj    *fake.Terraformed
+		want   error
+	}{
+		"Valid": {
+			reason: "A valid configuration should be admitted.",
+			obj:    fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.micro"})),
+		},
+		"MissingRequiredArgument": {
+			reason: "Missing arguments should not be reported as they can still be resolved while reconciling.",
+			obj:    fake.NewTerraformed(fake.WithParameters(map[string]any{"instance_type": "t3.micro"})),
+		},
+		"InvalidTopLevelAttribute": {
+			reason: "An attribute rejected by its validator should be reported at its spec field.",
+			obj:    fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.large"})),
+			want: kerrors.NewInvalid(gk, "", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "forProvider", "instanceType"), field.OmitValueType{}, "Invalid instance type: only t3.micro is allowed"),
+			}),
+		},
+		"InvalidNestedAttribute": {
+			reason: "A nested attribute rejected by its validator should be reported at its spec field with the list index.",
+			obj: fake.NewTerraformed(fake.WithParameters(map[string]any{
+				"name": "example",
+				"rule": []any{map[string]any{"instance_type": "t3.micro"}, map[string]any{"instance_type": "t3.large"}},
+			})),
+			want: kerrors.NewInvalid(gk, "", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "forProvider", "rule").Index(1).Child("instanceType"), field.OmitValueType{}, "Invalid instance type: only t3.micro is allowed"),
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSchemaValidator[*fake.Terraformed](cfg).ValidateCreate(context.Background(), tc.obj)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidateCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSchemaValidator(t *testing.T) {
+	cfg := &config.Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {Type: schema.TypeString, Required: true},
+				"instance_type": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateInstanceType,
+				},
+				"rule": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"instance_type": {
+								Type:             schema.TypeString,
+								Optional:         true,
+								ValidateDiagFunc: validateInstanceType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gk := fake.GroupVersion.WithKind(fake.Kind).GroupKind()
+
+	type args struct {
+		oldObj *fake.Terraformed
+		newObj *fake.Terraformed
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"Valid": {
+			reason: "A valid configuration should be admitted.",
+			args: args{
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.micro"})),
+			},
+		},
+		"MissingRequiredArgument": {
+			reason: "Missing arguments should not be reported as they can still be resolved while reconciling.",
+			args: args{
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"instance_type": "t3.micro"})),
+			},
+		},
+		"InvalidTopLevelArgument": {
+			reason: "An invalid top-level argument should be reported at its spec field.",
+			args: args{
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.large"})),
+			},
+			want: kerrors.NewInvalid(gk, "", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "forProvider", "instanceType"), field.OmitValueType{}, "Invalid instance type: only t3.micro is allowed"),
+			}),
+		},
+		"InvalidNestedArgument": {
+			reason: "An invalid nested argument should be reported at its spec field with the list index.",
+			args: args{
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{
+					"name": "example",
+					"rule": []any{map[string]any{"instance_type": "t3.micro"}, map[string]any{"instance_type": "t3.large"}},
+				})),
+			},
+			want: kerrors.NewInvalid(gk, "", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "forProvider", "rule").Index(1).Child("instanceType"), field.OmitValueType{}, "Invalid instance type: only t3.micro is allowed"),
+			}),
+		},
+		"UpdateWithoutParameterChanges": {
+			reason: "An update not changing the parameters should be admitted.",
+			args: args{
+				oldObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.large"})),
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.large"})),
+			},
+		},
+		"UpdateWithParameterChanges": {
+			reason: "An update changing the parameters should be validated.",
+			args: args{
+				oldObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.micro"})),
+				newObj: fake.NewTerraformed(fake.WithParameters(map[string]any{"name": "example", "instance_type": "t3.large"})),
+			},
+			want: kerrors.NewInvalid(gk, "", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "forProvider", "instanceType"), field.OmitValueType{}, "Invalid instance type: only t3.micro is allowed"),
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewSchemaValidator[*fake.Terraformed](cfg)
+			var err error
+			if tc.args.oldObj == nil {
+				_, err = v.ValidateCreate(context.Background(), tc.args.newObj)
+			} else {
+				_, err = v.ValidateUpdate(context.Background(), tc.args.oldObj, tc.args.newObj)
+			}
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nValidate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -18,6 +17,7 @@ import (
 	xpmeta "github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"sigs.k8s.io/yaml"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
@@ -49,6 +49,7 @@ type Generator struct {
 
 	exampleNamespace string
 	localSecretRefs  bool
+	fs               afero.Fs
 }
 
 type GeneratorOption func(*Generator)
@@ -62,6 +63,14 @@ func WithLocalSecretRefs() GeneratorOption {
 	}
 }
 
+// WithFileSystem configures the file system the example manifests are
+// written to. Defaults to the OS file system.
+func WithFileSystem(fs afero.Fs) GeneratorOption {
+	return func(g *Generator) {
+		g.fs = fs
+	}
+}
+
 // WithCRDScope sets the CRD scope for the generator.
 func WithCRDScope(scope tjtypes.CRDScope) GeneratorOption {
 	return func(g *Generator) {
@@ -90,6 +99,7 @@ func NewGenerator(exampleDir, apisModulePath, shortName string, configResources
 		exampleDir:      exampleDir,
 		configResources: configResources,
 		resources:       make(map[string]*reference.PavedWithManifest),
+		fs:              afero.NewOsFs(),
 	}
 	for _, opt := range opts {
 		opt(g)
@@ -102,7 +112,7 @@ func NewGenerator(exampleDir, apisModulePath, shortName string, configResources
 func (eg *Generator) StoreExamples() error { //nolint:gocyclo
 	for rn, pm := range eg.resources {
 		manifestDir := filepath.Dir(pm.ManifestPath)
-		if err := os.MkdirAll(manifestDir, 0750); err != nil {
+		if err := eg.fs.MkdirAll(manifestDir, 0750); err != nil {
 			return errors.Wrapf(err, "cannot mkdir %s", manifestDir)
 		}
 		var buff bytes.Buffer
@@ -145,7 +155,7 @@ func (eg *Generator) StoreExamples() error { //nolint:gocyclo
 		newBuff := bytes.TrimSuffix(buff.Bytes(), []byte("\n---\n\n"))
 
 		// no sensitive info in the example manifest
-		if err := os.WriteFile(pm.ManifestPath, newBuff, 0600); err != nil {
+		if err := afero.WriteFile(eg.fs, pm.ManifestPath, newBuff, 0600); err != nil {
 			return errors.Wrapf(err, "cannot write example manifest file %s for resource %s", pm.ManifestPath, rn)
 		}
 	}
@@ -298,7 +308,7 @@ func transformFields(r *config.Resource, params map[string]any, omittedFields []
 				ref["namespace"] = namespace
 			}
 			params[fn.LowerCamelComputed+"SecretRef"] = getRefField(v, ref)
-		case r.References[fieldPath] != config.Reference{}:
+		case hasReference(r, fieldPath):
 			switch v.(type) {
 			case []any:
 				l := sch.Type == schema.TypeList || sch.Type == schema.TypeSet
@@ -314,6 +324,13 @@ func transformFields(r *config.Resource, params map[string]any, omittedFields []
 	}
 }
 
+// hasReference returns true if a non-empty reference is configured for the
+// field at the specified path.
+func hasReference(r *config.Resource, fieldPath string) bool {
+	ref := r.References[fieldPath]
+	return ref.Type != "" || ref.TerraformName != "" || ref.Extractor != "" || ref.RefFieldName != "" || ref.SelectorFieldName != "" || ref.IsPolymorphic() || ref.External != nil //nolint:staticcheck // still handling deprecated field behavior
+}
+
 func getNameRefField(v any) any {
 	arr := v.([]any)
 	refArr := make([]map[string]any, len(arr))
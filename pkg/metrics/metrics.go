@@ -99,6 +99,16 @@ var (
 		Help:      "The number of running Terraform CLI and Terraform provider processes",
 	}, []string{"type"})
 
+	// SupersededOperations is a counter metric of the number of the
+	// asynchronous operations canceled because they have been superseded by
+	// a newer generation or the deletion of the managed resource.
+	SupersededOperations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: promNSUpjet,
+		Subsystem: promSysResource,
+		Name:      "superseded_operations_total",
+		Help:      "The number of asynchronous operations canceled because they have been superseded",
+	}, []string{"group", "version", "kind", "operation", "cause"})
+
 	// TTRMeasurements are the time-to-readiness measurements for
 	// the managed resources.
 	TTRMeasurements = prometheus.NewHistogramVec(prometheus.HistogramOpts{
@@ -196,5 +206,5 @@ func (r *MetricRecorder) Start(ctx context.Context) error {
 }
 
 func init() {
-	metrics.Registry.MustRegister(CLITime, CLIExecutions, TFProcesses, TTRMeasurements, ExternalAPITime, ExternalAPICalls, DeletionTime, ReconcileDelay)
+	metrics.Registry.MustRegister(CLITime, CLIExecutions, TFProcesses, TTRMeasurements, ExternalAPITime, ExternalAPICalls, DeletionTime, ReconcileDelay, SupersededOperations)
 }
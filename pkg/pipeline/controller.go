@@ -11,6 +11,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/muvaf/typewriter/pkg/wrapper"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
@@ -23,6 +24,7 @@ func NewControllerGenerator(ctrlDir, hackDir, ctrlModulePath, group string, o ..
 		ControllerGroupDir: filepath.Join(ctrlDir, strings.Split(group, ".")[0]),
 		ModulePath:         ctrlModulePath,
 		LicenseHeaderPath:  filepath.Join(hackDir, "boilerplate.go.txt"),
+		fs:                 afero.NewOsFs(),
 	}
 
 	// apply the specified configuration options.
@@ -41,6 +43,7 @@ type ControllerGenerator struct {
 	LicenseHeaderPath  string
 
 	controllerTemplate string
+	fs                 afero.Fs
 }
 
 // A ControllerGeneratorOption configures a ControllerGenerator option.
@@ -53,11 +56,24 @@ func WithControllerTemplate(template string) ControllerGeneratorOption {
 	}
 }
 
+// PackagePath returns the Go package path of the controller to be generated
+// for the given resource.
+func (cg *ControllerGenerator) PackagePath(cfg *config.Resource) string {
+	return filepath.Join(cg.ModulePath, strings.ToLower(strings.Split(cg.Group, ".")[0]), strings.ToLower(cfg.Kind))
+}
+
+// hasValidatingWebhook returns true if a validating webhook is generated for
+// the given resource. The observe-only data sources and the ephemeral
+// resources do not have validating webhooks.
+func hasValidatingWebhook(cfg *config.Resource) bool {
+	return cfg.ValidatingWebhook && !cfg.IsDataSource() && !cfg.IsEphemeralResource()
+}
+
 // Generate writes controller setup functions.
 func (cg *ControllerGenerator) Generate(cfg *config.Resource, typesPkgPath string, featuresPkgPath string) (pkgPath string, err error) {
 	ctrlTemplate := templateOrDefault(cg.controllerTemplate, templates.ControllerTemplate)
 
-	controllerPkgPath := filepath.Join(cg.ModulePath, strings.ToLower(strings.Split(cg.Group, ".")[0]), strings.ToLower(cfg.Kind))
+	controllerPkgPath := cg.PackagePath(cfg)
 	ctrlFile := wrapper.NewFile(controllerPkgPath, strings.ToLower(cfg.Kind), ctrlTemplate,
 		wrapper.WithGenStatement(GenStatement),
 		wrapper.WithHeaderPath(cg.LicenseHeaderPath),
@@ -74,7 +90,10 @@ func (cg *ControllerGenerator) Generate(cfg *config.Resource, typesPkgPath strin
 		"UseTerraformPluginSDKClient":       cfg.ShouldUseTerraformPluginSDKClient(),
 		"UseTerraformPluginFrameworkClient": cfg.ShouldUseTerraformPluginFrameworkClient(),
 		"ResourceType":                      cfg.Name,
+		"DataSource":                        cfg.IsDataSource(),
+		"EphemeralResource":                 cfg.IsEphemeralResource(),
 		"Initializers":                      cfg.InitializerFns,
+		"ValidatingWebhook":                 hasValidatingWebhook(cfg),
 	}
 
 	// If the provider has a features package, add it to the controller template.
@@ -86,7 +105,7 @@ func (cg *ControllerGenerator) Generate(cfg *config.Resource, typesPkgPath strin
 
 	filePath := filepath.Join(cg.ControllerGroupDir, strings.ToLower(cfg.Kind), "zz_controller.go")
 	return controllerPkgPath, errors.Wrap(
-		ctrlFile.Write(filePath, vars, os.ModePerm),
+		writeFile(cg.fs, ctrlFile, filePath, vars, os.ModePerm),
 		"cannot write controller file",
 	)
 }
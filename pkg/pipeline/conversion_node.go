@@ -13,6 +13,7 @@ import (
 
 	"github.com/muvaf/typewriter/pkg/wrapper"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 )
@@ -37,6 +38,7 @@ func NewConversionNodeGenerator(apiDir, hackDir, apiModulePath, group, generated
 		generatedFileName: generatedFileName,
 		fileTemplate:      fileTemplate,
 		predicate:         p,
+		fs:                afero.NewOsFs(),
 	}
 }
 
@@ -50,11 +52,12 @@ type ConversionNodeGenerator struct {
 	generatedFileName string
 	fileTemplate      string
 	predicate         generationPredicate
+	fs                afero.Fs
 }
 
 // Generate writes generated conversion.Convertible interface functions
 func (cg *ConversionNodeGenerator) Generate(versionMap map[string]map[string]*config.Resource) error { //nolint:gocyclo
-	entries, err := os.ReadDir(cg.apiGroupDir)
+	entries, err := afero.ReadDir(cg.fs, cg.apiGroupDir)
 	if err != nil {
 		return errors.Wrapf(err, "cannot list the directory entries for the source folder %s while generating the conversion functions", cg.apiGroupDir)
 	}
@@ -74,7 +77,7 @@ func (cg *ConversionNodeGenerator) Generate(versionMap map[string]map[string]*co
 		}
 
 		versionDir := filepath.Join(cg.apiGroupDir, version)
-		files, err := os.ReadDir(versionDir)
+		files, err := afero.ReadDir(cg.fs, versionDir)
 		if err != nil {
 			return errors.Wrapf(err, "cannot list the directory entries for the source folder %s while looking for the generated types", versionDir)
 		}
@@ -110,7 +113,7 @@ func (cg *ConversionNodeGenerator) Generate(versionMap map[string]map[string]*co
 		if len(resources) == 0 {
 			continue
 		}
-		if err := convFile.Write(filePath, vars, os.ModePerm); err != nil {
+		if err := writeFile(cg.fs, convFile, filePath, vars, os.ModePerm); err != nil {
 			return errors.Wrapf(err, "cannot write the generated conversion functions file %s", filePath)
 		}
 	}
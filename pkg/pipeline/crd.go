@@ -16,6 +16,7 @@ import (
 	twtypes "github.com/muvaf/typewriter/pkg/types"
 	"github.com/muvaf/typewriter/pkg/wrapper"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 
 	tjpkg "github.com/crossplane/upjet/v2/pkg"
 	"github.com/crossplane/upjet/v2/pkg/config"
@@ -37,6 +38,7 @@ func NewCRDGenerator(pkg *types.Package, apiDir, hackDir, providerShortName, gro
 		Scope:              scope,
 		ProviderShortName:  providerShortName,
 		pkg:                pkg,
+		fs:                 afero.NewOsFs(),
 	}
 }
 
@@ -51,6 +53,17 @@ type CRDGenerator struct {
 	Generated          *tjtypes.Generated
 
 	pkg *types.Package
+	fs  afero.Fs
+}
+
+// prepareTerraformSchema removes the omitted fields from the Terraform schema
+// of the given resource and adds the id field to it. It's idempotent.
+func prepareTerraformSchema(cfg *config.Resource) {
+	deleteOmittedFields(cfg.TerraformResource.Schema, cfg.ExternalName.OmittedFields)
+	cfg.TerraformResource.Schema["id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
 }
 
 // Generate builds and writes a new CRD out of Terraform resource definition.
@@ -60,17 +73,16 @@ func (cg *CRDGenerator) Generate(cfg *config.Resource) (string, error) {
 		wrapper.WithHeaderPath(cg.LicenseHeaderPath),
 	)
 
-	deleteOmittedFields(cfg.TerraformResource.Schema, cfg.ExternalName.OmittedFields)
-	cfg.TerraformResource.Schema["id"] = &schema.Schema{
-		Type:     schema.TypeString,
-		Computed: true,
-	}
+	prepareTerraformSchema(cfg)
 
 	gen, err := tjtypes.NewBuilder(cg.pkg, cg.Scope).Build(cfg)
 	if err != nil {
 		return "", errors.Wrapf(err, "cannot build types for %s", cfg.Kind)
 	}
 	cg.Generated = &gen
+	if err := validateCRDOptions(cfg, &gen); err != nil {
+		return "", errors.Wrapf(err, "invalid CRD configuration for %s", cfg.Kind)
+	}
 
 	// TODO(muvaf): TypePrinter uses the given scope to see if the type exists
 	// before printing. We should ideally load the package in file system but
@@ -94,6 +106,9 @@ func (cg *CRDGenerator) Generate(cfg *config.Resource) (string, error) {
 		"ValidationRules":    gen.ValidationRules,
 		"Path":               cfg.Path,
 		"Scope":              string(cg.Scope),
+		"PrinterColumns":     printerColumnMarkers(cfg),
+		"ShortNames":         strings.Join(cfg.ShortNames, ","),
+		"Categories":         strings.Join(cfg.Categories, ","),
 	}
 
 	// Add deprecation information if this version is deprecated
@@ -132,7 +147,7 @@ func (cg *CRDGenerator) Generate(cfg *config.Resource) (string, error) {
 		vars["CRD"].(map[string]string)["Description"] = tjpkg.FilterDescription(cfg.MetaResource.Description, tjpkg.TerraformKeyword)
 	}
 	filePath := filepath.Join(cg.LocalDirectoryPath, fmt.Sprintf("zz_%s_types.go", strings.ToLower(cfg.Kind)))
-	return gen.ForProviderType.Obj().Name(), errors.Wrap(file.Write(filePath, vars, os.ModePerm), "cannot write crd file")
+	return gen.ForProviderType.Obj().Name(), errors.Wrap(writeFile(cg.fs, file, filePath, vars, os.ModePerm), "cannot write crd file")
 }
 
 func deleteOmittedFields(sch map[string]*schema.Schema, omittedFields []string) {
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+const (
+	errFmtPrinterColumn        = "invalid printer column %q"
+	errFmtPrinterColumnType    = "unsupported type %q, must be one of integer, number, string, boolean or date"
+	errFmtPrinterColumnPath    = "JSONPath %q must start with one of .spec.forProvider., .spec.initProvider. or .status.atProvider."
+	errFmtPrinterColumnSegment = "unsupported JSONPath segment %q"
+	errFmtPrinterColumnField   = "cannot find the field %q in the type %s"
+	errFmtPrinterColumnList    = "the field %q is a list and must be indexed"
+	errFmtPrinterColumnIndex   = "the field %q is not a list and cannot be indexed"
+	errFmtPrinterColumnScalar  = "the field at %q with the type %s is not a scalar field of type %q"
+	errFmtInvalidShortName     = "invalid short name %q"
+	errFmtInvalidCategory      = "invalid category %q"
+)
+
+var (
+	// reJSONPathSegment matches a JSONPath segment such as field or
+	// field[0] in a printer column.
+	reJSONPathSegment = regexp.MustCompile(`^([^.\[\]]+)(\[(\d+|\*)\])?$`)
+	// reResourceName matches the valid short names & categories of a CRD,
+	// which must be lower-case DNS labels.
+	reResourceName = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+)
+
+// validateCRDOptions validates the short names, the categories and the
+// printer columns of the specified resource configuration. The JSONPaths of
+// the printer columns are validated against the generated types.
+func validateCRDOptions(cfg *config.Resource, gen *tjtypes.Generated) error {
+	for _, n := range cfg.ShortNames {
+		if !reResourceName.MatchString(n) {
+			return errors.Errorf(errFmtInvalidShortName, n)
+		}
+	}
+	for _, c := range cfg.Categories {
+		if !reResourceName.MatchString(c) {
+			return errors.Errorf(errFmtInvalidCategory, c)
+		}
+	}
+	for _, c := range cfg.PrinterColumns {
+		if err := validatePrinterColumn(c, gen); err != nil {
+			return errors.Wrapf(err, errFmtPrinterColumn, c.Name)
+		}
+	}
+	return nil
+}
+
+func validatePrinterColumn(c config.PrinterColumn, gen *tjtypes.Generated) error {
+	roots := map[string]types.Type{
+		".spec.forProvider.":  gen.ForProviderType,
+		".spec.initProvider.": gen.InitProviderType,
+		".status.atProvider.": gen.AtProviderType,
+	}
+	var t types.Type
+	var path string
+	for prefix, root := range roots {
+		if p, ok := strings.CutPrefix(c.JSONPath, prefix); ok {
+			t, path = root, p
+			break
+		}
+	}
+	if t == nil {
+		return errors.Errorf(errFmtPrinterColumnPath, c.JSONPath)
+	}
+	for _, s := range strings.Split(path, ".") {
+		m := reJSONPathSegment.FindStringSubmatch(s)
+		if m == nil {
+			return errors.Errorf(errFmtPrinterColumnSegment, s)
+		}
+		var err error
+		if t, err = jsonField(t, m[1]); err != nil {
+			return err
+		}
+		_, isList := t.Underlying().(*types.Slice)
+		switch {
+		case isList && m[2] == "":
+			return errors.Errorf(errFmtPrinterColumnList, m[1])
+		case !isList && m[2] != "":
+			return errors.Errorf(errFmtPrinterColumnIndex, m[1])
+		case isList:
+			t = t.Underlying().(*types.Slice).Elem()
+		}
+	}
+	return checkColumnType(c, t)
+}
+
+// jsonField returns the type of the field with the specified JSON name in
+// the specified struct type, or the element type if the specified type is a
+// map.
+func jsonField(t types.Type, name string) (types.Type, error) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Map:
+		return derefType(u.Elem()), nil
+	case *types.Struct:
+		for i := range u.NumFields() {
+			n, _, _ := strings.Cut(reflect.StructTag(u.Tag(i)).Get("json"), ",")
+			if n == name {
+				return derefType(u.Field(i).Type()), nil
+			}
+		}
+	}
+	return nil, errors.Errorf(errFmtPrinterColumnField, name, types.TypeString(t, nil))
+}
+
+func derefType(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// checkColumnType checks whether the type of the field referred to by
+// the specified printer column is compatible with the column's type.
+func checkColumnType(c config.PrinterColumn, t types.Type) error {
+	b, ok := derefType(t).Underlying().(*types.Basic)
+	var valid bool
+	switch c.Type {
+	case "string", "date":
+		valid = ok && b.Info()&types.IsString != 0
+	case "integer":
+		valid = ok && b.Info()&types.IsInteger != 0
+	case "number":
+		valid = ok && b.Info()&types.IsNumeric != 0
+	case "boolean":
+		valid = ok && b.Info()&types.IsBoolean != 0
+	default:
+		return errors.Errorf(errFmtPrinterColumnType, c.Type)
+	}
+	if !valid {
+		return errors.Errorf(errFmtPrinterColumnScalar, c.JSONPath, types.TypeString(t, nil), c.Type)
+	}
+	return nil
+}
+
+// printerColumnMarkers returns the kubebuilder markers for the additional
+// printer columns of the specified resource configuration.
+func printerColumnMarkers(cfg *config.Resource) string {
+	var sb strings.Builder
+	for _, c := range cfg.PrinterColumns {
+		fmt.Fprintf(&sb, "// +kubebuilder:printcolumn:name=%q,type=%q,JSONPath=%q", c.Name, c.Type, c.JSONPath)
+		if c.Priority > 0 {
+			fmt.Fprintf(&sb, ",priority=%d", c.Priority)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
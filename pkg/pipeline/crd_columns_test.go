@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+func TestValidateCRDOptions(t *testing.T) {
+	cfg := &config.Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"size": {
+					Type:     schema.TypeInt,
+					Optional: true,
+				},
+				"tags": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"endpoint": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"address": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	gen, err := tjtypes.NewBuilder(types.NewPackage("example", ""), tjtypes.CRDScopeCluster).Build(cfg)
+	if err != nil {
+		t.Fatalf("Build(...): unexpected error: %v", err)
+	}
+
+	type args struct {
+		shortNames []string
+		categories []string
+		columns    []config.PrinterColumn
+	}
+	cases := map[string]struct {
+		args
+		want error
+	}{
+		"Valid": {
+			args: args{
+				shortNames: []string{"ex"},
+				categories: []string{"compute"},
+				columns: []config.PrinterColumn{
+					{Name: "STATE", Type: "string", JSONPath: ".status.atProvider.state"},
+					{Name: "NAME", Type: "string", JSONPath: ".spec.forProvider.name"},
+					{Name: "SIZE", Type: "integer", JSONPath: ".spec.initProvider.size", Priority: 1},
+					{Name: "TAG", Type: "string", JSONPath: ".spec.forProvider.tags.env"},
+					{Name: "ENDPOINT", Type: "string", JSONPath: ".status.atProvider.endpoint[0].address"},
+				},
+			},
+		},
+		"InvalidShortName": {
+			args: args{
+				shortNames: []string{"Ex"},
+			},
+			want: errors.Errorf(errFmtInvalidShortName, "Ex"),
+		},
+		"InvalidCategory": {
+			args: args{
+				categories: []string{"com pute"},
+			},
+			want: errors.Errorf(errFmtInvalidCategory, "com pute"),
+		},
+		"InvalidPrefix": {
+			args: args{
+				columns: []config.PrinterColumn{{Name: "STATE", Type: "string", JSONPath: ".status.state"}},
+			},
+			want: errors.Wrapf(errors.Errorf(errFmtPrinterColumnPath, ".status.state"), errFmtPrinterColumn, "STATE"),
+		},
+		"MissingField": {
+			args: args{
+				columns: []config.PrinterColumn{{Name: "STATE", Type: "string", JSONPath: ".spec.forProvider.state"}},
+			},
+			want: errors.Wrapf(errors.Errorf(errFmtPrinterColumnField, "state", "example.Parameters"), errFmtPrinterColumn, "STATE"),
+		},
+		"UnindexedList": {
+			args: args{
+				columns: []config.PrinterColumn{{Name: "ENDPOINT", Type: "string", JSONPath: ".status.atProvider.endpoint.address"}},
+			},
+			want: errors.Wrapf(errors.Errorf(errFmtPrinterColumnList, "endpoint"), errFmtPrinterColumn, "ENDPOINT"),
+		},
+		"TypeMismatch": {
+			args: args{
+				columns: []config.PrinterColumn{{Name: "SIZE", Type: "boolean", JSONPath: ".spec.forProvider.size"}},
+			},
+			want: errors.Wrapf(errors.Errorf(errFmtPrinterColumnScalar, ".spec.forProvider.size", "int64", "boolean"), errFmtPrinterColumn, "SIZE"),
+		},
+		"UnsupportedType": {
+			args: args{
+				columns: []config.PrinterColumn{{Name: "SIZE", Type: "object", JSONPath: ".spec.forProvider.size"}},
+			},
+			want: errors.Wrapf(errors.Errorf(errFmtPrinterColumnType, "object"), errFmtPrinterColumn, "SIZE"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg.ShortNames = tc.shortNames
+			cfg.Categories = tc.categories
+			cfg.PrinterColumns = tc.columns
+			err := validateCRDOptions(cfg, &gen)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("validateCRDOptions(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrinterColumnMarkers(t *testing.T) {
+	cfg := &config.Resource{
+		PrinterColumns: []config.PrinterColumn{
+			{Name: "STATE", Type: "string", JSONPath: ".status.atProvider.state"},
+			{Name: "SIZE", Type: "integer", JSONPath: ".spec.forProvider.size", Priority: 1},
+		},
+	}
+	want := `// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="SIZE",type="integer",JSONPath=".spec.forProvider.size",priority=1
+`
+	if diff := cmp.Diff(want, printerColumnMarkers(cfg)); diff != "" {
+		t.Errorf("printerColumnMarkers(...): -want, +got:\n%s", diff)
+	}
+}
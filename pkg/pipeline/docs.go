@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	tjpkg "github.com/crossplane/upjet/v2/pkg"
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+var (
+	docsTemplate      = template.Must(template.New("api-docs").Parse(templates.APIDocsTemplate))
+	docsIndexTemplate = template.Must(template.New("api-docs-index").Parse(templates.APIDocsIndexTemplate))
+
+	// markdownCellReplacer escapes the text to be rendered in a Markdown
+	// table cell.
+	markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+)
+
+// NewDocsGenerator returns a new DocsGenerator.
+func NewDocsGenerator(docsDir, examplesDir string, pc *config.Provider, group, version string, scope tjtypes.CRDScope) *DocsGenerator {
+	return &DocsGenerator{
+		DocsDir:     docsDir,
+		ExamplesDir: examplesDir,
+		Group:       group,
+		Version:     version,
+		Scope:       scope,
+		provider:    pc,
+		fs:          afero.NewOsFs(),
+	}
+}
+
+// DocsGenerator generates the Markdown API reference documentation pages of
+// the managed resources of a specific group and version.
+type DocsGenerator struct {
+	DocsDir     string
+	ExamplesDir string
+	Group       string
+	Version     string
+	Scope       tjtypes.CRDScope
+
+	provider *config.Provider
+	fs       afero.Fs
+}
+
+type docsField struct {
+	Path        string
+	Type        string
+	Description string
+	Required    bool
+	Sensitive   bool
+	Immutable   bool
+	Reference   string
+}
+
+type docsExternalName struct {
+	NameInitializer  bool
+	IdentifierFields []string
+	OmittedFields    []string
+	Example          string
+}
+
+type docsLink struct {
+	Name string
+	Link string
+}
+
+// Generate writes the API reference documentation page of the specified
+// resource whose types have been generated.
+func (dg *DocsGenerator) Generate(cfg *config.Resource, gen *tjtypes.Generated) error {
+	pageDir := filepath.Join(dg.DocsDir, groupDir(dg.Group), dg.Version)
+	externalName := docsExternalName{
+		NameInitializer:  !cfg.ExternalName.DisableNameInitializer,
+		IdentifierFields: cfg.ExternalName.IdentifierFields,
+		OmittedFields:    cfg.ExternalName.OmittedFields,
+	}
+	vars := map[string]any{
+		"Kind":          cfg.Kind,
+		"Group":         dg.Group,
+		"Version":       dg.Version,
+		"Scope":         string(dg.Scope),
+		"TerraformName": cfg.Name,
+		"ExternalName":  &externalName,
+	}
+	if deprecation, ok := cfg.IsVersionDeprecated(dg.Version); ok {
+		vars["Deprecation"] = buildEnhancedDeprecationWarning(deprecation)
+	}
+
+	fields := slices.Clone(gen.Fields)
+	slices.SortStableFunc(fields, func(a, b tjtypes.FieldDoc) int {
+		// sort by the path segments so that the nested fields follow their
+		// parents.
+		return slices.Compare(strings.Split(strings.ReplaceAll(a.Path, "[*]", ""), "."), strings.Split(strings.ReplaceAll(b.Path, "[*]", ""), "."))
+	})
+	var params, obs []docsField
+	for _, f := range fields {
+		df := docsField{
+			Path:        f.Path,
+			Type:        f.Type,
+			Description: markdownCell(f.Description),
+			Required:    f.Required,
+			Sensitive:   f.Sensitive,
+			Immutable:   f.Immutable,
+		}
+		if f.Deprecated != "" {
+			df.Description = strings.TrimSpace("**Deprecated:** " + markdownCell(f.Deprecated) + " " + df.Description)
+		}
+		if f.Observation {
+			obs = append(obs, df)
+			continue
+		}
+		if f.Reference != nil {
+			df.Reference = dg.referenceCell(pageDir, f.Reference)
+		}
+		params = append(params, df)
+	}
+	vars["Parameters"] = params
+	vars["Observations"] = obs
+
+	if cfg.MetaResource != nil {
+		vars["Description"] = tjpkg.FilterDescription(cfg.MetaResource.Description, tjpkg.TerraformKeyword)
+		vars["ImportStatements"] = cfg.MetaResource.ImportStatements
+		externalName.Example = cfg.MetaResource.ExternalName
+		// only the first example of a resource is generated as an example
+		// manifest.
+		if len(cfg.MetaResource.Examples) > 0 {
+			manifest := filepath.Join(dg.ExamplesDir, groupDir(dg.Group), cfg.Version, strings.ToLower(cfg.Kind)+".yaml")
+			link, err := filepath.Rel(pageDir, manifest)
+			if err != nil {
+				return errors.Wrapf(err, "cannot get the relative path of the example manifest %s", manifest)
+			}
+			vars["Examples"] = []docsLink{{Name: filepath.Base(manifest), Link: filepath.ToSlash(link)}}
+		}
+	}
+
+	buff := &bytes.Buffer{}
+	if err := docsTemplate.Execute(buff, vars); err != nil {
+		return errors.Wrapf(err, "cannot execute the API reference documentation template for %s", cfg.Kind)
+	}
+	if err := dg.fs.MkdirAll(pageDir, 0o750); err != nil {
+		return errors.Wrapf(err, "cannot mkdir %s", pageDir)
+	}
+	return errors.Wrap(afero.WriteFile(dg.fs, filepath.Join(pageDir, strings.ToLower(cfg.Kind)+".md"), buff.Bytes(), 0o600), "cannot write the API reference documentation page")
+}
+
+// referenceCell returns the Markdown table cell for the specified reference
+// linking to the API reference documentation pages of the referenced kinds if
+// they are known.
+func (dg *DocsGenerator) referenceCell(pageDir string, ref *config.Reference) string {
+	if ref.IsPolymorphic() {
+		cells := make([]string, 0, len(ref.Targets))
+		for _, t := range ref.Targets {
+			cells = append(cells, dg.referenceCell(pageDir, &config.Reference{TerraformName: t.TerraformName}))
+		}
+		return strings.Join(cells, ", ")
+	}
+	// the kinds of the other providers are not documented here.
+	if ref.External != nil {
+		return fmt.Sprintf("`%s` (%s/%s)", ref.External.Kind, ref.External.Group, ref.External.Version)
+	}
+	if ref.TerraformName != "" {
+		r, ok := dg.provider.Resources[ref.TerraformName]
+		if !ok {
+			return fmt.Sprintf("`%s`", ref.TerraformName)
+		}
+		group := dg.provider.RootGroup
+		if r.ShortGroup != "" {
+			group = strings.ToLower(r.ShortGroup) + "." + dg.provider.RootGroup
+		}
+		page := filepath.Join(dg.DocsDir, groupDir(group), r.Version, strings.ToLower(r.Kind)+".md")
+		link, err := filepath.Rel(pageDir, page)
+		if err != nil {
+			return fmt.Sprintf("`%s`", r.Kind)
+		}
+		return fmt.Sprintf("[%s](%s)", r.Kind, filepath.ToSlash(link))
+	}
+	// a type without a package path is in the same package as the
+	// referencing resource.
+	if !strings.Contains(ref.Type, ".") {
+		return fmt.Sprintf("[%s](%s.md)", ref.Type, strings.ToLower(ref.Type))
+	}
+	return fmt.Sprintf("`%s`", ref.Type)
+}
+
+// NewDocsIndexGenerator returns a new DocsIndexGenerator.
+func NewDocsIndexGenerator(docsDir string) *DocsIndexGenerator {
+	return &DocsIndexGenerator{
+		DocsDir: docsDir,
+		fs:      afero.NewOsFs(),
+	}
+}
+
+// DocsIndexGenerator generates the index page of the API reference
+// documentation.
+type DocsIndexGenerator struct {
+	DocsDir string
+
+	fs afero.Fs
+}
+
+type docsIndexGroup struct {
+	Name  string
+	Kinds []docsIndexKind
+}
+
+type docsIndexKind struct {
+	Kind     string
+	Versions []docsIndexVersion
+}
+
+type docsIndexVersion struct {
+	Version    string
+	Link       string
+	Deprecated bool
+}
+
+// Generate writes the index page of the API reference documentation for the
+// specified resources grouped by their API groups and versions.
+func (ig *DocsIndexGenerator) Generate(resourcesGroups map[string]map[string]map[string]*config.Resource) error {
+	groups := make([]docsIndexGroup, 0, len(resourcesGroups))
+	for _, group := range slices.Sorted(maps.Keys(resourcesGroups)) {
+		kinds := map[string]*docsIndexKind{}
+		for _, version := range slices.Sorted(maps.Keys(resourcesGroups[group])) {
+			for _, r := range resourcesGroups[group][version] {
+				k, ok := kinds[r.Kind]
+				if !ok {
+					k = &docsIndexKind{Kind: r.Kind}
+					kinds[r.Kind] = k
+				}
+				_, deprecated := r.IsVersionDeprecated(version)
+				k.Versions = append(k.Versions, docsIndexVersion{
+					Version:    version,
+					Link:       filepath.ToSlash(filepath.Join(groupDir(group), version, strings.ToLower(r.Kind)+".md")),
+					Deprecated: deprecated,
+				})
+			}
+		}
+		g := docsIndexGroup{Name: group}
+		for _, k := range slices.Sorted(maps.Keys(kinds)) {
+			g.Kinds = append(g.Kinds, *kinds[k])
+		}
+		groups = append(groups, g)
+	}
+
+	buff := &bytes.Buffer{}
+	if err := docsIndexTemplate.Execute(buff, map[string]any{"Groups": groups}); err != nil {
+		return errors.Wrap(err, "cannot execute the API reference documentation index template")
+	}
+	if err := ig.fs.MkdirAll(ig.DocsDir, 0o750); err != nil {
+		return errors.Wrapf(err, "cannot mkdir %s", ig.DocsDir)
+	}
+	return errors.Wrap(afero.WriteFile(ig.fs, filepath.Join(ig.DocsDir, "README.md"), buff.Bytes(), 0o600), "cannot write the API reference documentation index")
+}
+
+// groupDir returns the name of the directory of the specified API group.
+func groupDir(group string) string {
+	return strings.ToLower(strings.Split(group, ".")[0])
+}
+
+func markdownCell(s string) string {
+	return strings.TrimSpace(markdownCellReplacer.Replace(s))
+}
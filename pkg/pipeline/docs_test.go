@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"go/types"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/registry"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+func newDocsTestResource(t *testing.T) *config.Resource {
+	t.Helper()
+	r := config.DefaultResource("example_instance", &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the instance.",
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ARN | identifier of the instance.",
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {
+							Type:       schema.TypeInt,
+							Required:   true,
+							Deprecated: "Use ports instead.",
+						},
+					},
+				},
+			},
+		},
+	}, nil, &registry.Resource{
+		Description:      "Manages an instance.",
+		ImportStatements: []string{"terraform import example_instance.example i-1234"},
+		Examples:         []registry.ResourceExample{{Name: "example"}},
+	})
+	r.ShortGroup = "compute"
+	r.Kind = "Instance"
+	r.Version = "v1beta1"
+	r.ImmutableForceNewFields = true
+	r.ExternalName = config.IdentifierFromProvider
+	r.References["network_id"] = config.Reference{TerraformName: "example_network"}
+	if err := r.SetDeprecatedVersion("v1beta1", config.VersionDeprecation{Warning: "Use v1beta2 instead."}); err != nil {
+		t.Fatalf("SetDeprecatedVersion(...): unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestDocsGeneratorGenerate(t *testing.T) {
+	r := newDocsTestResource(t)
+	pc := &config.Provider{
+		RootGroup: "example.upbound.io",
+		Resources: map[string]*config.Resource{
+			"example_instance": r,
+			"example_network": {
+				Name:    "example_network",
+				Kind:    "Network",
+				Version: "v1beta1",
+			},
+		},
+	}
+	gen, err := tjtypes.NewBuilder(types.NewPackage("example", "v1beta1"), tjtypes.CRDScopeCluster).Build(r)
+	if err != nil {
+		t.Fatalf("Build(...): unexpected error: %v", err)
+	}
+	fs := afero.NewMemMapFs()
+	g := NewDocsGenerator("/docs/api", "/examples-generated", pc, "compute.example.upbound.io", "v1beta1", tjtypes.CRDScopeCluster)
+	g.fs = fs
+	if err := g.Generate(r, &gen); err != nil {
+		t.Fatalf("Generate(...): unexpected error: %v", err)
+	}
+	got, err := afero.ReadFile(fs, "/docs/api/compute/v1beta1/instance.md")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	want, err := os.ReadFile("testdata/api_docs/instance.md")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("Generate(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestDocsIndexGeneratorGenerate(t *testing.T) {
+	r := newDocsTestResource(t)
+	network := &config.Resource{Name: "example_network", Kind: "Network", Version: "v1beta1"}
+	fs := afero.NewMemMapFs()
+	g := NewDocsIndexGenerator("/docs/api")
+	g.fs = fs
+	err := g.Generate(map[string]map[string]map[string]*config.Resource{
+		"compute.example.upbound.io": {
+			"v1beta1": {"example_instance": r},
+			"v1beta2": {"example_instance": r},
+		},
+		"example.upbound.io": {
+			"v1beta1": {"example_network": network},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Generate(...): unexpected error: %v", err)
+	}
+	got, err := afero.ReadFile(fs, "/docs/api/README.md")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	want, err := os.ReadFile("testdata/api_docs/README.md")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("Generate(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+// ChangeType is the type of change to a generated file.
+type ChangeType string
+
+const (
+	// ChangeTypeAdded means the file would be added by the code generation
+	// pipelines.
+	ChangeTypeAdded ChangeType = "added"
+	// ChangeTypeModified means the content of the file would be changed by
+	// the code generation pipelines.
+	ChangeTypeModified ChangeType = "modified"
+	// ChangeTypeDeleted means the file is no longer generated by the code
+	// generation pipelines.
+	ChangeTypeDeleted ChangeType = "deleted"
+)
+
+var (
+	// generatedDirs are the directories, relative to the root directory,
+	// the code generation pipelines write to.
+	generatedDirs = []string{
+		"apis",
+		filepath.Join("internal", "controller"),
+		filepath.Join("cmd", "provider"),
+		"examples-generated",
+		filepath.Join("docs", "api"),
+	}
+	// regexGeneratedGoFile matches the names of the Go files written by the
+	// code generation pipelines. It does not match the files generated by
+	// the other tools, such as zz_generated.deepcopy.go.
+	regexGeneratedGoFile = regexp.MustCompile(`^zz_(.+_types|.+_terraformed|groupversion_info|generated\.conversion_hubs|generated\.conversion_spokes|register|controller|setup|.+_setup|main)\.go$`)
+)
+
+// FileChange is a change the code generation pipelines would make to a file.
+type FileChange struct {
+	// Path is the path of the file relative to the root directory.
+	Path string
+	// Type is the type of the change.
+	Type ChangeType
+	// Diff is the unified diff of the change.
+	Diff string
+}
+
+// DryRunReport is the result of a dry-run of the code generation pipelines.
+type DryRunReport struct {
+	// Changes are the changes the code generation pipelines would make,
+	// sorted by path.
+	Changes []FileChange
+}
+
+// Stale returns true if the generated files in the root directory are not up
+// to date.
+func (r *DryRunReport) Stale() bool {
+	return len(r.Changes) > 0
+}
+
+// ExitCode returns a non-zero exit code if the generated files are stale so
+// that it can be passed to os.Exit in CI.
+func (r *DryRunReport) ExitCode() int {
+	if r.Stale() {
+		return 1
+	}
+	return 0
+}
+
+// Print writes a summary of the changes followed by their unified diffs to
+// the given writer.
+func (r *DryRunReport) Print(w io.Writer) error {
+	if !r.Stale() {
+		_, err := fmt.Fprintln(w, "Generated files are up to date.")
+		return err
+	}
+	for _, c := range r.Changes {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", c.Type, c.Path); err != nil {
+			return err
+		}
+	}
+	for _, c := range r.Changes {
+		if _, err := fmt.Fprint(w, "\n", c.Diff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DryRun runs the code generation pipelines like Run, but renders the
+// generated files into an in-memory file system instead of writing them
+// under rootDir. It then reports the files that would be added, modified or
+// deleted by Run. Deleted files are the ones generated by a previous run
+// that are no longer generated. The post-generation hooks are also run
+// against the in-memory file system.
+func DryRun(pcCluster, pcNamespace *config.Provider, rootDir string) (*DryRunReport, error) {
+	fs := afero.NewMemMapFs()
+	run(pcCluster, pcNamespace, rootDir, fs)
+	return diffGenerated(fs, afero.NewOsFs(), rootDir)
+}
+
+// diffGenerated compares the files generated into the generated file system
+// with the files in the current file system under rootDir.
+func diffGenerated(generated, current afero.Fs, rootDir string) (*DryRunReport, error) { //nolint:gocyclo // easier to follow as a unit
+	report := &DryRunReport{}
+	for _, d := range generatedDirs {
+		dir := filepath.Join(rootDir, d)
+		err := walkDir(generated, dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			newContent, err := afero.ReadFile(generated, path)
+			if err != nil {
+				return errors.Wrapf(err, "cannot read the generated file %s", path)
+			}
+			oldContent, err := afero.ReadFile(current, path)
+			changeType := ChangeTypeModified
+			switch {
+			case os.IsNotExist(err):
+				changeType = ChangeTypeAdded
+			case err != nil:
+				return errors.Wrapf(err, "cannot read the file %s", path)
+			case bytes.Equal(oldContent, newContent):
+				return nil
+			}
+			c, err := newFileChange(rootDir, path, changeType, oldContent, newContent)
+			if err != nil {
+				return err
+			}
+			report.Changes = append(report.Changes, c)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot compare the generated files under %s", dir)
+		}
+
+		err = walkDir(current, dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !isGeneratedFile(rootDir, path) {
+				return err
+			}
+			if ok, err := afero.Exists(generated, path); err != nil || ok {
+				return errors.Wrapf(err, "cannot check the generated file %s", path)
+			}
+			oldContent, err := afero.ReadFile(current, path)
+			if err != nil {
+				return errors.Wrapf(err, "cannot read the file %s", path)
+			}
+			c, err := newFileChange(rootDir, path, ChangeTypeDeleted, oldContent, nil)
+			if err != nil {
+				return err
+			}
+			report.Changes = append(report.Changes, c)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot look for the deleted files under %s", dir)
+		}
+	}
+	sort.Slice(report.Changes, func(i, j int) bool {
+		return report.Changes[i].Path < report.Changes[j].Path
+	})
+	return report, nil
+}
+
+// walkDir walks the given directory in fs if it exists.
+func walkDir(fs afero.Fs, dir string, fn filepath.WalkFunc) error {
+	if ok, err := afero.DirExists(fs, dir); err != nil || !ok {
+		return err
+	}
+	return afero.Walk(fs, dir, fn)
+}
+
+// isGeneratedFile returns true if the file at the given path is written by
+// the code generation pipelines.
+func isGeneratedFile(rootDir, path string) bool {
+	rel, err := filepath.Rel(filepath.Join(rootDir, "examples-generated"), path)
+	if err == nil && filepath.IsLocal(rel) {
+		return filepath.Ext(path) == ".yaml"
+	}
+	rel, err = filepath.Rel(filepath.Join(rootDir, "docs", "api"), path)
+	if err == nil && filepath.IsLocal(rel) {
+		return filepath.Ext(path) == ".md"
+	}
+	return regexGeneratedGoFile.MatchString(filepath.Base(path))
+}
+
+func newFileChange(rootDir, path string, t ChangeType, oldContent, newContent []byte) (FileChange, error) {
+	rel, err := filepath.Rel(rootDir, path)
+	if err != nil {
+		return FileChange{}, errors.Wrapf(err, "cannot get the relative path of %s", path)
+	}
+	from, to := "a/"+rel, "b/"+rel
+	switch t {
+	case ChangeTypeAdded:
+		from = os.DevNull
+	case ChangeTypeDeleted:
+		to = os.DevNull
+	case ChangeTypeModified:
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(oldContent),
+		B:        splitLines(newContent),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	if err != nil {
+		return FileChange{}, errors.Wrapf(err, "cannot compute the diff of %s", rel)
+	}
+	return FileChange{
+		Path: rel,
+		Type: t,
+		Diff: diff,
+	}, nil
+}
+
+// splitLines splits the given content into lines keeping the line endings.
+// Unlike difflib.SplitLines, it does not add an empty line at the end of the
+// content.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
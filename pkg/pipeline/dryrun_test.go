@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+)
+
+func TestDiffGenerated(t *testing.T) {
+	rootDir := "/provider"
+	typesFile := filepath.Join(rootDir, "apis", "cluster", "ec2", "v1beta1", "zz_vpc_types.go")
+	tfFile := filepath.Join(rootDir, "apis", "cluster", "ec2", "v1beta1", "zz_vpc_terraformed.go")
+	deepCopyFile := filepath.Join(rootDir, "apis", "cluster", "ec2", "v1beta1", "zz_generated.deepcopy.go")
+	ctrlFile := filepath.Join(rootDir, "internal", "controller", "cluster", "ec2", "subnet", "zz_controller.go")
+	exampleFile := filepath.Join(rootDir, "examples-generated", "cluster", "ec2", "v1beta1", "vpc.yaml")
+
+	type want struct {
+		changes []FileChange
+		stale   bool
+	}
+	cases := map[string]struct {
+		reason    string
+		generated map[string]string
+		current   map[string]string
+		want      want
+	}{
+		"UpToDate": {
+			reason: "No changes should be reported if the generated files are identical to the current ones",
+			generated: map[string]string{
+				typesFile: "package v1beta1\n",
+			},
+			current: map[string]string{
+				typesFile:    "package v1beta1\n",
+				deepCopyFile: "package v1beta1\n",
+			},
+			want: want{},
+		},
+		"Changes": {
+			reason: "Added, modified and deleted generated files should be reported with their diffs",
+			generated: map[string]string{
+				typesFile:   "package v1beta1\n\ntype VPC struct{}\n",
+				tfFile:      "package v1beta1\n",
+				exampleFile: "kind: VPC\n",
+			},
+			current: map[string]string{
+				typesFile:    "package v1beta1\n",
+				deepCopyFile: "package v1beta1\n",
+				ctrlFile:     "package subnet\n",
+				exampleFile:  "kind: VPC\n",
+			},
+			want: want{
+				stale: true,
+				changes: []FileChange{
+					{
+						Path: filepath.Join("apis", "cluster", "ec2", "v1beta1", "zz_vpc_terraformed.go"),
+						Type: ChangeTypeAdded,
+						Diff: "--- /dev/null\n+++ b/apis/cluster/ec2/v1beta1/zz_vpc_terraformed.go\n@@ -0,0 +1 @@\n+package v1beta1\n",
+					},
+					{
+						Path: filepath.Join("apis", "cluster", "ec2", "v1beta1", "zz_vpc_types.go"),
+						Type: ChangeTypeModified,
+						Diff: "--- a/apis/cluster/ec2/v1beta1/zz_vpc_types.go\n+++ b/apis/cluster/ec2/v1beta1/zz_vpc_types.go\n@@ -1 +1,3 @@\n package v1beta1\n+\n+type VPC struct{}\n",
+					},
+					{
+						Path: filepath.Join("internal", "controller", "cluster", "ec2", "subnet", "zz_controller.go"),
+						Type: ChangeTypeDeleted,
+						Diff: "--- a/internal/controller/cluster/ec2/subnet/zz_controller.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package subnet\n",
+					},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			generated, current := afero.NewMemMapFs(), afero.NewMemMapFs()
+			for p, c := range tc.generated {
+				if err := afero.WriteFile(generated, p, []byte(c), 0o600); err != nil {
+					t.Fatalf("cannot write the generated file: %v", err)
+				}
+			}
+			for p, c := range tc.current {
+				if err := afero.WriteFile(current, p, []byte(c), 0o600); err != nil {
+					t.Fatalf("cannot write the current file: %v", err)
+				}
+			}
+			got, err := diffGenerated(generated, current, rootDir)
+			if err != nil {
+				t.Fatalf("\n%s\ndiffGenerated(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.changes, got.Changes); diff != "" {
+				t.Errorf("\n%s\ndiffGenerated(...): -want changes, +got changes:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.stale, got.Stale()); diff != "" {
+				t.Errorf("\n%s\nStale(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	rootDir := newPipelineTestRootDir(t)
+	vpcTypes := filepath.Join(rootDir, "apis", "ec2", "v1alpha1", "zz_vpc_types.go")
+	checkout := readAll(t, afero.NewOsFs(), rootDir)
+	report, err := DryRun(newPipelineTestProvider(), nil, rootDir)
+	if err != nil {
+		t.Fatalf("DryRun(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(1, report.ExitCode()); diff != "" {
+		t.Errorf("DryRun(...): the missing generated files should be reported: -want exit code, +got exit code:\n%s", diff)
+	}
+	if diff := cmp.Diff(checkout, readAll(t, afero.NewOsFs(), rootDir)); diff != "" {
+		t.Errorf("DryRun(...): the checkout should not be modified: -want, +got:\n%s", diff)
+	}
+
+	Run(newPipelineTestProvider(), nil, rootDir)
+	report, err = DryRun(newPipelineTestProvider(), nil, rootDir)
+	if err != nil {
+		t.Fatalf("DryRun(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(0, report.ExitCode()); diff != "" {
+		t.Errorf("DryRun(...): the generated files should be up to date: -want exit code, +got exit code:\n%s", diff)
+	}
+
+	if err := os.WriteFile(vpcTypes, []byte("stale"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	checkout = readAll(t, afero.NewOsFs(), rootDir)
+	report, err = DryRun(newPipelineTestProvider(), nil, rootDir)
+	if err != nil {
+		t.Fatalf("DryRun(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(1, report.ExitCode()); diff != "" {
+		t.Errorf("DryRun(...): the stale generated files should be reported: -want exit code, +got exit code:\n%s", diff)
+	}
+	var changes []FileChange
+	for _, c := range report.Changes {
+		changes = append(changes, FileChange{Path: c.Path, Type: c.Type})
+	}
+	want := []FileChange{{Path: filepath.Join("apis", "ec2", "v1alpha1", "zz_vpc_types.go"), Type: ChangeTypeModified}}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Errorf("DryRun(...): -want changes, +got changes:\n%s", diff)
+	}
+	if diff := cmp.Diff(checkout, readAll(t, afero.NewOsFs(), rootDir)); diff != "" {
+		t.Errorf("DryRun(...): the checkout should not be modified: -want, +got:\n%s", diff)
+	}
+}
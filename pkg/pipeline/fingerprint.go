@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+const (
+	upjetModulePath = "github.com/crossplane/upjet/v2"
+)
+
+// generationFingerprints are the fingerprints of the inputs of the code
+// generation pipelines from a previous run. They are used to skip the
+// generation of the API groups whose inputs have not changed.
+type generationFingerprints struct {
+	// Generator is the fingerprint of the provider-wide inputs, such as the
+	// templates, that affect all the API groups.
+	Generator string `json:"generator"`
+	// Groups are the fingerprints of the API groups keyed by the group name.
+	Groups map[string]groupFingerprints `json:"groups"`
+}
+
+// groupFingerprints are the fingerprints of an API group.
+type groupFingerprints struct {
+	// Resources are the fingerprints of the resource configurations &
+	// Terraform schemas of the group keyed by the resource name.
+	Resources map[string]string `json:"resources"`
+	// Files are the paths of the files generated for the group relative to
+	// the hack directory.
+	Files []string `json:"files"`
+}
+
+// fingerprintsPath returns the path of the fingerprints file for the given
+// CRD scope.
+func fingerprintsPath(hackDir string, scope tjtypes.CRDScope) string {
+	return filepath.Join(hackDir, fmt.Sprintf("generation-fingerprints-%s.json", strings.ToLower(string(scope))))
+}
+
+// loadFingerprints loads the fingerprints from the given path in fs. An empty
+// set of fingerprints is returned if the file does not exist or cannot be
+// parsed so that all the API groups are generated.
+func loadFingerprints(fs afero.Fs, path string) *generationFingerprints {
+	f := &generationFingerprints{Groups: map[string]groupFingerprints{}}
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			fmt.Println(errors.Wrapf(err, "cannot read the generation fingerprints file %s, all API groups will be generated", path))
+		}
+		return f
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		fmt.Println(errors.Wrapf(err, "cannot parse the generation fingerprints file %s, all API groups will be generated", path))
+		return &generationFingerprints{Groups: map[string]groupFingerprints{}}
+	}
+	if f.Groups == nil {
+		f.Groups = map[string]groupFingerprints{}
+	}
+	return f
+}
+
+// store writes the fingerprints to the given path in fs.
+func (f *generationFingerprints) store(fs afero.Fs, path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal the generation fingerprints")
+	}
+	if err := fs.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "cannot mkdir directory of the file %s", path)
+	}
+	return errors.Wrapf(afero.WriteFile(fs, path, append(data, '\n'), 0o644), "cannot write the generation fingerprints file %s", path) //nolint:gosec // not sensitive
+}
+
+// upToDate returns true if the given group has been generated from the same
+// inputs in a previous run and all of its generated files still exist.
+func (f *generationFingerprints) upToDate(fs afero.Fs, hackDir, generator, group string, resources map[string]string) bool {
+	g, ok := f.Groups[group]
+	if !ok || f.Generator != generator || len(g.Files) == 0 || !maps.Equal(g.Resources, resources) {
+		return false
+	}
+	for _, p := range g.Files {
+		if ok, err := afero.Exists(fs, filepath.Join(hackDir, p)); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// generatorFingerprint returns the fingerprint of the provider-wide inputs of
+// the code generation pipelines for the API groups.
+func (r *PipelineRunner) generatorFingerprint(pc *config.Provider) (string, error) {
+	// the license header is always read from the OS file system by the
+	// file wrappers.
+	header, err := os.ReadFile(filepath.Join(r.DirHack, "boilerplate.go.txt"))
+	if err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrap(err, "cannot read the license header file")
+	}
+	return fingerprint(struct {
+		Upjet                 string
+		Header                []byte
+		Templates             []string
+		Scope                 tjtypes.CRDScope
+		ModulePathAPIs        string
+		ModulePathControllers string
+		ModulePath            string
+		FeaturesPackage       string
+		ShortName             string
+		ControllerTemplate    string
+		TerraformedTemplate   string
+		APIReferenceDocs      bool
+	}{
+		Upjet:  upjetVersion(),
+		Header: header,
+		Templates: []string{
+			templates.CRDTypesTemplate,
+			templates.GroupVersionInfoTemplate,
+			templates.TerraformedTemplate,
+			templates.ControllerTemplate,
+			templates.ConversionHubTemplate,
+			templates.ConversionSpokeTemplate,
+			templates.APIDocsTemplate,
+		},
+		Scope:                 r.Scope,
+		ModulePathAPIs:        r.ModulePathAPIs,
+		ModulePathControllers: r.ModulePathControllers,
+		ModulePath:            pc.ModulePath,
+		FeaturesPackage:       pc.FeaturesPackage,
+		ShortName:             pc.ShortName,
+		ControllerTemplate:    pc.ControllerTemplate,
+		TerraformedTemplate:   pc.TerraformedTemplate,
+		APIReferenceDocs:      pc.APIReferenceDocs,
+	}), nil
+}
+
+// referencedKind is the API group, version & kind of a referenced resource.
+type referencedKind struct {
+	Group   string
+	Version string
+	Kind    string
+}
+
+// resourceFingerprint returns the fingerprint of the given resource
+// configuration together with the API group, version & kind of the
+// resources it references. The referenced resources may be in other API
+// groups, whose changes must also regenerate the references to them.
+func resourceFingerprint(pc *config.Provider, r *config.Resource) string {
+	refs := make(map[string]referencedKind)
+	for _, ref := range r.References {
+		names := []string{ref.TerraformName}
+		for _, t := range ref.Targets {
+			names = append(names, t.TerraformName)
+		}
+		for _, n := range names {
+			if target, ok := pc.Resources[n]; ok {
+				refs[n] = referencedKind{Group: resourceGroup(pc, target), Version: target.Version, Kind: target.Kind}
+			}
+		}
+	}
+	return fingerprint(struct {
+		Resource   *config.Resource
+		References map[string]referencedKind
+	}{
+		Resource:   r,
+		References: refs,
+	})
+}
+
+// upjetVersion returns the version of the upjet module the generator is built
+// with so that upgrading upjet invalidates the fingerprints.
+func upjetVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if bi.Main.Path == upjetModulePath {
+		return bi.Main.Version
+	}
+	for _, d := range bi.Deps {
+		if d.Path != upjetModulePath {
+			continue
+		}
+		if d.Replace != nil {
+			return d.Replace.Path + "@" + d.Replace.Version + d.Replace.Sum
+		}
+		return d.Version + d.Sum
+	}
+	return ""
+}
+
+// fingerprint returns a deterministic digest of the given value. Functions
+// are represented by their names, and channels & unsafe pointers are
+// ignored.
+func fingerprint(v any) string {
+	h := &fingerprinter{
+		hash:  sha256.New(),
+		stack: map[visit]bool{},
+	}
+	h.write(reflect.ValueOf(v))
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
+
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// fingerprinter writes a deterministic representation of the values to a
+// hash.
+type fingerprinter struct {
+	hash hash.Hash
+	// stack is the set of the pointers being visited to break the cycles.
+	// The pointers are not memoized across the siblings so that the digest
+	// does not depend on the map iteration order.
+	stack map[visit]bool
+}
+
+func (f *fingerprinter) writeString(s string) {
+	f.hash.Write([]byte(strconv.Itoa(len(s)) + ":" + s)) //nolint:errcheck // hash.Hash never returns an error
+}
+
+func (f *fingerprinter) digest(v reflect.Value) []byte {
+	sub := &fingerprinter{
+		hash:  sha256.New(),
+		stack: f.stack,
+	}
+	sub.write(v)
+	return sub.hash.Sum(nil)
+}
+
+func (f *fingerprinter) write(v reflect.Value) { //nolint:gocyclo // a switch over the kinds
+	if !v.IsValid() {
+		f.writeString("invalid")
+		return
+	}
+	f.writeString(v.Kind().String())
+	switch v.Kind() { //nolint:exhaustive // the rest of the kinds are ignored
+	case reflect.Bool:
+		f.writeString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.writeString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.writeString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f.writeString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	case reflect.Complex64, reflect.Complex128:
+		f.writeString(strconv.FormatComplex(v.Complex(), 'g', -1, 128))
+	case reflect.String:
+		f.writeString(v.String())
+	case reflect.Func:
+		if v.IsNil() {
+			f.writeString("nil")
+			return
+		}
+		if fn := runtime.FuncForPC(v.Pointer()); fn != nil {
+			f.writeString(fn.Name())
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			f.writeString("nil")
+			return
+		}
+		k := visit{ptr: v.Pointer(), typ: v.Type()}
+		if f.stack[k] {
+			f.writeString("cycle")
+			return
+		}
+		f.stack[k] = true
+		f.write(v.Elem())
+		delete(f.stack, k)
+	case reflect.Interface:
+		if v.IsNil() {
+			f.writeString("nil")
+			return
+		}
+		f.writeString(v.Elem().Type().String())
+		f.write(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			f.writeString("nil")
+			return
+		}
+		f.writeString(strconv.Itoa(v.Len()))
+		for i := range v.Len() {
+			f.write(v.Index(i))
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			f.writeString("nil")
+			return
+		}
+		entries := make([][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries = append(entries, append(f.digest(iter.Key()), f.digest(iter.Value())...))
+		}
+		slices.SortFunc(entries, bytes.Compare)
+		f.writeString(strconv.Itoa(len(entries)))
+		for _, e := range entries {
+			f.hash.Write(e) //nolint:errcheck // hash.Hash never returns an error
+		}
+	case reflect.Struct:
+		t := v.Type()
+		f.writeString(t.String())
+		for i := range v.NumField() {
+			f.writeString(t.Field(i).Name)
+			f.write(v.Field(i))
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+type fingerprintTestNode struct {
+	Name     string
+	Labels   map[string]string
+	Next     *fingerprintTestNode
+	Callback func() string
+}
+
+func TestFingerprint(t *testing.T) {
+	newNode := func(labels map[string]string) *fingerprintTestNode {
+		n := &fingerprintTestNode{
+			Name:     "a",
+			Labels:   labels,
+			Callback: func() string { return "" },
+		}
+		// a cycle must not cause an infinite recursion
+		n.Next = n
+		return n
+	}
+	base := fingerprint(newNode(map[string]string{"k1": "v1", "k2": "v2", "k3": "v3"}))
+
+	cases := map[string]struct {
+		reason string
+		value  any
+		equal  bool
+	}{
+		"Same": {
+			reason: "Equal values should have the same fingerprint regardless of the map iteration order",
+			value:  newNode(map[string]string{"k3": "v3", "k2": "v2", "k1": "v1"}),
+			equal:  true,
+		},
+		"ChangedMapValue": {
+			reason: "A change in a map value should change the fingerprint",
+			value:  newNode(map[string]string{"k1": "v1", "k2": "v2", "k3": "v4"}),
+		},
+		"SwappedMapEntries": {
+			reason: "Swapping the keys & values should change the fingerprint",
+			value:  newNode(map[string]string{"v1": "k1", "v2": "k2", "v3": "k3"}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := fingerprint(tc.value) == base; got != tc.equal {
+				t.Errorf("\n%s\nfingerprint(...): want equal %t, got %t", tc.reason, tc.equal, got)
+			}
+		})
+	}
+}
+
+func TestResourceFingerprint(t *testing.T) {
+	newProvider := func(opts ...func(pc *config.Provider)) *config.Provider {
+		pc := newPipelineTestProvider()
+		for _, o := range opts {
+			o(pc)
+		}
+		return pc
+	}
+	fp := func(pc *config.Provider) string {
+		return resourceFingerprint(pc, pc.Resources["test_s3_bucket"])
+	}
+	base := fp(newProvider())
+
+	cases := map[string]struct {
+		reason string
+		pc     *config.Provider
+		equal  bool
+	}{
+		"Same": {
+			reason: "The same configurations should have the same fingerprint.",
+			pc:     newProvider(),
+			equal:  true,
+		},
+		"UnreferencedResourceChanged": {
+			reason: "A change in a resource that is not referenced should not change the fingerprint.",
+			pc: newProvider(func(pc *config.Provider) {
+				pc.Resources["test_ec2_subnet"].Kind = "Network"
+			}),
+			equal: true,
+		},
+		"ReferencedKindChanged": {
+			reason: "A change in the kind of a referenced resource should change the fingerprint.",
+			pc: newProvider(func(pc *config.Provider) {
+				pc.Resources["test_ec2_vpc"].Kind = "Network"
+			}),
+		},
+		"ReferencedVersionChanged": {
+			reason: "A change in the version of a referenced resource should change the fingerprint.",
+			pc: newProvider(func(pc *config.Provider) {
+				pc.Resources["test_ec2_vpc"].Version = "v1beta1"
+			}),
+		},
+		"ReferencedGroupChanged": {
+			reason: "A change in the group of a referenced resource should change the fingerprint.",
+			pc: newProvider(func(pc *config.Provider) {
+				pc.Resources["test_ec2_vpc"].ShortGroup = "vpc"
+			}),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := fp(tc.pc) == base; got != tc.equal {
+				t.Errorf("\n%s\nresourceFingerprint(...): want equal %t, got %t", tc.reason, tc.equal, got)
+			}
+		})
+	}
+}
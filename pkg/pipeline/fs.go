@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"bytes"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/muvaf/typewriter/pkg/wrapper"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	// prefixGeneratedFile is the file name prefix of the generated Go files.
+	prefixGeneratedFile = "zz_"
+)
+
+// writeFile wraps the given file with the given input and writes it to the
+// specified path in fs, creating the parent directories as needed.
+func writeFile(fs afero.Fs, f *wrapper.File, path string, input map[string]any, perm os.FileMode) error {
+	if err := fs.MkdirAll(filepath.Dir(path), perm); err != nil {
+		return errors.Wrap(err, "cannot mkdir directory of the file")
+	}
+	data, err := f.Wrap(input)
+	if err != nil {
+		return errors.Wrap(err, "cannot wrap file")
+	}
+	return errors.Wrap(afero.WriteFile(fs, path, data, perm), "cannot write file")
+}
+
+// formatGeneratedFiles runs goimports on the given generated Go files in fs.
+func formatGeneratedFiles(fs afero.Fs, paths []string) error {
+	for _, path := range paths {
+		if !strings.HasPrefix(filepath.Base(path), prefixGeneratedFile) || filepath.Ext(path) != ".go" {
+			continue
+		}
+		info, err := fs.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "cannot stat file %s", path)
+		}
+		src, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read file %s", path)
+		}
+		out, err := imports.Process(path, src, nil)
+		if err != nil {
+			return errors.Wrapf(err, "cannot run goimports for file %s", path)
+		}
+		// like goimports -w, do not rewrite the already formatted files.
+		if bytes.Equal(src, out) {
+			continue
+		}
+		if err := afero.WriteFile(fs, path, out, info.Mode()); err != nil {
+			return errors.Wrapf(err, "cannot write file %s", path)
+		}
+	}
+	return nil
+}
+
+// recordingFs is an afero.Fs that records the paths of the files opened for
+// writing. It is safe for concurrent use.
+type recordingFs struct {
+	afero.Fs
+
+	mu    sync.Mutex
+	paths map[string]struct{}
+}
+
+func newRecordingFs(fs afero.Fs) *recordingFs {
+	return &recordingFs{
+		Fs:    fs,
+		paths: make(map[string]struct{}),
+	}
+}
+
+func (r *recordingFs) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[filepath.Clean(name)] = struct{}{}
+}
+
+// Create creates the named file and records its path.
+func (r *recordingFs) Create(name string) (afero.File, error) {
+	r.record(name)
+	return r.Fs.Create(name)
+}
+
+// OpenFile opens the named file and records its path if it is opened for
+// writing.
+func (r *recordingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		r.record(name)
+	}
+	return r.Fs.OpenFile(name, flag, perm)
+}
+
+// written returns the sorted paths of the files opened for writing.
+func (r *recordingFs) written() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Sorted(maps.Keys(r.paths))
+}
+
+// isOsFs returns true if the given file system is the OS file system.
+func isOsFs(fs afero.Fs) bool {
+	for {
+		switch f := fs.(type) {
+		case *afero.OsFs:
+			return true
+		case *recordingFs:
+			fs = f.Fs
+		default:
+			return false
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 )
@@ -82,13 +83,13 @@ func (mu *lifecycleMarkerUpdater) updateVersionFile(group, version string, resou
 	}
 
 	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+	if _, err := mu.runner.fileSystem().Stat(filePath); os.IsNotExist(err) {
 		// File doesn't exist, skip (this is not necessarily an error)
 		return nil
 	}
 
 	// Read the file content
-	content, err := os.ReadFile(filePath) //nolint:gosec // filePath is validated above
+	content, err := afero.ReadFile(mu.runner.fileSystem(), filePath)
 	if err != nil {
 		return errors.Wrapf(err, "cannot read file %s", filePath)
 	}
@@ -113,7 +114,7 @@ func (mu *lifecycleMarkerUpdater) updateVersionFile(group, version string, resou
 	}
 
 	// Write back to file
-	if err := os.WriteFile(filePath, []byte(updatedContent), 0600); err != nil {
+	if err := afero.WriteFile(mu.runner.fileSystem(), filePath, []byte(updatedContent), 0600); err != nil {
 		return errors.Wrapf(err, "cannot write file %s", filePath)
 	}
 
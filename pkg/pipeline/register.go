@@ -11,6 +11,7 @@ import (
 
 	"github.com/muvaf/typewriter/pkg/wrapper"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
 )
@@ -21,6 +22,7 @@ func NewRegisterGenerator(apiDir, hackDir, apiModulePath string) *RegisterGenera
 		LocalDirectoryPath: apiDir,
 		LicenseHeaderPath:  filepath.Join(hackDir, "boilerplate.go.txt"),
 		ModulePath:         apiModulePath,
+		fs:                 afero.NewOsFs(),
 	}
 }
 
@@ -29,6 +31,8 @@ type RegisterGenerator struct {
 	LocalDirectoryPath string
 	ModulePath         string
 	LicenseHeaderPath  string
+
+	fs afero.Fs
 }
 
 // Generate writes the register file with the content produced using given
@@ -49,5 +53,5 @@ func (rg *RegisterGenerator) Generate(versionPkgList []string) error {
 		"Aliases": aliases,
 	}
 	filePath := filepath.Join(rg.LocalDirectoryPath, "zz_register.go")
-	return errors.Wrap(registerFile.Write(filePath, vars, os.ModePerm), "cannot write register file")
+	return errors.Wrap(writeFile(rg.fs, registerFile, filePath, vars, os.ModePerm), "cannot write register file")
 }
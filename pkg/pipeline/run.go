@@ -6,12 +6,16 @@ package pipeline
 
 import (
 	"fmt"
-	"os/exec"
+	"maps"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 	"github.com/crossplane/upjet/v2/pkg/examples"
@@ -19,6 +23,15 @@ import (
 	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
 )
 
+const (
+	// dataSourceKeyPrefix is the prefix of the keys of the data sources in
+	// the resource groups.
+	dataSourceKeyPrefix = "data."
+	// ephemeralResourceKeyPrefix is the prefix of the keys of the ephemeral
+	// resources in the resource groups.
+	ephemeralResourceKeyPrefix = "ephemeral."
+)
+
 type terraformedInput struct {
 	*config.Resource
 	ParametersTypeName string
@@ -29,6 +42,22 @@ type terraformedInput struct {
 // configuration for namespaced resources is optional, if it isn't provided then
 // this function will only generate cluster scoped resources.
 func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
+	run(pcCluster, pcNamespace, rootDir, afero.NewOsFs())
+}
+
+// run runs the code generation pipelines writing the generated files to the
+// given file system.
+func run(pcCluster, pcNamespace *config.Provider, rootDir string, fs afero.Fs) {
+	// Validate the provider configurations before writing any files so that
+	// all configuration problems are reported at once.
+	for _, pc := range []*config.Provider{pcCluster, pcNamespace} {
+		if pc == nil {
+			continue
+		}
+		if err := pc.Validate(); err != nil {
+			panic(err)
+		}
+	}
 	var groups []string
 	if pcNamespace == nil {
 		// namespaced resource generation is not enabled, generate only cluster scoped resources
@@ -37,11 +66,13 @@ func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
 			DirControllers: filepath.Join(rootDir, "internal", "controller"),
 			DirExamples:    filepath.Join(rootDir, "examples-generated"),
 			DirHack:        filepath.Join(rootDir, "hack"),
+			DirDocs:        filepath.Join(rootDir, "docs", "api"),
 
 			ModulePathAPIs:        filepath.Join(pcCluster.ModulePath, "apis"),
 			ModulePathControllers: filepath.Join(pcCluster.ModulePath, "internal", "controller"),
 
-			Scope: tjtypes.CRDScopeCluster,
+			Scope:      tjtypes.CRDScopeCluster,
+			FileSystem: fs,
 			// Register built-in post-generation hooks
 			postGenerationHooks: []postGenerationHook{
 				newStorageVersionMarkerUpdateHook(),
@@ -51,7 +82,9 @@ func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
 
 		groups = cluster.Run(pcCluster)
 		if len(pcCluster.MainTemplate) > 0 {
-			if err := NewMainGenerator(filepath.Join(rootDir, "cmd", "provider"), pcCluster.MainTemplate).Generate(groups); err != nil {
+			mainGen := NewMainGenerator(filepath.Join(rootDir, "cmd", "provider"), pcCluster.MainTemplate)
+			mainGen.fs = fs
+			if err := mainGen.Generate(groups); err != nil {
 				panic(errors.Wrap(err, "cannot generate main.go"))
 			}
 		}
@@ -64,11 +97,13 @@ func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
 		DirControllers: filepath.Join(rootDir, "internal", "controller", "cluster"),
 		DirExamples:    filepath.Join(rootDir, "examples-generated", "cluster"),
 		DirHack:        filepath.Join(rootDir, "hack"),
+		DirDocs:        filepath.Join(rootDir, "docs", "api", "cluster"),
 
 		ModulePathAPIs:        filepath.Join(pcCluster.ModulePath, "apis", "cluster"),
 		ModulePathControllers: filepath.Join(pcCluster.ModulePath, "internal", "controller", "cluster"),
 
-		Scope: tjtypes.CRDScopeCluster,
+		Scope:      tjtypes.CRDScopeCluster,
+		FileSystem: fs,
 		// Register built-in post-generation hooks
 		postGenerationHooks: []postGenerationHook{
 			newStorageVersionMarkerUpdateHook(),
@@ -81,11 +116,13 @@ func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
 		DirControllers: filepath.Join(rootDir, "internal", "controller", "namespaced"),
 		DirExamples:    filepath.Join(rootDir, "examples-generated", "namespaced"),
 		DirHack:        filepath.Join(rootDir, "hack"),
+		DirDocs:        filepath.Join(rootDir, "docs", "api", "namespaced"),
 
 		ModulePathAPIs:        filepath.Join(pcNamespace.ModulePath, "apis", "namespaced"),
 		ModulePathControllers: filepath.Join(pcNamespace.ModulePath, "internal", "controller", "namespaced"),
 
-		Scope: tjtypes.CRDScopeNamespaced,
+		Scope:      tjtypes.CRDScopeNamespaced,
+		FileSystem: fs,
 		// Register built-in post-generation hooks
 		postGenerationHooks: []postGenerationHook{
 			newStorageVersionMarkerUpdateHook(),
@@ -98,7 +135,9 @@ func Run(pcCluster, pcNamespace *config.Provider, rootDir string) {
 	groups = cluster.Run(pcCluster)
 	_ = namespaced.Run(pcNamespace)
 	if len(pcCluster.MainTemplate) > 0 {
-		if err := NewMainGenerator(filepath.Join(rootDir, "cmd", "provider"), pcCluster.MainTemplate).Generate(groups); err != nil {
+		mainGen := NewMainGenerator(filepath.Join(rootDir, "cmd", "provider"), pcCluster.MainTemplate)
+		mainGen.fs = fs
+		if err := mainGen.Generate(groups); err != nil {
 			panic(errors.Wrap(err, "cannot generate main.go"))
 		}
 	}
@@ -109,15 +148,29 @@ type PipelineRunner struct {
 	DirControllers string
 	DirExamples    string
 	DirHack        string
+	// DirDocs is the directory the API reference documentation pages are
+	// written to if they are enabled with config.WithAPIReferenceDocs.
+	DirDocs string
 
 	ModulePathAPIs        string
 	ModulePathControllers string
 
 	Scope tjtypes.CRDScope
 
+	// FileSystem is the file system the generated files are written to.
+	// Defaults to the OS file system.
+	FileSystem afero.Fs
+
 	postGenerationHooks []postGenerationHook
 }
 
+func (r *PipelineRunner) fileSystem() afero.Fs {
+	if r.FileSystem == nil {
+		return afero.NewOsFs()
+	}
+	return r.FileSystem
+}
+
 func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 	// Note(turkenh): nolint reasoning - this is the main function of the code
 	// generation pipeline. We didn't want to split it into multiple functions
@@ -127,11 +180,8 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 	// An example entry in the tree would be:
 	// ec2.aws.upbound.io -> v1beta1 -> aws_vpc
 	resourcesGroups := map[string]map[string]map[string]*config.Resource{}
-	for name, resource := range pc.Resources {
-		group := pc.RootGroup
-		if resource.ShortGroup != "" {
-			group = strings.ToLower(resource.ShortGroup) + "." + pc.RootGroup
-		}
+	addToGroups := func(name string, resource *config.Resource) {
+		group := resourceGroup(pc, resource)
 		if len(resourcesGroups[group]) == 0 {
 			resourcesGroups[group] = map[string]map[string]*config.Resource{}
 		}
@@ -140,8 +190,22 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 		}
 		resourcesGroups[group][resource.Version][name] = resource
 	}
+	for name, resource := range pc.Resources {
+		addToGroups(name, resource)
+	}
+	// Data sources & ephemeral resources are keyed with the "data." &
+	// "ephemeral." prefixes, as in Terraform addresses, so that they do not
+	// collide with the resources of the same name, e.g., data.aws_ami &
+	// aws_ami.
+	for name, dataSource := range pc.DataSources {
+		addToGroups(dataSourceKeyPrefix+name, dataSource.Resource)
+	}
+	for name, ephemeralResource := range pc.EphemeralResources {
+		addToGroups(ephemeralResourceKeyPrefix+name, ephemeralResource.Resource)
+	}
 
-	exampleGeneratorOpts := []examples.GeneratorOption{examples.WithCRDScope(r.Scope), examples.WithNamespace(pc.ExampleManifestConfiguration.ManagedResourceNamespace)}
+	fs := newRecordingFs(r.fileSystem())
+	exampleGeneratorOpts := []examples.GeneratorOption{examples.WithCRDScope(r.Scope), examples.WithNamespace(pc.ExampleManifestConfiguration.ManagedResourceNamespace), examples.WithFileSystem(fs)}
 	if r.Scope == tjtypes.CRDScopeNamespaced {
 		exampleGeneratorOpts = append(exampleGeneratorOpts, examples.WithLocalSecretRefs())
 	}
@@ -187,97 +251,78 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 		}
 	}
 
-	count := 0
-	for group, versions := range resourcesGroups {
-		shortGroup := strings.Split(group, ".")[0]
-		for version, resources := range versions {
-			versionGen := NewVersionGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, version)
-			crdGen := NewCRDGenerator(versionGen.Package(), r.DirAPIs, r.DirHack, pc.ShortName, group, version, r.Scope)
-			tfGen := NewTerraformedGenerator(versionGen.Package(), r.DirAPIs, r.DirHack, group, version, WithTerraformedTemplate(pc.TerraformedTemplate))
-			ctrlGen := NewControllerGenerator(r.DirControllers, r.DirHack, r.ModulePathControllers, group, WithControllerTemplate(pc.ControllerTemplate))
-
-			if err := versionGen.InsertPreviousObjects(versions); err != nil {
-				fmt.Println(errors.Wrapf(err, "cannot insert type definitions from the previous versions into the package scope for group %q", group))
-			}
+	featuresPkgPath := ""
+	if pc.FeaturesPackage != "" {
+		featuresPkgPath = filepath.Join(pc.ModulePath, pc.FeaturesPackage)
+	}
+	var fingerprints *generationFingerprints
+	generatorFingerprint := ""
+	if pc.IncrementalGeneration {
+		fingerprints = loadFingerprints(fs, fingerprintsPath(r.DirHack, r.Scope))
+		fp, err := r.generatorFingerprint(pc)
+		if err != nil {
+			panic(errors.Wrap(err, "cannot compute the generator fingerprint"))
+		}
+		generatorFingerprint = fp
+	}
 
-			var tfResources []*terraformedInput
-			for _, name := range sortedResources(resources) {
-				paramTypeName, err := crdGen.Generate(resources[name])
-				if err != nil {
-					panic(errors.Wrapf(err, "cannot generate crd for resource %s", name))
-				}
-				tfResources = append(tfResources, &terraformedInput{
-					Resource:           resources[name],
-					ParametersTypeName: paramTypeName,
-				})
-
-				featuresPkgPath := ""
-				if pc.FeaturesPackage != "" {
-					featuresPkgPath = filepath.Join(pc.ModulePath, pc.FeaturesPackage)
-				}
-				watchVersionGen := versionGen
-				//nolint:staticcheck // still handling deprecated field behavior
-				if len(resources[name].ControllerReconcileVersion) != 0 {
-					watchVersionGen = NewVersionGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, resources[name].ControllerReconcileVersion)
-				}
-				ctrlPkgPath, err := ctrlGen.Generate(resources[name], watchVersionGen.Package().Path(), featuresPkgPath)
-				if err != nil {
-					panic(errors.Wrapf(err, "cannot generate controller for resource %s", name))
-				}
-				controllerPkgMap[shortGroup] = append(controllerPkgMap[shortGroup], ctrlPkgPath)
-				// if the controller is not already added as a base package controller
-				// to the monolith provider.
-				if len(pc.BasePackages.ControllerMap[strings.TrimPrefix(ctrlPkgPath, strings.TrimSuffix(r.ModulePathControllers, "/")+"/")]) == 0 {
-					controllerPkgMap[config.PackageNameMonolith] = append(controllerPkgMap[config.PackageNameMonolith], ctrlPkgPath)
-				}
-				if err := exampleGen.Generate(group, version, resources[name]); err != nil {
-					panic(errors.Wrapf(err, "cannot generate example manifest for resource %s", name))
-				}
-				count++
-			}
+	// API groups are independent of each other, so they are generated
+	// concurrently. The results are then merged in the order of the group
+	// names so that the generated files are deterministic.
+	groupNames := slices.Sorted(maps.Keys(resourcesGroups))
+	results := make([]*groupResult, len(groupNames))
+	var eg errgroup.Group
+	eg.SetLimit(generationParallelism(pc))
+	for i, group := range groupNames {
+		eg.Go(func() error {
+			var err error
+			results[i], err = r.generateGroup(pc, group, resourcesGroups[group], fs, featuresPkgPath, fingerprints, generatorFingerprint)
+			return err
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		panic(err)
+	}
 
-			if err := tfGen.Generate(tfResources, version); err != nil {
-				panic(errors.Wrapf(err, "cannot generate terraformed for resource %s", group))
-			}
-			if err := versionGen.Generate(); err != nil {
-				panic(errors.Wrap(err, "cannot generate version files"))
+	count, skipped := 0, 0
+	webhookPkgs := make(map[string]bool)
+	newFingerprints := &generationFingerprints{
+		Generator: generatorFingerprint,
+		Groups:    make(map[string]groupFingerprints, len(groupNames)),
+	}
+	for i, group := range groupNames {
+		res := results[i]
+		shortGroup := strings.Split(group, ".")[0]
+		for _, ctrlPkgPath := range res.controllerPackages {
+			controllerPkgMap[shortGroup] = append(controllerPkgMap[shortGroup], ctrlPkgPath)
+			// if the controller is not already added as a base package controller
+			// to the monolith provider.
+			if len(pc.BasePackages.ControllerMap[strings.TrimPrefix(ctrlPkgPath, strings.TrimSuffix(r.ModulePathControllers, "/")+"/")]) == 0 {
+				controllerPkgMap[config.PackageNameMonolith] = append(controllerPkgMap[config.PackageNameMonolith], ctrlPkgPath)
 			}
-			p := versionGen.Package().Path()
-			apiVersionPkgList = append(apiVersionPkgList, p)
 		}
-		conversionHubGen := NewConversionNodeGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, "zz_generated.conversion_hubs.go", templates.ConversionHubTemplate,
-			func(c *config.Resource, fileAPIVersion string) bool {
-				// if this is the hub version, then mark it as a hub
-				return c.CRDHubVersion() == fileAPIVersion
-			})
-		if err := conversionHubGen.Generate(versions); err != nil {
-			panic(errors.Wrapf(err, "cannot generate the conversion.Hub function for the resource group %q", group))
+		apiVersionPkgList = append(apiVersionPkgList, res.apiVersionPackages...)
+		for _, p := range res.webhookPackages {
+			webhookPkgs[p] = true
 		}
-		conversionSpokeGen := NewConversionNodeGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, "zz_generated.conversion_spokes.go", templates.ConversionSpokeTemplate,
-			func(c *config.Resource, fileAPIVersion string) bool {
-				// if not the hub version, mark it as a spoke
-				return c.CRDHubVersion() != fileAPIVersion
-			})
-		if err := conversionSpokeGen.Generate(versions); err != nil {
-			panic(errors.Wrapf(err, "cannot generate the conversion.Convertible functions for the resource group %q", group))
+		if res.skipped {
+			skipped++
+		} else {
+			count += res.count
 		}
+		newFingerprints.Groups[group] = res.fingerprints
 
-		base := filepath.Join(r.ModulePathAPIs, shortGroup)
-		for _, versions := range resourcesGroups {
-			for _, resources := range versions {
-				for _, r := range resources {
-					// if there are spoke versions for the given group.Kind
-					if spokeVersions := conversionSpokeGen.nodeVersionsMap[fmt.Sprintf("%s.%s", r.ShortGroup, r.Kind)]; spokeVersions != nil {
-						for _, sv := range spokeVersions {
-							apiVersionPkgList = append(apiVersionPkgList, filepath.Join(base, sv))
-						}
-					}
-					// if there are hub versions for the given group.Kind
-					if hubVersions := conversionHubGen.nodeVersionsMap[fmt.Sprintf("%s.%s", r.ShortGroup, r.Kind)]; hubVersions != nil {
-						for _, sv := range hubVersions {
-							apiVersionPkgList = append(apiVersionPkgList, filepath.Join(base, sv))
-						}
-					}
+		versions := resourcesGroups[group]
+		for _, version := range slices.Sorted(maps.Keys(versions)) {
+			resources := versions[version]
+			for _, name := range sortedResources(resources) {
+				// we don't have registry metadata for the data sources &
+				// ephemeral resources
+				if resources[name].IsDataSource() || resources[name].IsEphemeralResource() {
+					continue
+				}
+				if err := exampleGen.Generate(group, version, resources[name]); err != nil {
+					panic(errors.Wrapf(err, "cannot generate example manifest for resource %s", name))
 				}
 			}
 		}
@@ -287,33 +332,36 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 		panic(errors.Wrapf(err, "cannot store examples"))
 	}
 
-	if err := NewRegisterGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs).Generate(apiVersionPkgList); err != nil {
+	if pc.APIReferenceDocs {
+		docsIndexGen := NewDocsIndexGenerator(r.DirDocs)
+		docsIndexGen.fs = fs
+		if err := docsIndexGen.Generate(resourcesGroups); err != nil {
+			panic(errors.Wrap(err, "cannot generate the API reference documentation index"))
+		}
+	}
+
+	registerGen := NewRegisterGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs)
+	registerGen.fs = fs
+	if err := registerGen.Generate(apiVersionPkgList); err != nil {
 		panic(errors.Wrap(err, "cannot generate register file"))
 	}
 
 	monolith := len(pc.MainTemplate) == 0
-	if err := NewSetupGenerator(r.DirControllers, r.DirHack, r.ModulePathAPIs, WithSetupAggregatorTemplate(pc.SetupAggregatorTemplate)).Generate(controllerPkgMap, monolith); err != nil {
+	setupGen := NewSetupGenerator(r.DirControllers, r.DirHack, r.ModulePathAPIs, WithSetupAggregatorTemplate(pc.SetupAggregatorTemplate), WithValidatingWebhookPackages(webhookPkgs))
+	setupGen.fs = fs
+	if err := setupGen.Generate(controllerPkgMap, monolith); err != nil {
 		panic(errors.Wrap(err, "cannot generate setup file"))
 	}
 
-	// NOTE(muvaf): gosec linter requires that the whole command is hard-coded.
-	// So, we set the directory of the command instead of passing in the directory
-	// as an argument to "find".
-	fmt.Printf("Running goimports on apis folder with scope %s\n", r.Scope)
-	apisCmd := exec.Command("bash", "-c", "goimports -w $(find . -iname 'zz_*')") //nolint:noctx
-	apisCmd.Dir = filepath.Clean(r.DirAPIs)
-	if out, err := apisCmd.CombinedOutput(); err != nil {
-		panic(errors.Wrap(err, "cannot run goimports for apis folder: "+string(out)))
-	}
-
-	fmt.Printf("Running goimports on controller folder with scope %s\n", r.Scope)
-	ctrlCmd := exec.Command("bash", "-c", "goimports -w $(find . -iname 'zz_*')") //nolint:noctx
-	ctrlCmd.Dir = filepath.Clean(r.DirControllers)
-	if out, err := ctrlCmd.CombinedOutput(); err != nil {
-		panic(errors.Wrap(err, "cannot run goimports for controller folder: "+string(out)))
+	fmt.Printf("Running goimports on the generated files with scope %s\n", r.Scope)
+	if err := formatGeneratedFiles(fs, fs.written()); err != nil {
+		panic(errors.Wrap(err, "cannot run goimports for the generated files"))
 	}
 
 	fmt.Printf("\nGenerated %d resources with scope %s!\n", count, r.Scope)
+	if skipped > 0 {
+		fmt.Printf("Skipped %d unchanged API group(s) with scope %s.\n", skipped, r.Scope)
+	}
 
 	// Run post-generation hooks
 	if len(r.postGenerationHooks) > 0 {
@@ -326,6 +374,12 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 		fmt.Println("Post-generation hooks completed successfully!")
 	}
 
+	if pc.IncrementalGeneration {
+		if err := newFingerprints.store(fs, fingerprintsPath(r.DirHack, r.Scope)); err != nil {
+			panic(errors.Wrap(err, "cannot store the generation fingerprints"))
+		}
+	}
+
 	groups := make([]string, 0, len(controllerPkgMap))
 	for g := range controllerPkgMap {
 		groups = append(groups, g)
@@ -333,6 +387,187 @@ func (r *PipelineRunner) Run(pc *config.Provider) []string { //nolint:gocyclo
 	return groups
 }
 
+// groupResult is the result of the code generation for an API group.
+type groupResult struct {
+	apiVersionPackages []string
+	controllerPackages []string
+	webhookPackages    []string
+	count              int
+	skipped            bool
+	fingerprints       groupFingerprints
+}
+
+// resourceGroup returns the API group of the given resource.
+func resourceGroup(pc *config.Provider, r *config.Resource) string {
+	if r.ShortGroup == "" {
+		return pc.RootGroup
+	}
+	return strings.ToLower(r.ShortGroup) + "." + pc.RootGroup
+}
+
+// generateGroup generates the API versions, controllers & conversion
+// functions of the given API group. If the fingerprints of a previous run are
+// given and the group has not changed since then, only the conversion
+// functions are generated.
+func (r *PipelineRunner) generateGroup(pc *config.Provider, group string, versions map[string]map[string]*config.Resource, fs afero.Fs, featuresPkgPath string, prev *generationFingerprints, generatorFingerprint string) (*groupResult, error) { //nolint:gocyclo
+	// Note: nolint reasoning - this is the body of the main loop of the code
+	// generation pipeline and we keep the steps together for readability.
+	gfs := newRecordingFs(fs)
+	res := &groupResult{}
+	var resourceFingerprints map[string]string
+	if prev != nil {
+		resourceFingerprints = make(map[string]string)
+		for _, resources := range versions {
+			for name, rc := range resources {
+				resourceFingerprints[name] = resourceFingerprint(pc, rc)
+			}
+		}
+		res.skipped = prev.upToDate(fs, r.DirHack, generatorFingerprint, group, resourceFingerprints)
+	}
+
+	for _, version := range slices.Sorted(maps.Keys(versions)) {
+		resources := versions[version]
+		versionGen := NewVersionGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, version)
+		crdGen := NewCRDGenerator(versionGen.Package(), r.DirAPIs, r.DirHack, pc.ShortName, group, version, r.Scope)
+		tfGen := NewTerraformedGenerator(versionGen.Package(), r.DirAPIs, r.DirHack, group, version, WithTerraformedTemplate(pc.TerraformedTemplate))
+		ctrlGen := NewControllerGenerator(r.DirControllers, r.DirHack, r.ModulePathControllers, group, WithControllerTemplate(pc.ControllerTemplate))
+		versionGen.fs, crdGen.fs, tfGen.fs, ctrlGen.fs = gfs, gfs, gfs, gfs
+		var docsGen *DocsGenerator
+		if pc.APIReferenceDocs {
+			docsGen = NewDocsGenerator(r.DirDocs, r.DirExamples, pc, group, version, r.Scope)
+			docsGen.fs = gfs
+		}
+		res.apiVersionPackages = append(res.apiVersionPackages, versionGen.Package().Path())
+		res.count += len(resources)
+
+		if res.skipped {
+			for _, name := range sortedResources(resources) {
+				// the example generator expects the prepared schemas.
+				prepareTerraformSchema(resources[name])
+				res.controllerPackages = append(res.controllerPackages, ctrlGen.PackagePath(resources[name]))
+				if hasValidatingWebhook(resources[name]) {
+					res.webhookPackages = append(res.webhookPackages, ctrlGen.PackagePath(resources[name]))
+				}
+			}
+			continue
+		}
+
+		if err := versionGen.InsertPreviousObjects(versions); err != nil {
+			fmt.Println(errors.Wrapf(err, "cannot insert type definitions from the previous versions into the package scope for group %q", group))
+		}
+
+		var tfResources []*terraformedInput
+		for _, name := range sortedResources(resources) {
+			paramTypeName, err := crdGen.Generate(resources[name])
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot generate crd for resource %s", name)
+			}
+			if docsGen != nil {
+				if err := docsGen.Generate(resources[name], crdGen.Generated); err != nil {
+					return nil, errors.Wrapf(err, "cannot generate the API reference documentation for resource %s", name)
+				}
+			}
+			tfResources = append(tfResources, &terraformedInput{
+				Resource:           resources[name],
+				ParametersTypeName: paramTypeName,
+			})
+
+			watchVersionGen := versionGen
+			//nolint:staticcheck // still handling deprecated field behavior
+			if len(resources[name].ControllerReconcileVersion) != 0 {
+				watchVersionGen = NewVersionGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, resources[name].ControllerReconcileVersion)
+				watchVersionGen.fs = gfs
+			}
+			ctrlPkgPath, err := ctrlGen.Generate(resources[name], watchVersionGen.Package().Path(), featuresPkgPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot generate controller for resource %s", name)
+			}
+			res.controllerPackages = append(res.controllerPackages, ctrlPkgPath)
+			if hasValidatingWebhook(resources[name]) {
+				res.webhookPackages = append(res.webhookPackages, ctrlPkgPath)
+			}
+		}
+
+		if err := tfGen.Generate(tfResources, version); err != nil {
+			return nil, errors.Wrapf(err, "cannot generate terraformed for resource %s", group)
+		}
+		if err := versionGen.Generate(); err != nil {
+			return nil, errors.Wrap(err, "cannot generate version files")
+		}
+	}
+
+	conversionHubGen := NewConversionNodeGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, "zz_generated.conversion_hubs.go", templates.ConversionHubTemplate,
+		func(c *config.Resource, fileAPIVersion string) bool {
+			// if this is the hub version, then mark it as a hub
+			return c.CRDHubVersion() == fileAPIVersion
+		})
+	conversionHubGen.fs = gfs
+	if err := conversionHubGen.Generate(versions); err != nil {
+		return nil, errors.Wrapf(err, "cannot generate the conversion.Hub function for the resource group %q", group)
+	}
+	conversionSpokeGen := NewConversionNodeGenerator(r.DirAPIs, r.DirHack, r.ModulePathAPIs, group, "zz_generated.conversion_spokes.go", templates.ConversionSpokeTemplate,
+		func(c *config.Resource, fileAPIVersion string) bool {
+			// if not the hub version, mark it as a spoke
+			return c.CRDHubVersion() != fileAPIVersion
+		})
+	conversionSpokeGen.fs = gfs
+	if err := conversionSpokeGen.Generate(versions); err != nil {
+		return nil, errors.Wrapf(err, "cannot generate the conversion.Convertible functions for the resource group %q", group)
+	}
+
+	base := filepath.Join(r.ModulePathAPIs, strings.Split(group, ".")[0])
+	for _, version := range slices.Sorted(maps.Keys(versions)) {
+		for _, name := range sortedResources(versions[version]) {
+			rc := versions[version][name]
+			// if there are spoke versions for the given group.Kind
+			for _, sv := range conversionSpokeGen.nodeVersionsMap[fmt.Sprintf("%s.%s", rc.ShortGroup, rc.Kind)] {
+				res.apiVersionPackages = append(res.apiVersionPackages, filepath.Join(base, sv))
+			}
+			// if there are hub versions for the given group.Kind
+			for _, hv := range conversionHubGen.nodeVersionsMap[fmt.Sprintf("%s.%s", rc.ShortGroup, rc.Kind)] {
+				res.apiVersionPackages = append(res.apiVersionPackages, filepath.Join(base, hv))
+			}
+		}
+	}
+
+	if prev != nil {
+		res.fingerprints = groupFingerprints{
+			Resources: resourceFingerprints,
+			Files:     prev.Groups[group].Files,
+		}
+		if !res.skipped {
+			files, err := relativePaths(r.DirHack, gfs.written())
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot record the generated files of the resource group %q", group)
+			}
+			res.fingerprints.Files = files
+		}
+	}
+	return res, nil
+}
+
+// generationParallelism returns the maximum number of API groups to generate
+// concurrently.
+func generationParallelism(pc *config.Provider) int {
+	if pc.GenerationParallelism > 0 {
+		return pc.GenerationParallelism
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// relativePaths returns the given paths relative to the base directory.
+func relativePaths(base string, paths []string) ([]string, error) {
+	result := make([]string, 0, len(paths))
+	for _, p := range paths {
+		rel, err := filepath.Rel(base, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get the path of %s relative to %s", p, base)
+		}
+		result = append(result, rel)
+	}
+	return result, nil
+}
+
 // TODO(negz): This could be slices.Sorted(maps.Keys(m)) with Go v1.24+
 
 func sortedResources(m map[string]*config.Resource) []string {
@@ -0,0 +1,170 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	tjtypes "github.com/crossplane/upjet/v2/pkg/types"
+)
+
+func newPipelineTestProvider(opts ...config.ProviderOption) *config.Provider {
+	newResource := func(name string) *config.Resource {
+		return config.DefaultResource(name, &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"arn": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		}, nil, nil)
+	}
+	pc := &config.Provider{
+		RootGroup:  "test.upbound.io",
+		ShortName:  "test",
+		ModulePath: "github.com/upbound/provider-test",
+		Resources: map[string]*config.Resource{
+			"test_ec2_vpc":    newResource("test_ec2_vpc"),
+			"test_ec2_subnet": newResource("test_ec2_subnet"),
+			"test_s3_bucket":  newResource("test_s3_bucket"),
+		},
+	}
+	// the s3 group references the ec2 group.
+	pc.Resources["test_s3_bucket"].TerraformResource.Schema["vpc_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	pc.Resources["test_s3_bucket"].References = config.References{
+		"vpc_id": config.Reference{TerraformName: "test_ec2_vpc"},
+	}
+	for _, o := range opts {
+		o(pc)
+	}
+	return pc
+}
+
+func runPipelineTest(t *testing.T, rootDir string, fs afero.Fs, pc *config.Provider) {
+	t.Helper()
+	r := &PipelineRunner{
+		DirAPIs:               filepath.Join(rootDir, "apis", "cluster"),
+		DirControllers:        filepath.Join(rootDir, "internal", "controller", "cluster"),
+		DirExamples:           filepath.Join(rootDir, "examples-generated", "cluster"),
+		DirHack:               filepath.Join(rootDir, "hack"),
+		ModulePathAPIs:        filepath.Join(pc.ModulePath, "apis", "cluster"),
+		ModulePathControllers: filepath.Join(pc.ModulePath, "internal", "controller", "cluster"),
+		Scope:                 tjtypes.CRDScopeCluster,
+		FileSystem:            fs,
+	}
+	r.Run(pc)
+}
+
+func readAll(t *testing.T, fs afero.Fs, rootDir string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := afero.Walk(fs, rootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		b, err := afero.ReadFile(fs, path)
+		files[path] = string(b)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("cannot read the generated files: %v", err)
+	}
+	return files
+}
+
+// newPipelineTestRootDir returns a temporary root directory with the license
+// header the generators read from the OS file system.
+func newPipelineTestRootDir(t *testing.T) string {
+	t.Helper()
+	rootDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(rootDir, "hack"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rootDir, "hack", "boilerplate.go.txt"), []byte("/*\nCopyright 2025 The Crossplane Authors.\n*/\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return rootDir
+}
+
+func TestPipelineRunnerRunDeterministic(t *testing.T) {
+	rootDir := newPipelineTestRootDir(t)
+
+	sequential := afero.NewMemMapFs()
+	runPipelineTest(t, rootDir, sequential, newPipelineTestProvider(config.WithGenerationParallelism(1)))
+	parallel := afero.NewMemMapFs()
+	runPipelineTest(t, rootDir, parallel, newPipelineTestProvider(config.WithGenerationParallelism(8)))
+	if diff := cmp.Diff(readAll(t, sequential, rootDir), readAll(t, parallel, rootDir)); diff != "" {
+		t.Errorf("Run(...): parallel generation should produce the same files as the sequential generation: -want, +got:\n%s", diff)
+	}
+}
+
+func TestPipelineRunnerRun(t *testing.T) {
+	rootDir := newPipelineTestRootDir(t)
+
+	fs := afero.NewMemMapFs()
+	runPipelineTest(t, rootDir, fs, newPipelineTestProvider(config.WithIncrementalGeneration(), config.WithGenerationParallelism(2)))
+	if ok, _ := afero.Exists(fs, fingerprintsPath(filepath.Join(rootDir, "hack"), tjtypes.CRDScopeCluster)); !ok {
+		t.Fatal("Run(...): the generation fingerprints should be stored")
+	}
+	want := readAll(t, fs, rootDir)
+	vpcTypes := filepath.Join(rootDir, "apis", "cluster", "ec2", "v1alpha1", "zz_vpc_types.go")
+	bucketTypes := filepath.Join(rootDir, "apis", "cluster", "s3", "v1alpha1", "zz_bucket_types.go")
+	for _, p := range []string{vpcTypes, bucketTypes} {
+		if err := afero.WriteFile(fs, p, []byte("stale"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// modify the configuration of the ec2 group only.
+	modifyVPC := func(pc *config.Provider) {
+		pc.Resources["test_ec2_vpc"].UseAsync = false
+	}
+	runPipelineTest(t, rootDir, fs, newPipelineTestProvider(config.WithIncrementalGeneration(), modifyVPC))
+	got := readAll(t, fs, rootDir)
+	if diff := cmp.Diff(want[vpcTypes], got[vpcTypes]); diff != "" {
+		t.Errorf("Run(...): the changed API group should be generated: -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff("stale", got[bucketTypes]); diff != "" {
+		t.Errorf("Run(...): the unchanged API group should be skipped: -want, +got:\n%s", diff)
+	}
+
+	if err := fs.Remove(bucketTypes); err != nil {
+		t.Fatal(err)
+	}
+	runPipelineTest(t, rootDir, fs, newPipelineTestProvider(config.WithIncrementalGeneration(), modifyVPC))
+	got = readAll(t, fs, rootDir)
+	if diff := cmp.Diff(want[bucketTypes], got[bucketTypes]); diff != "" {
+		t.Errorf("Run(...): an API group with missing files should be generated: -want, +got:\n%s", diff)
+	}
+
+	// rename the referenced kind in the ec2 group, which should also
+	// regenerate the reference in the s3 group.
+	if err := afero.WriteFile(fs, bucketTypes, []byte("stale"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	renameVPC := func(pc *config.Provider) {
+		pc.Resources["test_ec2_vpc"].Kind = "Network"
+	}
+	runPipelineTest(t, rootDir, fs, newPipelineTestProvider(config.WithIncrementalGeneration(), modifyVPC, renameVPC))
+	got = readAll(t, fs, rootDir)
+	if !strings.Contains(got[bucketTypes], "ec2/v1alpha1.Network") {
+		t.Errorf("Run(...): an API group referencing a changed kind should be generated, got:\n%s", got[bucketTypes])
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/muvaf/typewriter/pkg/wrapper"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
@@ -25,6 +26,7 @@ func NewSetupGenerator(ctrlDir, hackDir, apiModulePath string, o ...SetupGenerat
 		LocalDirectoryPath: ctrlDir,
 		LicenseHeaderPath:  filepath.Join(hackDir, "boilerplate.go.txt"),
 		ModulePath:         apiModulePath,
+		fs:                 afero.NewOsFs(),
 	}
 
 	// apply the specified configuration options.
@@ -41,7 +43,9 @@ type SetupGenerator struct {
 	LicenseHeaderPath  string
 	ModulePath         string
 
-	setupAggregatorTemplate string
+	setupAggregatorTemplate   string
+	validatingWebhookPackages map[string]bool
+	fs                        afero.Fs
 }
 
 // A SetupGeneratorOption configures a SetupGenerator option.
@@ -55,6 +59,15 @@ func WithSetupAggregatorTemplate(template string) SetupGeneratorOption {
 	}
 }
 
+// WithValidatingWebhookPackages configures the controller packages of the
+// resources with validating webhooks, whose validating webhook setup
+// functions are aggregated.
+func WithValidatingWebhookPackages(pkgs map[string]bool) SetupGeneratorOption {
+	return func(g *SetupGenerator) {
+		g.validatingWebhookPackages = pkgs
+	}
+}
+
 // Generate writes the setup file given list of version packages.
 func (sg *SetupGenerator) Generate(versionPkgMap map[string][]string, monolith bool) error {
 	if monolith {
@@ -79,8 +92,12 @@ func (sg *SetupGenerator) generate(group string, versionPkgList []string) error
 	)
 	sort.Strings(versionPkgList)
 	aliases := make([]string, len(versionPkgList))
+	var webhookAliases []string
 	for i, pkgPath := range versionPkgList {
 		aliases[i] = setupFile.Imports.UsePackage(pkgPath)
+		if sg.validatingWebhookPackages[pkgPath] {
+			webhookAliases = append(webhookAliases, aliases[i])
+		}
 	}
 	g := ""
 	filePath := filepath.Join(sg.LocalDirectoryPath, "zz_setup.go")
@@ -89,22 +106,25 @@ func (sg *SetupGenerator) generate(group string, versionPkgList []string) error
 		g = "_" + group
 	}
 	vars := map[string]any{
-		"Aliases": aliases,
-		"Group":   g,
+		"Aliases":                  aliases,
+		"ValidatingWebhookAliases": webhookAliases,
+		"Group":                    g,
 	}
-	if err := setupFile.Write(filePath, vars, os.ModePerm); err != nil {
+	if err := writeFile(sg.fs, setupFile, filePath, vars, os.ModePerm); err != nil {
 		return errors.Wrap(err, "cannot write setup file")
 	}
 	return nil
 }
 
 func NewMainGenerator(cmdDir, template string) *MainGenerator {
-	return &MainGenerator{ProviderPath: cmdDir, Template: template}
+	return &MainGenerator{ProviderPath: cmdDir, Template: template, fs: afero.NewOsFs()}
 }
 
 type MainGenerator struct {
 	ProviderPath string
 	Template     string
+
+	fs afero.Fs
 }
 
 // Generate writes the setup file given list of version packages.
@@ -116,10 +136,10 @@ func (mg *MainGenerator) Generate(groups []string) error {
 
 	for _, g := range groups {
 		f := filepath.Join(mg.ProviderPath, g)
-		if err := os.MkdirAll(f, 0o750); err != nil {
+		if err := mg.fs.MkdirAll(f, 0o750); err != nil {
 			return errors.Wrapf(err, "failed to mkdir provider main program path: %s", f)
 		}
-		m, err := os.OpenFile(filepath.Join(filepath.Clean(f), "zz_main.go"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		m, err := mg.fs.OpenFile(filepath.Join(filepath.Clean(f), "zz_main.go"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
 		if err != nil {
 			return errors.Wrap(err, "failed to open provider main program file")
 		}
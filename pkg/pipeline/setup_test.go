@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pipeline
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+func TestSetupGeneratorGenerate(t *testing.T) {
+	const (
+		vpcPkg    = "github.com/upbound/provider-test/internal/controller/ec2/vpc"
+		bucketPkg = "github.com/upbound/provider-test/internal/controller/s3/bucket"
+	)
+	type want struct {
+		calls map[string]bool
+	}
+	cases := map[string]struct {
+		reason      string
+		webhookPkgs map[string]bool
+		want        want
+	}{
+		"ValidatingWebhooks": {
+			reason:      "Only the validating webhook setup functions of the controller packages with validating webhooks should be aggregated.",
+			webhookPkgs: map[string]bool{vpcPkg: true},
+			want: want{
+				calls: map[string]bool{
+					"vpc.Setup,":                                true,
+					"bucket.Setup,":                             true,
+					"vpc.SetupValidatingWebhookWithManager,":    true,
+					"bucket.SetupValidatingWebhookWithManager,": false,
+				},
+			},
+		},
+		"NoValidatingWebhooks": {
+			reason: "No validating webhook setup functions should be aggregated if there are no validating webhooks.",
+			want: want{
+				calls: map[string]bool{
+					"vpc.Setup,":                                true,
+					"bucket.Setup,":                             true,
+					"vpc.SetupValidatingWebhookWithManager,":    false,
+					"bucket.SetupValidatingWebhookWithManager,": false,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rootDir := newPipelineTestRootDir(t)
+			fs := afero.NewMemMapFs()
+			sg := NewSetupGenerator(filepath.Join(rootDir, "internal", "controller"), filepath.Join(rootDir, "hack"), "github.com/upbound/provider-test/apis", WithValidatingWebhookPackages(tc.webhookPkgs))
+			sg.fs = fs
+			if err := sg.Generate(map[string][]string{config.PackageNameMonolith: {vpcPkg, bucketPkg}}, true); err != nil {
+				t.Fatalf("\n%s\nGenerate(...): unexpected error: %v", tc.reason, err)
+			}
+			b, err := afero.ReadFile(fs, filepath.Join(rootDir, "internal", "controller", "zz_setup.go"))
+			if err != nil {
+				t.Fatalf("\n%s\nGenerate(...): cannot read the setup file: %v", tc.reason, err)
+			}
+			got := make(map[string]bool, len(tc.want.calls))
+			for c := range tc.want.calls {
+				got[c] = strings.Contains(string(b), c)
+			}
+			if diff := cmp.Diff(tc.want.calls, got); diff != "" {
+				t.Errorf("\n%s\nGenerate(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -48,3 +48,15 @@ var ConversionHubTemplate string
 //
 //go:embed conversion_spoke.go.tmpl
 var ConversionSpokeTemplate string
+
+// APIDocsTemplate is populated with the API reference documentation of a
+// managed resource.
+//
+//go:embed api_docs.md.tmpl
+var APIDocsTemplate string
+
+// APIDocsIndexTemplate is populated with the index of the API reference
+// documentation of the managed resources.
+//
+//go:embed api_docs_index.md.tmpl
+var APIDocsIndexTemplate string
@@ -13,6 +13,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
 	"github.com/muvaf/typewriter/pkg/wrapper"
+	"github.com/spf13/afero"
 
 	"github.com/crossplane/upjet/v2/pkg/pipeline/templates"
 )
@@ -23,6 +24,7 @@ func NewTerraformedGenerator(pkg *types.Package, apiDir, hackDir, group, version
 		LocalDirectoryPath: filepath.Join(apiDir, strings.ToLower(strings.Split(group, ".")[0]), version),
 		LicenseHeaderPath:  filepath.Join(hackDir, "boilerplate.go.txt"),
 		pkg:                pkg,
+		fs:                 afero.NewOsFs(),
 	}
 
 	// apply the specified configuration options.
@@ -41,6 +43,7 @@ type TerraformedGenerator struct {
 
 	pkg                 *types.Package
 	terraformedTemplate string
+	fs                  afero.Fs
 }
 
 // A TerraformedGeneratorOption configures a TerraformedGenerator option.
@@ -83,7 +86,7 @@ func (tg *TerraformedGenerator) Generate(cfgs []*terraformedInput, apiVersion st
 			"ConditionalIgnoredFields": cfg.LateInitializer.GetConditionalIgnoredCanonicalFields(),
 		}
 
-		if err := trFile.Write(filePath, vars, os.ModePerm); err != nil {
+		if err := writeFile(tg.fs, trFile, filePath, vars, os.ModePerm); err != nil {
 			return errors.Wrapf(err, "cannot write the Terraformed interface implementation file %s", filePath)
 		}
 	}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/muvaf/typewriter/pkg/wrapper"
 	"github.com/pkg/errors"
+	"github.com/spf13/afero"
 	"golang.org/x/tools/go/packages"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
@@ -28,6 +29,7 @@ func NewVersionGenerator(apiDir, hackDir, apisModulePath, group, version string)
 		DirectoryPath:     filepath.Join(apiDir, strings.ToLower(strings.Split(group, ".")[0]), version),
 		LicenseHeaderPath: filepath.Join(hackDir, "boilerplate.go.txt"),
 		pkg:               types.NewPackage(pkgPath, version),
+		fs:                afero.NewOsFs(),
 	}
 }
 
@@ -39,6 +41,7 @@ type VersionGenerator struct {
 	LicenseHeaderPath string
 
 	pkg *types.Package
+	fs  afero.Fs
 }
 
 // Generate writes doc and group version info files to the disk.
@@ -54,7 +57,7 @@ func (vg *VersionGenerator) Generate() error {
 		wrapper.WithHeaderPath(vg.LicenseHeaderPath),
 	)
 	return errors.Wrap(
-		gviFile.Write(filepath.Join(vg.DirectoryPath, "zz_groupversion_info.go"), vars, os.ModePerm),
+		writeFile(vg.fs, gviFile, filepath.Join(vg.DirectoryPath, "zz_groupversion_info.go"), vars, os.ModePerm),
 		"cannot write group version info file",
 	)
 }
@@ -76,9 +79,14 @@ func (vg *VersionGenerator) InsertPreviousObjects(versions map[string]map[string
 					// processing
 					continue
 				}
+				overlay, err := vg.overlay()
+				if err != nil {
+					return errors.Wrapf(err, "cannot load the previous versions of %q from path %s", r.Name, vg.pkg.Path())
+				}
 				pkgs, err := packages.Load(&packages.Config{
-					Mode: packages.NeedTypes,
-					Dir:  vg.DirectoryPath,
+					Mode:    packages.NeedTypes,
+					Dir:     vg.DirectoryPath,
+					Overlay: overlay,
 				}, fmt.Sprintf("zz_%s_types.go", strings.ToLower(r.Kind)))
 				if err != nil {
 					return errors.Wrapf(err, "cannot load the previous versions of %q from path %s", r.Name, vg.pkg.Path())
@@ -96,3 +104,29 @@ func (vg *VersionGenerator) InsertPreviousObjects(versions map[string]map[string
 	}
 	return nil
 }
+
+// overlay returns the Go files in the version directory as a package loader
+// overlay if the files are not written to the OS file system, so that the
+// previous versions generated in the same run can be loaded.
+func (vg *VersionGenerator) overlay() (map[string][]byte, error) {
+	if isOsFs(vg.fs) {
+		return nil, nil
+	}
+	files, err := afero.ReadDir(vg.fs, vg.DirectoryPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list the directory %s", vg.DirectoryPath)
+	}
+	overlay := make(map[string][]byte, len(files))
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".go" {
+			continue
+		}
+		p := filepath.Join(vg.DirectoryPath, f.Name())
+		b, err := afero.ReadFile(vg.fs, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read file %s", p)
+		}
+		overlay[p] = b
+	}
+	return overlay, nil
+}
@@ -0,0 +1,296 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reference
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/types"
+)
+
+// Confidence is the confidence level of a proposed reference.
+type Confidence int
+
+const (
+	// ConfidenceLow is the confidence of the proposals that have more than
+	// one candidate target resource. They are only reported.
+	ConfidenceLow Confidence = iota + 1
+	// ConfidenceMedium is the confidence of the proposals whose single
+	// candidate target resource's name ends with the name of the argument,
+	// e.g., role_arn -> aws_iam_role.
+	ConfidenceMedium
+	// ConfidenceHigh is the confidence of the proposals whose single
+	// candidate target resource's name matches the name of the argument,
+	// e.g., vpc_id -> aws_vpc, or whose argument is an identifier of the
+	// target resource.
+	ConfidenceHigh
+)
+
+// String returns the name of the confidence level.
+func (c Confidence) String() string {
+	switch c {
+	case ConfidenceLow:
+		return "low"
+	case ConfidenceMedium:
+		return "medium"
+	case ConfidenceHigh:
+		return "high"
+	}
+	return "unknown"
+}
+
+// namePatterns map the suffixes of the Terraform argument names to the
+// attributes of the target resources from which their values are extracted.
+var namePatterns = []struct {
+	suffix    string
+	attribute string
+}{
+	{suffix: "_ids", attribute: "id"},
+	{suffix: "_id", attribute: "id"},
+	{suffix: "_arns", attribute: "arn"},
+	{suffix: "_arn", attribute: "arn"},
+	{suffix: "_names", attribute: "name"},
+	{suffix: "_name", attribute: "name"},
+}
+
+// Proposal is a cross-resource reference proposed by the HeuristicInjector.
+type Proposal struct {
+	// Resource is the Terraform name of the referencing resource.
+	Resource string
+	// Field is the path of the referencing argument, e.g., vpc_config.subnet_ids.
+	Field string
+	// Candidates are the Terraform names of the candidate target resources.
+	Candidates []string
+	// Extractor is the extractor function path of the proposed reference if
+	// there's a single candidate.
+	Extractor string
+	// Confidence is the confidence level of the proposal.
+	Confidence Confidence
+	// Applied is set if the proposal has been added to the references of the
+	// referencing resource.
+	Applied bool
+}
+
+// Report is the list of the proposals of a HeuristicInjector.
+type Report struct {
+	Proposals []Proposal
+}
+
+// Print writes the proposals, one per line, to the given writer.
+func (r *Report) Print(w io.Writer) error {
+	for _, p := range r.Proposals {
+		status := "proposed"
+		if p.Applied {
+			status = "applied"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s.%s -> %s\tconfidence=%s", status, p.Resource, p.Field, strings.Join(p.Candidates, ","), p.Confidence); err != nil {
+			return err
+		}
+		if p.Extractor != "" {
+			if _, err := fmt.Fprintf(w, "\textractor=%s", p.Extractor); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HeuristicInjector proposes cross-resource references from the naming
+// patterns of the Terraform arguments, such as *_id, *_arn and *_name, by
+// matching them against the Terraform names and the identifier arguments of
+// the other resources of the provider. Unlike the Injector, it does not need
+// the example manifests from the Terraform registry. The proposals are
+// collected in a reviewable report and the ones with a confidence level at
+// or above the configured threshold are added to the references of the
+// resources. The already configured references are never overridden.
+type HeuristicInjector struct {
+	applyConfidence Confidence
+	report          *Report
+}
+
+// HeuristicInjectorOption configures a HeuristicInjector.
+type HeuristicInjectorOption func(hi *HeuristicInjector)
+
+// WithApplyConfidence configures the HeuristicInjector to add the proposals
+// with a confidence level at or above the specified level to the references
+// of the resources. By default, the proposals are only reported.
+func WithApplyConfidence(c Confidence) HeuristicInjectorOption {
+	return func(hi *HeuristicInjector) {
+		hi.applyConfidence = c
+	}
+}
+
+// NewHeuristicInjector returns a new HeuristicInjector.
+func NewHeuristicInjector(opts ...HeuristicInjectorOption) *HeuristicInjector {
+	hi := &HeuristicInjector{
+		report: &Report{},
+	}
+	for _, o := range opts {
+		o(hi)
+	}
+	return hi
+}
+
+// Report returns the report of the proposals of the last InjectReferences
+// call.
+func (hi *HeuristicInjector) Report() *Report {
+	return hi.report
+}
+
+// InjectReferences proposes cross-resource references for the arguments of
+// the specified resources and injects the ones with high enough confidence.
+func (hi *HeuristicInjector) InjectReferences(configResources map[string]*config.Resource) error {
+	hi.report = &Report{}
+	names := make([]string, 0, len(configResources))
+	for n := range configResources {
+		names = append(names, n)
+	}
+	slices.Sort(names)
+	for _, n := range names {
+		r := configResources[n]
+		if r.TerraformResource == nil {
+			continue
+		}
+		for _, field := range referenceableArguments(r.TerraformResource, nil) {
+			// skip the configured references & the arguments that are
+			// not in the spec as they are set from the external name.
+			if _, ok := r.References[field]; ok || slices.Contains(r.ExternalName.OmittedFields, field) {
+				continue
+			}
+			p, ok := propose(n, field, configResources, names)
+			if !ok {
+				continue
+			}
+			// only a single candidate can be applied.
+			if hi.applyConfidence != 0 && p.Confidence >= hi.applyConfidence && len(p.Candidates) == 1 {
+				if r.References == nil {
+					r.References = make(config.References)
+				}
+				r.References[field] = config.Reference{
+					TerraformName: p.Candidates[0],
+					Extractor:     p.Extractor,
+				}
+				p.Applied = true
+			}
+			hi.report.Proposals = append(hi.report.Proposals, p)
+		}
+	}
+	return nil
+}
+
+// referenceableArguments returns the paths of the string and the string list
+// arguments of the specified Terraform resource, including the nested ones.
+func referenceableArguments(res *schema.Resource, tfPath []string) []string {
+	var result []string
+	for _, k := range slices.Sorted(maps.Keys(res.Schema)) {
+		s := res.Schema[k]
+		if types.IsObservation(s) || s.Sensitive {
+			continue
+		}
+		p := append(slices.Clone(tfPath), k)
+		switch s.Type { //nolint:exhaustive
+		case schema.TypeString:
+			result = append(result, strings.Join(p, "."))
+		case schema.TypeList, schema.TypeSet:
+			switch e := s.Elem.(type) {
+			case *schema.Schema:
+				if e.Type == schema.TypeString {
+					result = append(result, strings.Join(p, "."))
+				}
+			case *schema.Resource:
+				result = append(result, referenceableArguments(e, p)...)
+			}
+		}
+	}
+	return result
+}
+
+// propose returns the proposed reference for the specified argument of the
+// specified resource, if any.
+func propose(resource, field string, configResources map[string]*config.Resource, names []string) (Proposal, bool) { //nolint:gocyclo // easier to follow as a unit
+	arg := field[strings.LastIndex(field, ".")+1:]
+	p := Proposal{Resource: resource, Field: field}
+
+	// an argument named after an identifier argument of a target resource,
+	// e.g., bucket -> aws_s3_bucket.
+	for _, t := range names {
+		if t == resource || (shortName(t) != arg && !strings.HasSuffix(shortName(t), "_"+arg)) {
+			continue
+		}
+		if slices.Contains(configResources[t].ExternalName.IdentifierFields, arg) {
+			p.Candidates = append(p.Candidates, t)
+		}
+	}
+	if len(p.Candidates) > 0 {
+		if len(p.Candidates) > 1 {
+			p.Confidence = ConfidenceLow
+			return p, true
+		}
+		p.Confidence = ConfidenceHigh
+		p.Extractor = getExtractorFuncPath(configResources[p.Candidates[0]], arg)
+		return p, true
+	}
+
+	for _, np := range namePatterns {
+		stem, ok := strings.CutSuffix(arg, np.suffix)
+		if !ok || stem == "" {
+			continue
+		}
+		var exact, suffix []string
+		for _, t := range names {
+			tr := configResources[t]
+			if t == resource || tr.TerraformResource == nil {
+				continue
+			}
+			// the value must be extractable from the target resource.
+			if _, ok := tr.TerraformResource.Schema[np.attribute]; !ok && np.attribute != "id" {
+				continue
+			}
+			switch sn := shortName(t); {
+			case sn == stem:
+				exact = append(exact, t)
+			case strings.HasSuffix(sn, "_"+stem):
+				suffix = append(suffix, t)
+			}
+		}
+		switch {
+		case len(exact) == 1:
+			p.Candidates, p.Confidence = exact, ConfidenceHigh
+		case len(exact) > 1:
+			p.Candidates, p.Confidence = exact, ConfidenceLow
+		case len(suffix) == 1:
+			p.Candidates, p.Confidence = suffix, ConfidenceMedium
+		case len(suffix) > 1:
+			p.Candidates, p.Confidence = suffix, ConfidenceLow
+		default:
+			return p, false
+		}
+		if len(p.Candidates) == 1 {
+			p.Extractor = getExtractorFuncPath(configResources[p.Candidates[0]], np.attribute)
+		}
+		return p, true
+	}
+	return p, false
+}
+
+// shortName returns the Terraform name of a resource without the provider
+// prefix, e.g., vpc for aws_vpc.
+func shortName(tfName string) string {
+	_, n, ok := strings.Cut(tfName, "_")
+	if !ok {
+		return tfName
+	}
+	return n
+}
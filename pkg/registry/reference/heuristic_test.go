@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package reference
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+func newHeuristicTestResources() map[string]*config.Resource {
+	str := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Optional: true} }
+	computed := func() *schema.Schema { return &schema.Schema{Type: schema.TypeString, Computed: true} }
+	resources := map[string]*config.Resource{
+		"aws_vpc": config.DefaultResource("aws_vpc", &schema.Resource{Schema: map[string]*schema.Schema{
+			"cidr_block": str(),
+		}}, nil, nil),
+		"aws_subnet": config.DefaultResource("aws_subnet", &schema.Resource{Schema: map[string]*schema.Schema{
+			"vpc_id": str(),
+			"arn":    computed(),
+		}}, nil, nil),
+		"aws_iam_role": config.DefaultResource("aws_iam_role", &schema.Resource{Schema: map[string]*schema.Schema{
+			"name": str(),
+			"arn":  computed(),
+		}}, nil, nil),
+		"aws_s3_bucket": config.DefaultResource("aws_s3_bucket", &schema.Resource{Schema: map[string]*schema.Schema{
+			"bucket": str(),
+		}}, nil, nil),
+		"aws_security_group":     config.DefaultResource("aws_security_group", &schema.Resource{Schema: map[string]*schema.Schema{}}, nil, nil),
+		"aws_ec2_security_group": config.DefaultResource("aws_ec2_security_group", &schema.Resource{Schema: map[string]*schema.Schema{}}, nil, nil),
+		"aws_lambda_function": config.DefaultResource("aws_lambda_function", &schema.Resource{Schema: map[string]*schema.Schema{
+			"role_arn":  str(),
+			"bucket":    str(),
+			"subnet_id": {Type: schema.TypeString, Computed: true},
+			"vpc_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"subnet_ids": {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+					"vpc_id":     str(),
+					"group_ids":  {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				}},
+			},
+			"description": str(),
+		}}, nil, nil),
+	}
+	resources["aws_s3_bucket"].ExternalName = config.ParameterAsIdentifier("bucket")
+	resources["aws_s3_bucket"].ExternalName.OmittedFields = []string{"bucket"}
+	resources["aws_lambda_function"].References["vpc_config.vpc_id"] = config.Reference{TerraformName: "aws_vpc"}
+	return resources
+}
+
+func TestHeuristicInjectorInjectReferences(t *testing.T) {
+	type want struct {
+		proposals  []Proposal
+		references map[string]config.References
+	}
+	cases := map[string]struct {
+		opts []HeuristicInjectorOption
+		want want
+	}{
+		"ReportOnly": {
+			want: want{
+				proposals: []Proposal{
+					{Resource: "aws_lambda_function", Field: "bucket", Candidates: []string{"aws_s3_bucket"}, Confidence: ConfidenceHigh},
+					{Resource: "aws_lambda_function", Field: "role_arn", Candidates: []string{"aws_iam_role"}, Extractor: `github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)`, Confidence: ConfidenceMedium},
+					{Resource: "aws_lambda_function", Field: "vpc_config.group_ids", Candidates: []string{"aws_ec2_security_group", "aws_security_group"}, Confidence: ConfidenceLow},
+					{Resource: "aws_lambda_function", Field: "vpc_config.subnet_ids", Candidates: []string{"aws_subnet"}, Extractor: extractResourceIDFuncPath, Confidence: ConfidenceHigh},
+					{Resource: "aws_subnet", Field: "vpc_id", Candidates: []string{"aws_vpc"}, Extractor: extractResourceIDFuncPath, Confidence: ConfidenceHigh},
+				},
+				references: map[string]config.References{
+					"aws_lambda_function": {"vpc_config.vpc_id": {TerraformName: "aws_vpc"}},
+				},
+			},
+		},
+		"ApplyHighConfidence": {
+			opts: []HeuristicInjectorOption{WithApplyConfidence(ConfidenceHigh)},
+			want: want{
+				proposals: []Proposal{
+					{Resource: "aws_lambda_function", Field: "bucket", Candidates: []string{"aws_s3_bucket"}, Confidence: ConfidenceHigh, Applied: true},
+					{Resource: "aws_lambda_function", Field: "role_arn", Candidates: []string{"aws_iam_role"}, Extractor: `github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)`, Confidence: ConfidenceMedium},
+					{Resource: "aws_lambda_function", Field: "vpc_config.group_ids", Candidates: []string{"aws_ec2_security_group", "aws_security_group"}, Confidence: ConfidenceLow},
+					{Resource: "aws_lambda_function", Field: "vpc_config.subnet_ids", Candidates: []string{"aws_subnet"}, Extractor: extractResourceIDFuncPath, Confidence: ConfidenceHigh, Applied: true},
+					{Resource: "aws_subnet", Field: "vpc_id", Candidates: []string{"aws_vpc"}, Extractor: extractResourceIDFuncPath, Confidence: ConfidenceHigh, Applied: true},
+				},
+				references: map[string]config.References{
+					"aws_lambda_function": {
+						"vpc_config.vpc_id":     {TerraformName: "aws_vpc"},
+						"bucket":                {TerraformName: "aws_s3_bucket"},
+						"vpc_config.subnet_ids": {TerraformName: "aws_subnet", Extractor: extractResourceIDFuncPath},
+					},
+					"aws_subnet": {"vpc_id": {TerraformName: "aws_vpc", Extractor: extractResourceIDFuncPath}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resources := newHeuristicTestResources()
+			hi := NewHeuristicInjector(tc.opts...)
+			if err := hi.InjectReferences(resources); err != nil {
+				t.Fatalf("InjectReferences(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want.proposals, hi.Report().Proposals); diff != "" {
+				t.Errorf("InjectReferences(...): -want proposals, +got proposals:\n%s", diff)
+			}
+			got := map[string]config.References{}
+			for n, r := range resources {
+				if len(r.References) > 0 {
+					got[n] = r.References
+				}
+			}
+			if diff := cmp.Diff(tc.want.references, got); diff != "" {
+				t.Errorf("InjectReferences(...): -want references, +got references:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReportPrint(t *testing.T) {
+	r := &Report{Proposals: []Proposal{
+		{Resource: "aws_subnet", Field: "vpc_id", Candidates: []string{"aws_vpc"}, Extractor: extractResourceIDFuncPath, Confidence: ConfidenceHigh, Applied: true},
+		{Resource: "aws_instance", Field: "security_group_ids", Candidates: []string{"aws_ec2_security_group", "aws_security_group"}, Confidence: ConfidenceLow},
+	}}
+	want := "applied\taws_subnet.vpc_id -> aws_vpc\tconfidence=high\textractor=" + extractResourceIDFuncPath + "\n" +
+		"proposed\taws_instance.security_group_ids -> aws_ec2_security_group,aws_security_group\tconfidence=low\n"
+	buff := &bytes.Buffer{}
+	if err := r.Print(buff); err != nil {
+		t.Fatalf("Print(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, buff.String()); diff != "" {
+		t.Errorf("Print(...): -want, +got:\n%s", diff)
+	}
+}
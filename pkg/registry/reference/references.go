@@ -150,6 +150,23 @@ func (rr *Injector) SetReferenceTypes(configResources map[string]*config.Resourc
 				ref.Type = crdTypePath //nolint:staticcheck // still handling deprecated field behavior
 				r.References[attr] = ref
 			}
+			if !ref.IsPolymorphic() {
+				continue
+			}
+			targets := make([]config.ReferenceTarget, len(ref.Targets))
+			for i, t := range ref.Targets {
+				targets[i] = t
+				if t.Type != "" {
+					continue
+				}
+				crdTypePath, err := rr.getTypePath(t.TerraformName, configResources)
+				if err != nil {
+					return errors.Wrap(err, "cannot set polymorphic reference target types")
+				}
+				targets[i].Type = crdTypePath
+			}
+			ref.Targets = targets
+			r.References[attr] = ref
 		}
 	}
 	return nil
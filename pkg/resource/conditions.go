@@ -17,6 +17,8 @@ import (
 const (
 	TypeLastAsyncOperation = "LastAsyncOperation"
 	TypeAsyncOperation     = "AsyncOperation"
+	TypeDrifted            = "Drifted"
+	TypePlanned            = "Planned"
 
 	ReasonApplyFailure       xpv2.ConditionReason = "ApplyFailure"
 	ReasonDestroyFailure     xpv2.ConditionReason = "DestroyFailure"
@@ -27,6 +29,13 @@ const (
 	ReasonOngoing            xpv2.ConditionReason = "Ongoing"
 	ReasonFinished           xpv2.ConditionReason = "Finished"
 	ReasonResourceUpToDate   xpv2.ConditionReason = "UpToDate"
+	ReasonDriftDetected      xpv2.ConditionReason = "DriftDetected"
+	ReasonNoDrift            xpv2.ConditionReason = "NoDrift"
+	ReasonPlannedCreate      xpv2.ConditionReason = "Create"
+	ReasonPlannedUpdate      xpv2.ConditionReason = "Update"
+	ReasonPlannedReplace     xpv2.ConditionReason = "Replace"
+	ReasonPlannedDelete      xpv2.ConditionReason = "Delete"
+	ReasonNoChanges          xpv2.ConditionReason = "NoChanges"
 )
 
 // LastAsyncOperationCondition returns the condition depending on the content
@@ -124,6 +133,52 @@ func UpToDateCondition() xpv2.Condition {
 	}
 }
 
+// DriftedCondition returns the condition TypeDrifted True with the given
+// message listing the fields that differ from the external resource.
+func DriftedCondition(msg string) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDriftDetected,
+		Message:            msg,
+	}
+}
+
+// NoDriftCondition returns the condition TypeDrifted False if the managed
+// resource is in sync with the external resource.
+func NoDriftCondition() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDrift,
+	}
+}
+
+// PlannedCondition returns the condition TypePlanned True with the planned
+// action as its reason and the given message listing the planned changes.
+func PlannedCondition(action xpv2.ConditionReason, msg string) xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             action,
+		Message:            msg,
+	}
+}
+
+// NoChangesPlannedCondition returns the condition TypePlanned False if no
+// changes are planned for the external resource.
+func NoChangesPlannedCondition() xpv2.Condition {
+	return xpv2.Condition{
+		Type:               TypePlanned,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoChanges,
+	}
+}
+
 // SetUpToDateCondition sets UpToDate condition if the resource is a test resource and up-to-date
 func SetUpToDateCondition(mg xpresource.Managed, upToDate bool) {
 	// At this point, we know that late initialization is done
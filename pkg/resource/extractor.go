@@ -5,9 +5,16 @@
 package resource
 
 import (
+	"maps"
+	"strings"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	xpmeta "github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	xpref "github.com/crossplane/crossplane-runtime/v2/pkg/reference"
 	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
 )
 
 // ExtractResourceID extracts the value of `status.atProvider.id`
@@ -57,3 +64,61 @@ func ExtractParamPath(sourceAttr string, isObservation bool) xpref.ExtractValueF
 		return v
 	}
 }
+
+// ExtractFieldPath extracts the value of the specified field path, such as
+// `status.atProvider.arn`, from a managed resource. Unlike ExtractParamPath,
+// it does not require a Terraformed resource and can also be used with the
+// UnstructuredManaged resources. If the value cannot be extracted, returns an
+// empty string.
+func ExtractFieldPath(path string) xpref.ExtractValueFn {
+	return func(mr xpresource.Managed) string {
+		obj, err := unstructuredContent(mr)
+		// TODO: we had better log the error
+		if err != nil {
+			return ""
+		}
+		v, err := fieldpath.Pave(obj).GetString(path)
+		// TODO: we had better log the error
+		if err != nil {
+			return ""
+		}
+		return v
+	}
+}
+
+// ExtractTemplate extracts a value by executing the specified
+// config.Reference.ExtractorTemplate with the fields of a managed resource,
+// such as `{{ .spec.forProvider.region }}`, and its external name as
+// `{{ .external_name }}`. It can be used to compose values like ARNs from
+// several fields of the referenced resource. If the template cannot be
+// executed, for example, because a field is missing, returns an empty string.
+func ExtractTemplate(tmpl string) xpref.ExtractValueFn {
+	t, parseErr := config.ParseExtractorTemplate(tmpl)
+	return func(mr xpresource.Managed) string {
+		// the template is validated while generating the provider.
+		if parseErr != nil {
+			return ""
+		}
+		obj, err := unstructuredContent(mr)
+		// TODO: we had better log the error
+		if err != nil {
+			return ""
+		}
+		data := make(map[string]any, len(obj)+1)
+		maps.Copy(data, obj)
+		data["external_name"] = xpmeta.GetExternalName(mr)
+		sb := &strings.Builder{}
+		// TODO: we had better log the error
+		if err := t.Execute(sb, data); err != nil {
+			return ""
+		}
+		return sb.String()
+	}
+}
+
+func unstructuredContent(mr xpresource.Managed) (map[string]any, error) {
+	if u, ok := mr.(runtime.Unstructured); ok {
+		return u.UnstructuredContent(), nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(mr)
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExtractTemplate(t *testing.T) {
+	role, _ := NewUnstructuredManaged("iam.aws.upbound.io", "v1beta1", "Role")
+	role.SetUnstructuredContent(map[string]any{
+		"apiVersion": "iam.aws.upbound.io/v1beta1",
+		"kind":       "Role",
+		"metadata": map[string]any{
+			"name": "example",
+			"annotations": map[string]any{
+				meta.AnnotationKeyExternalName: "example-role",
+			},
+		},
+		"spec": map[string]any{
+			"forProvider": map[string]any{
+				"path": "/service/",
+			},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{
+				"accountId": "123456789012",
+			},
+		},
+	})
+	type args struct {
+		tmpl string
+		mr   xpresource.Managed
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"SpecStatusAndExternalName": {
+			reason: "The spec and status fields and the external name of the referenced resource should be available to the template.",
+			args: args{
+				tmpl: "arn:aws:iam::{{ .status.atProvider.accountId }}:role{{ .spec.forProvider.path }}{{ .external_name | ToUpper }}",
+				mr:   role,
+			},
+			want: "arn:aws:iam::123456789012:role/service/EXAMPLE-ROLE",
+		},
+		"TypedResource": {
+			reason: "The template should also be executed with a typed managed resource.",
+			args: args{
+				tmpl: "id/{{ .external_name }}",
+				mr: &xpfake.Managed{ObjectMeta: metav1.ObjectMeta{
+					Name:        "example",
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "example-id"},
+				}},
+			},
+			want: "id/example-id",
+		},
+		"MissingField": {
+			reason: "An empty string should be extracted if a field is missing, so that the reference is not resolved to a partial value.",
+			args: args{
+				tmpl: "{{ .status.atProvider.arn }}",
+				mr:   role,
+			},
+		},
+		"InvalidTemplate": {
+			reason: "An empty string should be extracted if the template cannot be parsed.",
+			args: args{
+				tmpl: "{{ .external_name",
+				mr:   role,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExtractTemplate(tc.args.tmpl)(tc.args.mr)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExtractTemplate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
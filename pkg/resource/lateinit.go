@@ -5,6 +5,7 @@
 package resource
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"runtime/debug"
@@ -29,16 +30,48 @@ const (
 	// AnnotationKeyTestResource is used for marking an MR as test for automated tests
 	AnnotationKeyTestResource = "upjet.upbound.io/test"
 
+	// AnnotationKeyReconciliationMode is used for setting the reconciliation
+	// mode of an MR. The only supported mode is ReconciliationModePlanOnly.
+	AnnotationKeyReconciliationMode = "upjet.upbound.io/reconciliation-mode"
+
+	// ReconciliationModePlanOnly is the reconciliation mode in which the
+	// changes planned for the external resource are reported in the Planned
+	// condition of the MR without being applied.
+	ReconciliationModePlanOnly = "PlanOnly"
+
+	// AnnotationKeyReplacementPolicy is used for overriding the replacement
+	// policy configured for the resource.
+	AnnotationKeyReplacementPolicy = "upjet.upbound.io/replacement-policy"
+
+	// AnnotationKeyApprovedReplacement is used for approving the planned
+	// replacement with the given hash of an MR whose replacement policy is
+	// config.ReplacementPolicyRequireApproval.
+	AnnotationKeyApprovedReplacement = "upjet.upbound.io/approved-replacement"
+
+	// AnnotationKeyAsyncOperation is the key that points to the record of
+	// the asynchronous Terraform operation running for an MR. The record
+	// survives the restarts of the provider, unlike the in-memory operation
+	// trackers.
+	AnnotationKeyAsyncOperation = "upjet.upbound.io/async-operation"
+
+	// AnnotationKeyReplacedResource is the key that points to the record of
+	// the external resource replaced by creating its replacement first. The
+	// record is kept until the replaced external resource is destroyed so
+	// that it does not leak if its destruction fails.
+	AnnotationKeyReplacedResource = "upjet.upbound.io/replaced-resource"
+
 	// CNameWildcard can be used as the canonical name of a value filter option
 	// that will apply to all fields of a struct
 	CNameWildcard = ""
 )
 const (
 	// error messages
-	errFmtTypeMismatch        = "observed object's type %q does not match desired object's type %q"
-	errFmtPanic               = "recovered from panic: %v\n%s"
-	errFmtMapElemNotSupported = "map items of kind %q is not supported for canonical name: %s"
-	errFmtNotPtrToStruct      = "%s must be of a pointer to struct type: %#v"
+	errFmtUnknownReplacementPolicy = "unknown replacement policy %q"
+	errFmtParseAnnotation          = "cannot parse the value of the %s annotation"
+	errFmtTypeMismatch             = "observed object's type %q does not match desired object's type %q"
+	errFmtPanic                    = "recovered from panic: %v\n%s"
+	errFmtMapElemNotSupported      = "map items of kind %q is not supported for canonical name: %s"
+	errFmtNotPtrToStruct           = "%s must be of a pointer to struct type: %#v"
 
 	fmtCanonical = "%s.%s"
 )
@@ -444,3 +477,123 @@ func getCanonicalName(parent, child string) string {
 func IsTest(mg xpresource.Managed) bool {
 	return mg.GetAnnotations()[AnnotationKeyTestResource] == "true"
 }
+
+// IsPlanOnly returns true if the managed resource has the
+// upjet.upbound.io/reconciliation-mode: PlanOnly annotation
+func IsPlanOnly(mg xpresource.Managed) bool {
+	return mg.GetAnnotations()[AnnotationKeyReconciliationMode] == ReconciliationModePlanOnly
+}
+
+// GetReplacementPolicy returns the replacement policy of the managed resource,
+// which is the value of its upjet.upbound.io/replacement-policy annotation if
+// set, or the replacement policy configured for the resource. Defaults to
+// config.ReplacementPolicyDeny.
+func GetReplacementPolicy(mg xpresource.Managed, cfg *config.Resource) (config.ReplacementPolicy, error) {
+	p := cfg.ReplacementPolicy
+	if v, ok := mg.GetAnnotations()[AnnotationKeyReplacementPolicy]; ok {
+		p = config.ReplacementPolicy(v)
+	}
+	switch p {
+	case "":
+		return config.ReplacementPolicyDeny, nil
+	case config.ReplacementPolicyDeny, config.ReplacementPolicyReplace, config.ReplacementPolicyCreateBeforeDestroy, config.ReplacementPolicyRequireApproval:
+		return p, nil
+	default:
+		return "", errors.Errorf(errFmtUnknownReplacementPolicy, p)
+	}
+}
+
+// AsyncOperation is the record of an asynchronous Terraform operation
+// persisted in the upjet.upbound.io/async-operation annotation of an MR.
+type AsyncOperation struct {
+	// Type is the type of the operation, e.g., create.
+	Type string `json:"type"`
+	// StartTime is the time when the operation was started.
+	StartTime metav1.Time `json:"startTime"`
+	// ID is the Terraform ID of the external resource returned by the
+	// provider before the operation failed, if any.
+	ID string `json:"id,omitempty"`
+	// State is the partial Terraform state of the external resource
+	// returned by the provider before the operation failed, if any. Only
+	// the top-level attributes that are neither sensitive nor nested are
+	// recorded, which suffice to identify the external resource.
+	State json.RawMessage `json:"state,omitempty"`
+}
+
+// GetAsyncOperation returns the record of the asynchronous operation of the
+// managed resource, or nil if there is no such record.
+func GetAsyncOperation(mg metav1.Object) (*AsyncOperation, error) {
+	v, ok := mg.GetAnnotations()[AnnotationKeyAsyncOperation]
+	if !ok {
+		return nil, nil
+	}
+	op := &AsyncOperation{}
+	if err := json.Unmarshal([]byte(v), op); err != nil {
+		return nil, errors.Wrapf(err, errFmtParseAnnotation, AnnotationKeyAsyncOperation)
+	}
+	return op, nil
+}
+
+// SetAsyncOperation records the given asynchronous operation in the
+// upjet.upbound.io/async-operation annotation of the managed resource.
+func SetAsyncOperation(mg metav1.Object, op AsyncOperation) {
+	// marshaling the record cannot fail as long as its state, if any, is
+	// valid JSON, which is produced by marshaling the partial state.
+	b, _ := json.Marshal(op)
+	xpmeta.AddAnnotations(mg, map[string]string{
+		AnnotationKeyAsyncOperation: string(b),
+	})
+}
+
+// RemoveAsyncOperation removes the record of the asynchronous operation of
+// the managed resource. Returns whether there was a record to remove.
+func RemoveAsyncOperation(mg metav1.Object) bool {
+	if _, ok := mg.GetAnnotations()[AnnotationKeyAsyncOperation]; !ok {
+		return false
+	}
+	xpmeta.RemoveAnnotations(mg, AnnotationKeyAsyncOperation)
+	return true
+}
+
+// ReplacedResource is the record of an external resource being replaced
+// persisted in the upjet.upbound.io/replaced-resource annotation of an MR.
+type ReplacedResource struct {
+	// ExternalName is the external-name of the replaced external resource.
+	ExternalName string `json:"externalName"`
+	// State is the Terraform state of the replaced external resource
+	// serialized by the Terraform client that replaced it.
+	State json.RawMessage `json:"state"`
+}
+
+// GetReplacedResource returns the record of the replaced external resource
+// of the managed resource, or nil if there is no such record.
+func GetReplacedResource(mg metav1.Object) (*ReplacedResource, error) {
+	v, ok := mg.GetAnnotations()[AnnotationKeyReplacedResource]
+	if !ok {
+		return nil, nil
+	}
+	rr := &ReplacedResource{}
+	if err := json.Unmarshal([]byte(v), rr); err != nil {
+		return nil, errors.Wrapf(err, errFmtParseAnnotation, AnnotationKeyReplacedResource)
+	}
+	return rr, nil
+}
+
+// SetReplacedResource records the given replaced external resource in the
+// upjet.upbound.io/replaced-resource annotation of the managed resource.
+func SetReplacedResource(mg metav1.Object, rr ReplacedResource) error {
+	b, err := json.Marshal(rr)
+	if err != nil {
+		return errors.Wrapf(err, "cannot serialize the value of the %s annotation", AnnotationKeyReplacedResource)
+	}
+	xpmeta.AddAnnotations(mg, map[string]string{
+		AnnotationKeyReplacedResource: string(b),
+	})
+	return nil
+}
+
+// RemoveReplacedResource removes the record of the replaced external
+// resource of the managed resource.
+func RemoveReplacedResource(mg metav1.Object) {
+	xpmeta.RemoveAnnotations(mg, AnnotationKeyReplacedResource)
+}
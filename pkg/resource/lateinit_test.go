@@ -5,9 +5,17 @@
 package resource
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
 )
 
 func TestLateInitialize(t *testing.T) {
@@ -538,3 +546,153 @@ func TestLateInitialize(t *testing.T) {
 		})
 	}
 }
+
+func TestGetReplacementPolicy(t *testing.T) {
+	type want struct {
+		policy config.ReplacementPolicy
+		err    error
+	}
+	cases := map[string]struct {
+		reason      string
+		policy      config.ReplacementPolicy
+		annotations map[string]string
+		want        want
+	}{
+		"Default": {
+			reason: "The Deny policy should be the default.",
+			want:   want{policy: config.ReplacementPolicyDeny},
+		},
+		"Configured": {
+			reason: "The policy configured for the resource should be used if there is no annotation.",
+			policy: config.ReplacementPolicyReplace,
+			want:   want{policy: config.ReplacementPolicyReplace},
+		},
+		"Annotation": {
+			reason:      "The annotation should override the policy configured for the resource.",
+			policy:      config.ReplacementPolicyReplace,
+			annotations: map[string]string{AnnotationKeyReplacementPolicy: string(config.ReplacementPolicyRequireApproval)},
+			want:        want{policy: config.ReplacementPolicyRequireApproval},
+		},
+		"Unknown": {
+			reason:      "An unknown policy should be reported.",
+			annotations: map[string]string{AnnotationKeyReplacementPolicy: "Recreate"},
+			want:        want{err: errors.Errorf(errFmtUnknownReplacementPolicy, "Recreate")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &xpfake.Managed{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			got, err := GetReplacementPolicy(mg, &config.Resource{ReplacementPolicy: tc.policy})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetReplacementPolicy(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.policy, got); diff != "" {
+				t.Errorf("\n%s\nGetReplacementPolicy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetAsyncOperation(t *testing.T) {
+	op := AsyncOperation{Type: "create", StartTime: metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))}
+	partial := AsyncOperation{Type: "create", StartTime: op.StartTime, ID: "some-id", State: json.RawMessage(`{"arn":"some-arn","id":"some-id"}`)}
+	invalid := `{"type": 1}`
+	type want struct {
+		op  *AsyncOperation
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		set    *AsyncOperation
+		value  string
+		want   want
+	}{
+		"NoRecord": {
+			reason: "No operation should be returned if there is no record.",
+			want:   want{},
+		},
+		"Record": {
+			reason: "The recorded operation should be returned.",
+			set:    &op,
+			want:   want{op: &op},
+		},
+		"RecordWithPartialState": {
+			reason: "The recorded operation should be returned together with the ID and the partial state returned by the provider.",
+			set:    &partial,
+			want:   want{op: &partial},
+		},
+		"Invalid": {
+			reason: "An invalid record should be reported.",
+			value:  invalid,
+			want:   want{err: errors.Wrapf(json.Unmarshal([]byte(invalid), &AsyncOperation{}), errFmtParseAnnotation, AnnotationKeyAsyncOperation)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &xpfake.Managed{}
+			if tc.set != nil {
+				SetAsyncOperation(mg, *tc.set)
+			}
+			if tc.value != "" {
+				mg.SetAnnotations(map[string]string{AnnotationKeyAsyncOperation: tc.value})
+			}
+			got, err := GetAsyncOperation(mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetAsyncOperation(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.op, got); diff != "" {
+				t.Errorf("\n%s\nGetAsyncOperation(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetReplacedResource(t *testing.T) {
+	rr := ReplacedResource{ExternalName: "old-name", State: json.RawMessage(`{"id":"old-id"}`)}
+	invalid := `{"externalName": 1}`
+	type want struct {
+		rr  *ReplacedResource
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		set    bool
+		value  string
+		want   want
+	}{
+		"NoRecord": {
+			reason: "No replaced resource should be returned if there is no record.",
+			want:   want{},
+		},
+		"Record": {
+			reason: "The recorded replaced resource should be returned.",
+			set:    true,
+			want:   want{rr: &rr},
+		},
+		"Invalid": {
+			reason: "An invalid record should be reported.",
+			value:  invalid,
+			want:   want{err: errors.Wrapf(json.Unmarshal([]byte(invalid), &ReplacedResource{}), errFmtParseAnnotation, AnnotationKeyReplacedResource)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &xpfake.Managed{}
+			if tc.set {
+				if err := SetReplacedResource(mg, rr); err != nil {
+					t.Fatalf("SetReplacedResource(...): unexpected error: %v", err)
+				}
+			}
+			if tc.value != "" {
+				mg.SetAnnotations(map[string]string{AnnotationKeyReplacedResource: tc.value})
+			}
+			got, err := GetReplacedResource(mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetReplacedResource(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rr, got); diff != "" {
+				t.Errorf("\n%s\nGetReplacedResource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpref "github.com/crossplane/crossplane-runtime/v2/pkg/reference"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/apis/reference/v1alpha1"
+)
+
+const (
+	errFmtUnknownKind        = "kind %q is not one of the candidate kinds of the polymorphic reference: %s"
+	errFmtGetManagedResource = "cannot get the managed resource and its list for the kind %q"
+	errFmtNoTargetResolved   = "cannot resolve the polymorphic reference to any of the candidate kinds: %s"
+	errFmtAmbiguousTargets   = "the polymorphic reference resolves to more than one of the candidate kinds: %s, please set the kind"
+)
+
+// ManagedResourceGetter returns a new managed resource of the specified
+// group, version and kind, and a new list of it.
+type ManagedResourceGetter func(group, version, kind, listKind string) (xpresource.Managed, xpresource.ManagedList, error)
+
+// PolymorphicTarget is a candidate target kind of a polymorphic reference.
+type PolymorphicTarget struct {
+	Group    string
+	Version  string
+	Kind     string
+	ListKind string
+	// Extract is the function to extract the value from the target managed
+	// resource. Defaults to extracting the external name.
+	Extract xpref.ExtractValueFn
+}
+
+// A PolymorphicResolutionRequest requests that a polymorphic reference of a
+// cluster-scoped managed resource be resolved.
+type PolymorphicResolutionRequest struct {
+	CurrentValue string
+	Reference    *v1alpha1.PolymorphicReference
+	Selector     *v1alpha1.PolymorphicSelector
+	Targets      []PolymorphicTarget
+}
+
+// A PolymorphicResolutionResponse returns the result of a polymorphic
+// reference resolution. The resolved reference carries the kind of the
+// resolved target.
+type PolymorphicResolutionResponse struct {
+	ResolvedValue     string
+	ResolvedReference *v1alpha1.PolymorphicReference
+}
+
+// A NamespacedPolymorphicResolutionRequest requests that a polymorphic
+// reference of a namespaced managed resource be resolved.
+type NamespacedPolymorphicResolutionRequest struct {
+	CurrentValue string
+	Reference    *v1alpha1.NamespacedPolymorphicReference
+	Selector     *v1alpha1.NamespacedPolymorphicSelector
+	Targets      []PolymorphicTarget
+}
+
+// A NamespacedPolymorphicResolutionResponse returns the result of a
+// namespaced polymorphic reference resolution.
+type NamespacedPolymorphicResolutionResponse struct {
+	ResolvedValue     string
+	ResolvedReference *v1alpha1.NamespacedPolymorphicReference
+}
+
+// A PolymorphicResolver resolves the references that can target one of
+// several kinds of managed resources. If the kind of the reference or the
+// selector is set, only the target of that kind is resolved. Otherwise, all
+// the candidate targets are tried and exactly one of them must resolve.
+type PolymorphicResolver struct {
+	client client.Reader
+	from   xpresource.Managed
+	get    ManagedResourceGetter
+}
+
+// NewPolymorphicResolver returns a new PolymorphicResolver for the
+// references of the specified managed resource. The specified getter is used
+// to initialize the candidate target managed resources and their lists.
+func NewPolymorphicResolver(c client.Reader, from xpresource.Managed, get ManagedResourceGetter) *PolymorphicResolver {
+	return &PolymorphicResolver{
+		client: c,
+		from:   from,
+		get:    get,
+	}
+}
+
+// resolvedTarget is a candidate target that resolved.
+type resolvedTarget struct {
+	kind  string
+	value string
+	name  string
+	// namespace is only set for the namespaced references.
+	namespace string
+}
+
+// resolveFn resolves the reference to the specified target.
+type resolveFn func(to xpref.To, extract xpref.ExtractValueFn) (resolvedTarget, error)
+
+// Resolve resolves the specified polymorphic reference of a cluster-scoped
+// managed resource.
+func (r *PolymorphicResolver) Resolve(ctx context.Context, req PolymorphicResolutionRequest) (PolymorphicResolutionResponse, error) {
+	xreq := xpref.ResolutionRequest{CurrentValue: req.CurrentValue}
+	if req.Reference != nil {
+		xreq.Reference = &xpv2.Reference{Name: req.Reference.Name, Policy: req.Reference.Policy}
+	}
+	if req.Selector != nil {
+		xreq.Selector = &xpv2.Selector{MatchLabels: req.Selector.MatchLabels, MatchControllerRef: req.Selector.MatchControllerRef, Policy: req.Selector.Policy}
+	}
+	if meta.WasDeleted(r.from) || xreq.IsNoOp() {
+		return PolymorphicResolutionResponse{ResolvedValue: req.CurrentValue, ResolvedReference: req.Reference}, nil
+	}
+	// IsNoOp drops the reference if the selector has the Always resolve
+	// policy.
+	var kind string
+	var policy *xpv2.Policy
+	if xreq.Reference != nil {
+		kind, policy = req.Reference.Kind, req.Reference.Policy
+	} else {
+		kind, policy = req.Selector.Kind, req.Selector.Policy
+	}
+	rt, ok, err := r.resolve(req.Targets, kind, policy, func(to xpref.To, extract xpref.ExtractValueFn) (resolvedTarget, error) {
+		rsp, err := xpref.NewAPIResolver(r.client, r.from).Resolve(ctx, xpref.ResolutionRequest{
+			Reference: xreq.Reference,
+			Selector:  xreq.Selector,
+			To:        to,
+			Extract:   extract,
+		})
+		if err != nil || rsp.ResolvedReference == nil {
+			return resolvedTarget{}, err
+		}
+		return resolvedTarget{value: rsp.ResolvedValue, name: rsp.ResolvedReference.Name}, nil
+	})
+	if err != nil || !ok {
+		return PolymorphicResolutionResponse{}, err
+	}
+	ref := &v1alpha1.PolymorphicReference{Kind: rt.kind, Name: rt.name}
+	if xreq.Reference != nil {
+		ref = req.Reference.DeepCopy()
+		ref.Kind = rt.kind
+	}
+	return PolymorphicResolutionResponse{ResolvedValue: rt.value, ResolvedReference: ref}, nil
+}
+
+// ResolveNamespaced resolves the specified polymorphic reference of a
+// namespaced managed resource.
+func (r *PolymorphicResolver) ResolveNamespaced(ctx context.Context, req NamespacedPolymorphicResolutionRequest) (NamespacedPolymorphicResolutionResponse, error) {
+	xreq := xpref.NamespacedResolutionRequest{CurrentValue: req.CurrentValue}
+	if req.Reference != nil {
+		xreq.Reference = &xpv2.NamespacedReference{Name: req.Reference.Name, Namespace: req.Reference.Namespace, Policy: req.Reference.Policy}
+	}
+	if req.Selector != nil {
+		xreq.Selector = &xpv2.NamespacedSelector{MatchLabels: req.Selector.MatchLabels, MatchControllerRef: req.Selector.MatchControllerRef, Policy: req.Selector.Policy, Namespace: req.Selector.Namespace}
+	}
+	if meta.WasDeleted(r.from) || xreq.IsNoOp() {
+		return NamespacedPolymorphicResolutionResponse{ResolvedValue: req.CurrentValue, ResolvedReference: req.Reference}, nil
+	}
+	var kind string
+	var policy *xpv2.Policy
+	if xreq.Reference != nil {
+		kind, policy = req.Reference.Kind, req.Reference.Policy
+	} else {
+		kind, policy = req.Selector.Kind, req.Selector.Policy
+	}
+	rt, ok, err := r.resolve(req.Targets, kind, policy, func(to xpref.To, extract xpref.ExtractValueFn) (resolvedTarget, error) {
+		rsp, err := xpref.NewAPINamespacedResolver(r.client, r.from).Resolve(ctx, xpref.NamespacedResolutionRequest{
+			Reference: xreq.Reference,
+			Selector:  xreq.Selector,
+			To:        to,
+			Extract:   extract,
+		})
+		if err != nil || rsp.ResolvedReference == nil {
+			return resolvedTarget{}, err
+		}
+		return resolvedTarget{value: rsp.ResolvedValue, name: rsp.ResolvedReference.Name, namespace: rsp.ResolvedReference.Namespace}, nil
+	})
+	if err != nil || !ok {
+		return NamespacedPolymorphicResolutionResponse{}, err
+	}
+	ref := &v1alpha1.NamespacedPolymorphicReference{Kind: rt.kind, Name: rt.name, Namespace: rt.namespace}
+	if xreq.Reference != nil {
+		ref = req.Reference.DeepCopy()
+		ref.Kind = rt.kind
+	}
+	return NamespacedPolymorphicResolutionResponse{ResolvedValue: rt.value, ResolvedReference: ref}, nil
+}
+
+// resolve resolves the reference to the target of the specified kind, or if
+// the kind is not set, to the only candidate target that resolves. It returns
+// false if no target has resolved and the resolution is optional.
+func (r *PolymorphicResolver) resolve(targets []PolymorphicTarget, kind string, policy *xpv2.Policy, fn resolveFn) (resolvedTarget, bool, error) {
+	kinds := make([]string, len(targets))
+	for i, t := range targets {
+		kinds[i] = t.Kind
+	}
+	candidates := targets
+	if kind != "" {
+		candidates = nil
+		for _, t := range targets {
+			if t.Kind == kind {
+				candidates = append(candidates, t)
+			}
+		}
+		if len(candidates) == 0 {
+			return resolvedTarget{}, false, errors.Errorf(errFmtUnknownKind, kind, strings.Join(kinds, ", "))
+		}
+	}
+
+	var resolved []resolvedTarget
+	var failures []string
+	for _, t := range candidates {
+		m, l, err := r.get(t.Group, t.Version, t.Kind, t.ListKind)
+		if err != nil {
+			return resolvedTarget{}, false, errors.Wrapf(err, errFmtGetManagedResource, t.Kind)
+		}
+		extract := t.Extract
+		if extract == nil {
+			extract = xpref.ExternalName()
+		}
+		rt, err := fn(xpref.To{Managed: m, List: l}, extract)
+		switch {
+		// the kind is chosen, so the resolution behaves as a
+		// regular reference.
+		case kind != "" && err != nil:
+			return resolvedTarget{}, false, errors.Wrap(err, t.Kind)
+		case err != nil:
+			failures = append(failures, fmt.Sprintf("%s: %s", t.Kind, err))
+		case rt.value != "":
+			rt.kind = t.Kind
+			resolved = append(resolved, rt)
+		}
+	}
+
+	switch {
+	case len(resolved) == 1:
+		return resolved[0], true, nil
+	case len(resolved) > 1:
+		resolvedKinds := make([]string, len(resolved))
+		for i, rt := range resolved {
+			resolvedKinds[i] = rt.kind
+		}
+		return resolvedTarget{}, false, errors.Errorf(errFmtAmbiguousTargets, strings.Join(resolvedKinds, ", "))
+	case kind != "" || policy.IsResolutionPolicyOptional():
+		// an optional reference that cannot be resolved is not an error.
+		return resolvedTarget{}, false, nil
+	default:
+		if len(failures) == 0 {
+			failures = kinds
+		}
+		return resolvedTarget{}, false, errors.Errorf(errFmtNoTargetResolved, strings.Join(failures, "; "))
+	}
+}
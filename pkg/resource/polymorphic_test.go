@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/apis/reference/v1alpha1"
+)
+
+const labelTestKind = "kind"
+
+type polymorphicTestList struct {
+	client.ObjectList
+	kind  string
+	items []resource.Managed
+}
+
+func (l *polymorphicTestList) GetItems() []resource.Managed {
+	return l.items
+}
+
+var polymorphicTestTargets = []PolymorphicTarget{
+	{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "VPC", ListKind: "VPCList"},
+	{Group: "ec2.aws.upbound.io", Version: "v1beta1", Kind: "Subnet", ListKind: "SubnetList"},
+}
+
+// getPolymorphicTestResource returns managed resources labeled with their
+// kinds so that the fake client can tell them apart.
+func getPolymorphicTestResource(_, _, kind, _ string) (resource.Managed, resource.ManagedList, error) {
+	if kind == "Unregistered" {
+		return nil, nil, errors.New("not registered")
+	}
+	return &xpfake.Managed{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{labelTestKind: kind}}}, &polymorphicTestList{kind: kind}, nil
+}
+
+// newPolymorphicTestClient returns a fake client which serves the specified
+// external names of the managed resources keyed by <kind>/<name>.
+func newPolymorphicTestClient(externalNames map[string]string) client.Reader {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			kind := obj.GetLabels()[labelTestKind]
+			en, ok := externalNames[kind+"/"+key.Name]
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: kind}, key.Name)
+			}
+			meta.SetExternalName(obj, en)
+			return nil
+		},
+		MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			l := obj.(*polymorphicTestList)
+			for _, n := range []string{"a", "b"} {
+				if en, ok := externalNames[l.kind+"/"+n]; ok {
+					m := &xpfake.Managed{ObjectMeta: metav1.ObjectMeta{Name: n, Namespace: "ns"}}
+					meta.SetExternalName(m, en)
+					l.items = append(l.items, m)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestPolymorphicResolverResolve(t *testing.T) {
+	errNotFound := func(kind string) error {
+		return errors.Wrap(kerrors.NewNotFound(schema.GroupResource{Resource: kind}, "a"), "cannot get referenced resource")
+	}
+	type args struct {
+		externalNames map[string]string
+		req           PolymorphicResolutionRequest
+	}
+	type want struct {
+		rsp PolymorphicResolutionResponse
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoOp": {
+			reason: "An already resolved value should not be resolved again.",
+			args: args{
+				req: PolymorphicResolutionRequest{
+					CurrentValue: "vpc-1",
+					Reference:    &v1alpha1.PolymorphicReference{Kind: "VPC", Name: "a"},
+					Targets:      polymorphicTestTargets,
+				},
+			},
+			want: want{
+				rsp: PolymorphicResolutionResponse{ResolvedValue: "vpc-1", ResolvedReference: &v1alpha1.PolymorphicReference{Kind: "VPC", Name: "a"}},
+			},
+		},
+		"ReferenceWithKind": {
+			reason: "A reference with a kind should be resolved to the target of that kind only.",
+			args: args{
+				externalNames: map[string]string{"VPC/a": "vpc-1", "Subnet/a": "subnet-1"},
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Kind: "Subnet", Name: "a"},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+			want: want{
+				rsp: PolymorphicResolutionResponse{ResolvedValue: "subnet-1", ResolvedReference: &v1alpha1.PolymorphicReference{Kind: "Subnet", Name: "a"}},
+			},
+		},
+		"ReferenceWithoutKind": {
+			reason: "A reference without a kind should be resolved to the only target that resolves and the kind should be set.",
+			args: args{
+				externalNames: map[string]string{"Subnet/a": "subnet-1"},
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Name: "a"},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+			want: want{
+				rsp: PolymorphicResolutionResponse{ResolvedValue: "subnet-1", ResolvedReference: &v1alpha1.PolymorphicReference{Kind: "Subnet", Name: "a"}},
+			},
+		},
+		"AmbiguousReference": {
+			reason: "A reference without a kind that resolves to more than one target should be rejected.",
+			args: args{
+				externalNames: map[string]string{"VPC/a": "vpc-1", "Subnet/a": "subnet-1"},
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Name: "a"},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtAmbiguousTargets, "VPC, Subnet"),
+			},
+		},
+		"UnresolvedReference": {
+			reason: "A reference without a kind that resolves to none of the targets should be rejected.",
+			args: args{
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Name: "a"},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoTargetResolved, "VPC: "+errNotFound("VPC").Error()+"; Subnet: "+errNotFound("Subnet").Error()),
+			},
+		},
+		"OptionalUnresolvedReference": {
+			reason: "An optional reference that resolves to none of the targets should not be an error.",
+			args: args{
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Name: "a", Policy: &xpv2.Policy{Resolution: ptr.To(xpv2.ResolutionPolicyOptional)}},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+		},
+		"UnknownKind": {
+			reason: "A reference to a kind that is not a candidate should be rejected.",
+			args: args{
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Kind: "EIP", Name: "a"},
+					Targets:   polymorphicTestTargets,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtUnknownKind, "EIP", "VPC, Subnet"),
+			},
+		},
+		"UnregisteredKind": {
+			reason: "An error should be returned if a candidate target cannot be initialized.",
+			args: args{
+				req: PolymorphicResolutionRequest{
+					Reference: &v1alpha1.PolymorphicReference{Name: "a"},
+					Targets:   []PolymorphicTarget{{Kind: "Unregistered"}},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("not registered"), errFmtGetManagedResource, "Unregistered"),
+			},
+		},
+		"SelectorWithKind": {
+			reason: "A selector with a kind should select a resource of that kind.",
+			args: args{
+				externalNames: map[string]string{"VPC/b": "vpc-2", "Subnet/a": "subnet-1"},
+				req: PolymorphicResolutionRequest{
+					Selector: &v1alpha1.PolymorphicSelector{Kind: "VPC", MatchLabels: map[string]string{"env": "test"}},
+					Targets:  polymorphicTestTargets,
+				},
+			},
+			want: want{
+				rsp: PolymorphicResolutionResponse{ResolvedValue: "vpc-2", ResolvedReference: &v1alpha1.PolymorphicReference{Kind: "VPC", Name: "b"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewPolymorphicResolver(newPolymorphicTestClient(tc.args.externalNames), &xpfake.Managed{}, getPolymorphicTestResource)
+			got, err := r.Resolve(context.TODO(), tc.args.req)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -wantErr, +gotErr:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.rsp, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPolymorphicResolverResolveNamespaced(t *testing.T) {
+	from := &xpfake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "ns"}}
+	r := NewPolymorphicResolver(newPolymorphicTestClient(map[string]string{"Subnet/a": "subnet-1"}), from, getPolymorphicTestResource)
+	got, err := r.ResolveNamespaced(context.TODO(), NamespacedPolymorphicResolutionRequest{
+		Selector: &v1alpha1.NamespacedPolymorphicSelector{MatchLabels: map[string]string{"env": "test"}},
+		Targets:  polymorphicTestTargets,
+	})
+	if err != nil {
+		t.Fatalf("ResolveNamespaced(...): unexpected error: %v", err)
+	}
+	want := NamespacedPolymorphicResolutionResponse{
+		ResolvedValue:     "subnet-1",
+		ResolvedReference: &v1alpha1.NamespacedPolymorphicReference{Kind: "Subnet", Name: "a", Namespace: "ns"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ResolveNamespaced(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/unstructured/composed"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const fieldPathManagementPolicies = "spec.managementPolicies"
+
+var (
+	_ xpresource.Managed     = &UnstructuredManaged{}
+	_ xpresource.ManagedList = &UnstructuredManagedList{}
+)
+
+// UnstructuredManaged is a managed resource whose Go type is not known, such
+// as a managed resource of another provider. It's used as the target of the
+// references to the kinds of the other providers, so that the referencing
+// provider does not need a Go dependency on their API packages.
+// The referencing provider must request the permissions to get, list & watch
+// the referenced kind via the permissionRequests of its package metadata, and
+// the CRD of the kind must be installed for the reference to be resolved.
+type UnstructuredManaged struct {
+	composed.Unstructured
+}
+
+// GetManagementPolicies of this UnstructuredManaged.
+func (u *UnstructuredManaged) GetManagementPolicies() xpv2.ManagementPolicies {
+	p := xpv2.ManagementPolicies{}
+	_ = fieldpath.Pave(u.Object).GetValueInto(fieldPathManagementPolicies, &p)
+	return p
+}
+
+// SetManagementPolicies of this UnstructuredManaged.
+func (u *UnstructuredManaged) SetManagementPolicies(p xpv2.ManagementPolicies) {
+	_ = fieldpath.Pave(u.Object).SetValue(fieldPathManagementPolicies, p)
+}
+
+// UnstructuredManagedList is a list of UnstructuredManaged resources.
+type UnstructuredManagedList struct {
+	composed.UnstructuredList
+}
+
+// GetItems of this UnstructuredManagedList.
+func (l *UnstructuredManagedList) GetItems() []xpresource.Managed {
+	items := make([]xpresource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &UnstructuredManaged{Unstructured: composed.Unstructured{Unstructured: l.Items[i]}}
+	}
+	return items
+}
+
+// NewUnstructuredManaged returns a new UnstructuredManaged and a new
+// UnstructuredManagedList of the specified group, version and kind. The list
+// kind is <kind>List.
+func NewUnstructuredManaged(group, version, kind string) (*UnstructuredManaged, *UnstructuredManagedList) {
+	m := &UnstructuredManaged{Unstructured: composed.Unstructured{Unstructured: unstructured.Unstructured{Object: make(map[string]any)}}}
+	m.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind})
+	l := &UnstructuredManagedList{UnstructuredList: composed.UnstructuredList{UnstructuredList: unstructured.UnstructuredList{Object: make(map[string]any)}}}
+	l.SetGroupVersionKind(schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"})
+	return m, l
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpref "github.com/crossplane/crossplane-runtime/v2/pkg/reference"
+	xpfake "github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUnstructuredManagedResolution(t *testing.T) {
+	lb := map[string]any{
+		"apiVersion": "elbv2.aws.upbound.io/v1beta1",
+		"kind":       "LB",
+		"metadata": map[string]any{
+			"name": "example",
+			"annotations": map[string]any{
+				meta.AnnotationKeyExternalName: "example-lb",
+			},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{
+				"dnsName": "example.elb.amazonaws.com",
+			},
+		},
+	}
+	type args struct {
+		extract xpref.ExtractValueFn
+		ref     *xpv2.Reference
+		sel     *xpv2.Selector
+	}
+	type want struct {
+		rsp xpref.ResolutionResponse
+		gvk schema.GroupVersionKind
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ReferenceWithFieldPath": {
+			reason: "A referenced object of another provider should be fetched as an unstructured object and the field path should be extracted.",
+			args: args{
+				extract: ExtractFieldPath("status.atProvider.dnsName"),
+				ref:     &xpv2.Reference{Name: "example"},
+			},
+			want: want{
+				rsp: xpref.ResolutionResponse{ResolvedValue: "example.elb.amazonaws.com", ResolvedReference: &xpv2.Reference{Name: "example"}},
+				gvk: schema.GroupVersionKind{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LB"},
+			},
+		},
+		"SelectorWithExternalName": {
+			reason: "A selected object of another provider should be listed as an unstructured object and its external name should be extracted.",
+			args: args{
+				extract: xpref.ExternalName(),
+				sel:     &xpv2.Selector{MatchLabels: map[string]string{"env": "test"}},
+			},
+			want: want{
+				rsp: xpref.ResolutionResponse{ResolvedValue: "example-lb", ResolvedReference: &xpv2.Reference{Name: "example"}},
+				gvk: schema.GroupVersionKind{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LBList"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var gvk schema.GroupVersionKind
+			c := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					gvk = obj.GetObjectKind().GroupVersionKind()
+					obj.(*UnstructuredManaged).SetUnstructuredContent(lb)
+					return nil
+				},
+				MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+					gvk = obj.GetObjectKind().GroupVersionKind()
+					obj.(*UnstructuredManagedList).Items = []unstructured.Unstructured{{Object: lb}}
+					return nil
+				},
+			}
+			m, l := NewUnstructuredManaged("elbv2.aws.upbound.io", "v1beta1", "LB")
+			got, err := xpref.NewAPIResolver(c, &xpfake.Managed{}).Resolve(context.TODO(), xpref.ResolutionRequest{
+				Reference: tc.args.ref,
+				Selector:  tc.args.sel,
+				To:        xpref.To{Managed: m, List: l},
+				Extract:   tc.args.extract,
+			})
+			if err != nil {
+				t.Fatalf("\n%s\nResolve(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.rsp, got); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.gvk, gvk); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want GVK, +got GVK:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
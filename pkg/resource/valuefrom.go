@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/fieldpath"
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/apis/valuefrom/v1alpha1"
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+const (
+	prefixForProvider = "spec.forProvider."
+
+	errFmtGetValueFromSource  = "cannot get the value source at %s"
+	errFmtValueAndValueFrom   = "only one of %s and its value source %s can be set"
+	errFmtResolveValueFrom    = "cannot resolve the value of %s from its source"
+	errFmtConvertValueFrom    = "cannot convert the value of %s resolved from its source"
+	errFmtGetConfigMap        = "cannot get the ConfigMap %s"
+	errFmtGetSecret           = "cannot get the Secret %s"
+	errFmtGetObject           = "cannot get the %s %s"
+	errFmtKeyNotFound         = "cannot find the key %q in %s"
+	errFmtGetObjectField      = "cannot get the field %q of the %s %s"
+	errFmtNonScalarField      = "field %q of the %s %s is not a scalar value"
+	errFmtOtherNamespace      = "cannot select the objects in the namespace %q from the managed resource's namespace %q"
+	errFmtObservedSecret      = "%s cannot be sourced from a Secret as it is reported in status.atProvider"
+	errNamespaceRequired      = "namespace of the selected object must be set for a cluster scoped managed resource"
+	errNoValueSource          = "exactly one of configMapKeyRef, secretKeyRef and fieldRef must be set"
+	errFmtUnsupportedDataType = "unsupported Terraform type %s"
+)
+
+// GetValueFromParameters resolves the values of the top-level arguments
+// configured with the ValueFrom schema element option from the sources
+// specified in their accompanying `spec.forProvider.<field>From` fields, and
+// stores them into the Terraform configuration. The values are resolved every
+// time the configuration is built, so a change in a source is observed as a
+// diff against the Terraform state and the external resource is updated.
+func GetValueFromParameters(ctx context.Context, kube client.Reader, cfg *config.Resource, from xpresource.Managed, into map[string]any) error {
+	fields := cfg.ValueFromFields()
+	if len(fields) == 0 {
+		return nil
+	}
+	paved, err := fieldpath.PaveObject(from)
+	if err != nil {
+		return err
+	}
+	for _, tfName := range slices.Sorted(maps.Keys(fields)) {
+		srcPath := prefixForProvider + fields[tfName]
+		src := &v1alpha1.ValueFromSource{}
+		if err := paved.GetValueInto(srcPath, src); err != nil {
+			if fieldpath.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, errFmtGetValueFromSource, srcPath)
+		}
+		// the value can still be set in spec.initProvider, which is
+		// overridden by the value source like any other forProvider value.
+		valuePath := prefixForProvider + strings.TrimSuffix(fields[tfName], "From")
+		if _, err := paved.GetValue(valuePath); err == nil {
+			return errors.Errorf(errFmtValueAndValueFrom, valuePath, srcPath)
+		}
+		var sch *schema.Schema
+		if cfg.TerraformResource != nil {
+			sch = cfg.TerraformResource.Schema[tfName]
+		}
+		// the values of the non-sensitive arguments are reported in
+		// status.atProvider, which would expose a Secret key to anyone who
+		// can read the managed resource. This is also enforced by the
+		// validation rule of the value source.
+		if src.SecretKeyRef != nil && (sch == nil || !sch.Sensitive) {
+			return errors.Errorf(errFmtObservedSecret, valuePath)
+		}
+		v, err := resolveValueFrom(ctx, kube, from.GetNamespace(), src)
+		if err != nil {
+			return errors.Wrapf(err, errFmtResolveValueFrom, valuePath)
+		}
+		cv, err := convertValueFrom(v, sch)
+		if err != nil {
+			return errors.Wrapf(err, errFmtConvertValueFrom, valuePath)
+		}
+		into[tfName] = cv
+	}
+	return nil
+}
+
+func resolveValueFrom(ctx context.Context, kube client.Reader, mrNamespace string, src *v1alpha1.ValueFromSource) (string, error) {
+	switch {
+	case src.ConfigMapKeyRef != nil:
+		ns, err := valueFromNamespace(mrNamespace, src.ConfigMapKeyRef.Namespace, true)
+		if err != nil {
+			return "", err
+		}
+		nn := types.NamespacedName{Namespace: ns, Name: src.ConfigMapKeyRef.Name}
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, nn, cm); err != nil {
+			return "", errors.Wrapf(err, errFmtGetConfigMap, nn)
+		}
+		if v, ok := cm.Data[src.ConfigMapKeyRef.Key]; ok {
+			return v, nil
+		}
+		if v, ok := cm.BinaryData[src.ConfigMapKeyRef.Key]; ok {
+			return string(v), nil
+		}
+		return "", errors.Errorf(errFmtKeyNotFound, src.ConfigMapKeyRef.Key, "the ConfigMap "+nn.String())
+	case src.SecretKeyRef != nil:
+		ns, err := valueFromNamespace(mrNamespace, src.SecretKeyRef.Namespace, true)
+		if err != nil {
+			return "", err
+		}
+		nn := types.NamespacedName{Namespace: ns, Name: src.SecretKeyRef.Name}
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, nn, s); err != nil {
+			return "", errors.Wrapf(err, errFmtGetSecret, nn)
+		}
+		v, ok := s.Data[src.SecretKeyRef.Key]
+		if !ok {
+			return "", errors.Errorf(errFmtKeyNotFound, src.SecretKeyRef.Key, "the Secret "+nn.String())
+		}
+		return string(v), nil
+	case src.FieldRef != nil:
+		return resolveFieldRef(ctx, kube, mrNamespace, src.FieldRef)
+	default:
+		return "", errors.New(errNoValueSource)
+	}
+}
+
+func resolveFieldRef(ctx context.Context, kube client.Reader, mrNamespace string, ref *v1alpha1.ObjectFieldSelector) (string, error) {
+	ns, err := valueFromNamespace(mrNamespace, ref.Namespace, false)
+	if err != nil {
+		return "", err
+	}
+	nn := types.NamespacedName{Namespace: ns, Name: ref.Name}
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+	if err := kube.Get(ctx, nn, u); err != nil {
+		return "", errors.Wrapf(err, errFmtGetObject, ref.Kind, nn)
+	}
+	v, err := fieldpath.Pave(u.Object).GetValue(ref.FieldPath)
+	if err != nil {
+		return "", errors.Wrapf(err, errFmtGetObjectField, ref.FieldPath, ref.Kind, nn)
+	}
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case int64, bool:
+		return fmt.Sprint(t), nil
+	default:
+		return "", errors.Errorf(errFmtNonScalarField, ref.FieldPath, ref.Kind, nn)
+	}
+}
+
+// valueFromNamespace returns the namespace of the selected object. The
+// namespaced managed resources can only select the objects in their own
+// namespaces, as is the case with their secret references.
+func valueFromNamespace(mrNamespace, namespace string, required bool) (string, error) {
+	if mrNamespace != "" {
+		if namespace != "" && namespace != mrNamespace {
+			return "", errors.Errorf(errFmtOtherNamespace, namespace, mrNamespace)
+		}
+		return mrNamespace, nil
+	}
+	if namespace == "" && required {
+		return "", errors.New(errNamespaceRequired)
+	}
+	return namespace, nil
+}
+
+// convertValueFrom converts the resolved string value to the type of the
+// Terraform argument. The numbers are represented as float64 as is the case
+// with the parameters deserialized from the spec.
+func convertValueFrom(v string, sch *schema.Schema) (any, error) {
+	if sch == nil {
+		return v, nil
+	}
+	switch sch.Type { //nolint:exhaustive // only the primitive arguments can be configured with a value source
+	case schema.TypeString:
+		return v, nil
+	case schema.TypeInt:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		return float64(i), errors.Wrap(err, "cannot parse the value as an integer")
+	case schema.TypeFloat:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, errors.Wrap(err, "cannot parse the value as a number")
+	case schema.TypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		return b, errors.Wrap(err, "cannot parse the value as a boolean")
+	default:
+		return nil, errors.Errorf(errFmtUnsupportedDataType, sch.Type)
+	}
+}
@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	xpresource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+func newValueFromTestResource(namespace string, forProvider map[string]any) xpresource.Managed {
+	mr, _ := NewUnstructuredManaged("ec2.aws.upbound.io", "v1beta1", "Instance")
+	mr.SetUnstructuredContent(map[string]any{
+		"apiVersion": "ec2.aws.upbound.io/v1beta1",
+		"kind":       "Instance",
+		"metadata": map[string]any{
+			"name":      "example",
+			"namespace": namespace,
+		},
+		"spec": map[string]any{
+			"forProvider": forProvider,
+		},
+	})
+	return mr
+}
+
+func newValueFromTestClient() client.Reader {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				if key != (types.NamespacedName{Namespace: "default", Name: "settings"}) {
+					return errors.New("unexpected ConfigMap")
+				}
+				o.Data = map[string]string{"instanceType": "t3.micro", "port": "8080"}
+			case *corev1.Secret:
+				if key != (types.NamespacedName{Namespace: "default", Name: "settings"}) {
+					return errors.New("unexpected Secret")
+				}
+				o.Data = map[string][]byte{"port": []byte("8080")}
+			case *unstructured.Unstructured:
+				if o.GetKind() != "VPC" || key != (types.NamespacedName{Name: "main"}) {
+					return errors.New("unexpected object")
+				}
+				o.Object["status"] = map[string]any{
+					"atProvider": map[string]any{"enableDnsSupport": true},
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func TestGetValueFromParameters(t *testing.T) {
+	cfg := &config.Resource{
+		TerraformResource: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"instance_type":      {Type: schema.TypeString, Optional: true},
+				"port":               {Type: schema.TypeInt, Optional: true},
+				"enable_dns_support": {Type: schema.TypeBool, Optional: true},
+			},
+		},
+		SchemaElementOptions: config.SchemaElementOptions{},
+	}
+	cfg.SchemaElementOptions.SetValueFrom("instance_type")
+	cfg.SchemaElementOptions.SetValueFrom("port")
+	cfg.SchemaElementOptions.SetValueFrom("enable_dns_support")
+
+	type args struct {
+		cfg  *config.Resource
+		from xpresource.Managed
+		into map[string]any
+	}
+	type want struct {
+		into map[string]any
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoValueFromFields": {
+			reason: "The parameters should be left intact if no field is configured with a value source.",
+			args: args{
+				cfg:  &config.Resource{},
+				from: newValueFromTestResource("default", map[string]any{}),
+				into: map[string]any{"instance_type": "t3.small"},
+			},
+			want: want{
+				into: map[string]any{"instance_type": "t3.small"},
+			},
+		},
+		"ResolveAllSources": {
+			reason: "The values should be resolved from the ConfigMap and converted to the argument types.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("default", map[string]any{
+					"instanceTypeFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "instanceType"},
+					},
+					"portFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "port"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{"instance_type": "t3.micro", "port": float64(8080)},
+			},
+		},
+		"SecretForObservedArgument": {
+			reason: "A Secret key should not be sourced into an argument reported in status.atProvider.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("", map[string]any{
+					"portFrom": map[string]any{
+						"secretKeyRef": map[string]any{"name": "settings", "namespace": "default", "key": "port"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{},
+				err:  errors.Errorf(errFmtObservedSecret, "spec.forProvider.port"),
+			},
+		},
+		"ResolveFieldRefFromClusterScoped": {
+			reason: "A cluster scoped managed resource should be able to select a field of a cluster scoped object.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("", map[string]any{
+					"enableDnsSupportFrom": map[string]any{
+						"fieldRef": map[string]any{"apiVersion": "ec2.aws.upbound.io/v1beta1", "kind": "VPC", "name": "main", "fieldPath": "status.atProvider.enableDnsSupport"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{"enable_dns_support": true},
+			},
+		},
+		"ValueAndValueFrom": {
+			reason: "An error should be returned if both the value and its source are set.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("default", map[string]any{
+					"instanceType": "t3.small",
+					"instanceTypeFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "instanceType"},
+					},
+				}),
+				into: map[string]any{"instance_type": "t3.small"},
+			},
+			want: want{
+				into: map[string]any{"instance_type": "t3.small"},
+				err:  errors.Errorf(errFmtValueAndValueFrom, "spec.forProvider.instanceType", "spec.forProvider.instanceTypeFrom"),
+			},
+		},
+		"OtherNamespace": {
+			reason: "A namespaced managed resource should not be able to select the objects in other namespaces.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("default", map[string]any{
+					"instanceTypeFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "namespace": "other", "key": "instanceType"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{},
+				err:  errors.Wrapf(errors.Errorf(errFmtOtherNamespace, "other", "default"), errFmtResolveValueFrom, "spec.forProvider.instanceType"),
+			},
+		},
+		"NamespaceRequired": {
+			reason: "A cluster scoped managed resource should specify the namespace of the selected ConfigMap.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("", map[string]any{
+					"portFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "port"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{},
+				err:  errors.Wrapf(errors.New(errNamespaceRequired), errFmtResolveValueFrom, "spec.forProvider.port"),
+			},
+		},
+		"KeyNotFound": {
+			reason: "An error should be returned if the selected key does not exist.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("default", map[string]any{
+					"instanceTypeFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "size"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{},
+				err:  errors.Wrapf(errors.Errorf(errFmtKeyNotFound, "size", "the ConfigMap default/settings"), errFmtResolveValueFrom, "spec.forProvider.instanceType"),
+			},
+		},
+		"InvalidValue": {
+			reason: "An error should be returned if the resolved value cannot be converted to the argument type.",
+			args: args{
+				cfg: cfg,
+				from: newValueFromTestResource("default", map[string]any{
+					"portFrom": map[string]any{
+						"configMapKeyRef": map[string]any{"name": "settings", "key": "instanceType"},
+					},
+				}),
+				into: map[string]any{},
+			},
+			want: want{
+				into: map[string]any{},
+				err:  errors.Wrapf(errors.Wrap(errors.New(`strconv.ParseInt: parsing "t3.micro": invalid syntax`), "cannot parse the value as an integer"), errFmtConvertValueFrom, "spec.forProvider.port"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := GetValueFromParameters(context.Background(), newValueFromTestClient(), tc.args.cfg, tc.args.from, tc.args.into)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nGetValueFromParameters(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.into, tc.args.into); diff != "" {
+				t.Errorf("\n%s\nGetValueFromParameters(...): -want params, +got params:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonschema converts the OpenAPI v3 schemas of the generated CRDs
+// into standalone JSON Schema documents, which can be used by editors and
+// linters to validate the managed resource manifests offline.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+	"github.com/spf13/afero"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// MetaSchema is the JSON Schema dialect of the generated schemas.
+	MetaSchema = "http://json-schema.org/draft-07/schema#"
+	// CatalogFile is the name of the catalog file of a JSON Schema bundle.
+	CatalogFile = "catalog.json"
+	// catalogMetaSchema is the schema of the catalog file.
+	catalogMetaSchema = "https://json.schemastore.org/schema-catalog.json"
+
+	prefixKubernetesExtension = "x-kubernetes-"
+
+	errFmtReadCRD      = "cannot read the CRD file %s"
+	errFmtParseCRD     = "cannot parse the CRD file %s"
+	errFmtConvert      = "cannot convert the OpenAPI v3 schema of the version %q of the CRD %q"
+	errFmtWriteSchema  = "cannot write the JSON Schema file %s"
+	errReadCRDDir      = "cannot read the CRD directory"
+	errMarshalCatalog  = "cannot marshal the JSON Schema catalog"
+	errWriteCatalog    = "cannot write the JSON Schema catalog"
+	errMarshalSchema   = "cannot marshal the JSON Schema"
+	errUnmarshalSchema = "cannot unmarshal the OpenAPI v3 schema"
+)
+
+// Schema is the JSON Schema of a specific group, version and kind.
+type Schema struct {
+	Group   string
+	Version string
+	Kind    string
+	// ID is the stable $id URL of the schema.
+	ID string
+	// Path is the slash-separated path of the schema file relative to the
+	// root directory of the bundle, i.e., <group>/<kind>_<version>.json.
+	Path string
+	// Document is the JSON Schema document.
+	Document map[string]any
+}
+
+// Catalog is the index of the schemas in a JSON Schema bundle. It follows
+// the JSON Schema Store catalog format.
+type Catalog struct {
+	Schema  string         `json:"$schema"`
+	Version int            `json:"version"`
+	Schemas []CatalogEntry `json:"schemas"`
+}
+
+// CatalogEntry is an entry of a Catalog.
+type CatalogEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	Path        string `json:"path"`
+	APIVersion  string `json:"apiVersion"`
+	Kind        string `json:"kind"`
+}
+
+// ReadCRDs reads the CRDs in the YAML files of the specified directory.
+func ReadCRDs(fs afero.Fs, dir string) ([]*extv1.CustomResourceDefinition, error) {
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadCRDDir)
+	}
+	crds := make([]*extv1.CustomResourceDefinition, 0, len(entries))
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if e.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		p := filepath.Join(dir, e.Name())
+		data, err := afero.ReadFile(fs, p)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtReadCRD, p)
+		}
+		crd := &extv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return nil, errors.Wrapf(err, errFmtParseCRD, p)
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// FromCRD returns the JSON Schemas of the served versions of the specified
+// CRD. The $id URLs of the schemas are derived from the specified root group
+// of the provider, so that they are stable across the provider releases.
+// The reference & selector fields of the managed resources are kept as they
+// are in the CRD schema. The objects with known properties do not allow
+// additional properties so that the misspelled fields are reported.
+func FromCRD(crd *extv1.CustomResourceDefinition, rootGroup string) ([]*Schema, error) {
+	var schemas []*Schema
+	for _, v := range crd.Spec.Versions {
+		if !v.Served || v.Schema == nil || v.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		doc, err := convert(v.Schema.OpenAPIV3Schema)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvert, v.Name, crd.Name)
+		}
+		s := &Schema{
+			Group:    crd.Spec.Group,
+			Version:  v.Name,
+			Kind:     crd.Spec.Names.Kind,
+			Path:     path.Join(crd.Spec.Group, fmt.Sprintf("%s_%s.json", strings.ToLower(crd.Spec.Names.Kind), v.Name)),
+			Document: doc,
+		}
+		s.ID = fmt.Sprintf("https://%s/schemas/%s", rootGroup, s.Path)
+		doc["$schema"] = MetaSchema
+		doc["$id"] = s.ID
+		doc["title"] = fmt.Sprintf("%s (%s/%s)", s.Kind, s.Group, s.Version)
+		// pin the apiVersion & kind so that a schema only matches the
+		// manifests of its own group, version and kind.
+		props, _ := doc["properties"].(map[string]any)
+		if props == nil {
+			props = map[string]any{}
+			doc["properties"] = props
+		}
+		props["apiVersion"] = map[string]any{"type": "string", "enum": []any{s.Group + "/" + s.Version}}
+		props["kind"] = map[string]any{"type": "string", "enum": []any{s.Kind}}
+		doc["required"] = appendMissing(doc["required"], "apiVersion", "kind")
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+// NewCatalog returns the catalog of the specified schemas.
+func NewCatalog(schemas []*Schema) *Catalog {
+	c := &Catalog{
+		Schema:  catalogMetaSchema,
+		Version: 1,
+		Schemas: make([]CatalogEntry, 0, len(schemas)),
+	}
+	for _, s := range schemas {
+		desc, _ := s.Document["description"].(string)
+		c.Schemas = append(c.Schemas, CatalogEntry{
+			Name:        fmt.Sprintf("%s.%s/%s", s.Kind, s.Group, s.Version),
+			Description: desc,
+			URL:         s.ID,
+			Path:        s.Path,
+			APIVersion:  s.Group + "/" + s.Version,
+			Kind:        s.Kind,
+		})
+	}
+	slices.SortFunc(c.Schemas, func(a, b CatalogEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+	return c
+}
+
+// WriteBundle writes the specified schemas and their catalog under the
+// specified directory.
+func WriteBundle(fs afero.Fs, dir string, schemas []*Schema) error {
+	for _, s := range schemas {
+		p := filepath.Join(dir, filepath.FromSlash(s.Path))
+		data, err := json.MarshalIndent(s.Document, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, errMarshalSchema)
+		}
+		if err := fs.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+			return errors.Wrapf(err, errFmtWriteSchema, p)
+		}
+		if err := afero.WriteFile(fs, p, append(data, '\n'), 0o600); err != nil {
+			return errors.Wrapf(err, errFmtWriteSchema, p)
+		}
+	}
+	data, err := json.MarshalIndent(NewCatalog(schemas), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errMarshalCatalog)
+	}
+	if err := fs.MkdirAll(dir, 0o750); err != nil {
+		return errors.Wrap(err, errWriteCatalog)
+	}
+	return errors.Wrap(afero.WriteFile(fs, filepath.Join(dir, CatalogFile), append(data, '\n'), 0o600), errWriteCatalog)
+}
+
+// convert converts the specified OpenAPI v3 schema into a JSON Schema
+// document.
+func convert(s *extv1.JSONSchemaProps) (map[string]any, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalSchema)
+	}
+	doc := map[string]any{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalSchema)
+	}
+	convertNode(doc)
+	return doc, nil
+}
+
+// convertNode converts the Kubernetes OpenAPI v3 extensions of the specified
+// schema node, and of its children, into their JSON Schema equivalents.
+func convertNode(n map[string]any) { //nolint:gocyclo // easier to follow as a unit
+	for _, k := range []string{"properties", "patternProperties", "definitions"} {
+		if children, ok := n[k].(map[string]any); ok {
+			for _, c := range children {
+				if c, ok := c.(map[string]any); ok {
+					convertNode(c)
+				}
+			}
+		}
+	}
+	for _, k := range []string{"items", "additionalProperties", "not"} {
+		if c, ok := n[k].(map[string]any); ok {
+			convertNode(c)
+		}
+	}
+	for _, k := range []string{"items", "allOf", "anyOf", "oneOf"} {
+		if children, ok := n[k].([]any); ok {
+			for _, c := range children {
+				if c, ok := c.(map[string]any); ok {
+					convertNode(c)
+				}
+			}
+		}
+	}
+
+	preserveUnknown, _ := n[prefixKubernetesExtension+"preserve-unknown-fields"].(bool)
+	if intOrString, _ := n[prefixKubernetesExtension+"int-or-string"].(bool); intOrString {
+		delete(n, "type")
+		n["anyOf"] = []any{map[string]any{"type": "integer"}, map[string]any{"type": "string"}}
+	}
+	if _, ok := n["properties"]; ok && !preserveUnknown {
+		if _, ok := n["additionalProperties"]; !ok {
+			n["additionalProperties"] = false
+		}
+	}
+	if nullable, _ := n["nullable"].(bool); nullable {
+		if t, ok := n["type"].(string); ok {
+			n["type"] = []any{t, "null"}
+		}
+	}
+	delete(n, "nullable")
+	for k := range n {
+		if strings.HasPrefix(k, prefixKubernetesExtension) {
+			delete(n, k)
+		}
+	}
+}
+
+// appendMissing appends the specified values to the specified JSON array if
+// they are not already in it.
+func appendMissing(arr any, values ...string) []any {
+	result, _ := arr.([]any)
+	for _, v := range values {
+		if !slices.Contains(result, any(v)) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/afero"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+const testCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: subnets.ec2.aws.upbound.io
+spec:
+  group: ec2.aws.upbound.io
+  names:
+    kind: Subnet
+    plural: subnets
+  scope: Cluster
+  versions:
+  - name: v1beta1
+    served: false
+    schema:
+      openAPIV3Schema:
+        type: object
+  - name: v1beta2
+    served: true
+    schema:
+      openAPIV3Schema:
+        description: Subnet is the Schema for the Subnets API.
+        type: object
+        properties:
+          apiVersion:
+            type: string
+          kind:
+            type: string
+          metadata:
+            type: object
+          spec:
+            type: object
+            x-kubernetes-validations:
+            - rule: has(self.forProvider.region)
+              message: spec.forProvider.region is a required parameter
+            properties:
+              forProvider:
+                type: object
+                properties:
+                  cidrBlock:
+                    type: string
+                    nullable: true
+                  port:
+                    x-kubernetes-int-or-string: true
+                  tags:
+                    type: object
+                    additionalProperties:
+                      type: string
+                  vpcId:
+                    type: string
+                  vpcIdRef:
+                    type: object
+                    properties:
+                      name:
+                        type: string
+                    required:
+                    - name
+                  vpcIdSelector:
+                    type: object
+                    properties:
+                      matchLabels:
+                        type: object
+                        additionalProperties:
+                          type: string
+                  settings:
+                    type: object
+                    x-kubernetes-preserve-unknown-fields: true
+                  rules:
+                    type: array
+                    x-kubernetes-list-type: atomic
+                    items:
+                      type: object
+                      properties:
+                        from:
+                          type: integer
+                          format: int64
+          status:
+            type: object
+`
+
+func TestFromCRD(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	if err := afero.WriteFile(fs, "/crds/ec2.aws.upbound.io_subnets.yaml", []byte(testCRD), 0o600); err != nil {
+		t.Fatalf("WriteFile(...): unexpected error: %v", err)
+	}
+	if err := afero.WriteFile(fs, "/crds/README.md", []byte("not a CRD"), 0o600); err != nil {
+		t.Fatalf("WriteFile(...): unexpected error: %v", err)
+	}
+	crds, err := ReadCRDs(fs, "/crds")
+	if err != nil {
+		t.Fatalf("ReadCRDs(...): unexpected error: %v", err)
+	}
+	if len(crds) != 1 {
+		t.Fatalf("ReadCRDs(...): want 1 CRD, got %d", len(crds))
+	}
+	got, err := FromCRD(crds[0], "aws.upbound.io")
+	if err != nil {
+		t.Fatalf("FromCRD(...): unexpected error: %v", err)
+	}
+	stringType := map[string]any{"type": "string"}
+	want := []*Schema{{
+		Group:   "ec2.aws.upbound.io",
+		Version: "v1beta2",
+		Kind:    "Subnet",
+		ID:      "https://aws.upbound.io/schemas/ec2.aws.upbound.io/subnet_v1beta2.json",
+		Path:    "ec2.aws.upbound.io/subnet_v1beta2.json",
+		Document: map[string]any{
+			"$schema":              MetaSchema,
+			"$id":                  "https://aws.upbound.io/schemas/ec2.aws.upbound.io/subnet_v1beta2.json",
+			"title":                "Subnet (ec2.aws.upbound.io/v1beta2)",
+			"description":          "Subnet is the Schema for the Subnets API.",
+			"type":                 "object",
+			"additionalProperties": false,
+			"required":             []any{"apiVersion", "kind"},
+			"properties": map[string]any{
+				"apiVersion": map[string]any{"type": "string", "enum": []any{"ec2.aws.upbound.io/v1beta2"}},
+				"kind":       map[string]any{"type": "string", "enum": []any{"Subnet"}},
+				"metadata":   map[string]any{"type": "object"},
+				"spec": map[string]any{
+					"type":                 "object",
+					"additionalProperties": false,
+					"properties": map[string]any{
+						"forProvider": map[string]any{
+							"type":                 "object",
+							"additionalProperties": false,
+							"properties": map[string]any{
+								"cidrBlock": map[string]any{"type": []any{"string", "null"}},
+								"port": map[string]any{
+									"anyOf": []any{map[string]any{"type": "integer"}, map[string]any{"type": "string"}},
+								},
+								"tags": map[string]any{
+									"type":                 "object",
+									"additionalProperties": stringType,
+								},
+								"vpcId": stringType,
+								"vpcIdRef": map[string]any{
+									"type":                 "object",
+									"additionalProperties": false,
+									"properties":           map[string]any{"name": stringType},
+									"required":             []any{"name"},
+								},
+								"vpcIdSelector": map[string]any{
+									"type":                 "object",
+									"additionalProperties": false,
+									"properties": map[string]any{
+										"matchLabels": map[string]any{
+											"type":                 "object",
+											"additionalProperties": stringType,
+										},
+									},
+								},
+								"settings": map[string]any{"type": "object"},
+								"rules": map[string]any{
+									"type": "array",
+									"items": map[string]any{
+										"type":                 "object",
+										"additionalProperties": false,
+										"properties": map[string]any{
+											"from": map[string]any{"type": "integer", "format": "int64"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"status": map[string]any{"type": "object"},
+			},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FromCRD(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	schemas := []*Schema{
+		{
+			Group:    "ec2.aws.upbound.io",
+			Version:  "v1beta1",
+			Kind:     "VPC",
+			ID:       "https://aws.upbound.io/schemas/ec2.aws.upbound.io/vpc_v1beta1.json",
+			Path:     "ec2.aws.upbound.io/vpc_v1beta1.json",
+			Document: map[string]any{"description": "VPC is the Schema for the VPCs API."},
+		},
+		{
+			Group:    "ec2.aws.upbound.io",
+			Version:  "v1beta1",
+			Kind:     "Subnet",
+			ID:       "https://aws.upbound.io/schemas/ec2.aws.upbound.io/subnet_v1beta1.json",
+			Path:     "ec2.aws.upbound.io/subnet_v1beta1.json",
+			Document: map[string]any{"type": "object"},
+		},
+	}
+	if err := WriteBundle(fs, "/out", schemas); err != nil {
+		t.Fatalf("WriteBundle(...): unexpected error: %v", err)
+	}
+	got, err := afero.ReadFile(fs, "/out/ec2.aws.upbound.io/subnet_v1beta1.json")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("{\n  \"type\": \"object\"\n}\n", string(got)); diff != "" {
+		t.Errorf("WriteBundle(...): -want schema, +got schema:\n%s", diff)
+	}
+	got, err = afero.ReadFile(fs, "/out/catalog.json")
+	if err != nil {
+		t.Fatalf("ReadFile(...): unexpected error: %v", err)
+	}
+	want := `{
+  "$schema": "https://json.schemastore.org/schema-catalog.json",
+  "version": 1,
+  "schemas": [
+    {
+      "name": "Subnet.ec2.aws.upbound.io/v1beta1",
+      "url": "https://aws.upbound.io/schemas/ec2.aws.upbound.io/subnet_v1beta1.json",
+      "path": "ec2.aws.upbound.io/subnet_v1beta1.json",
+      "apiVersion": "ec2.aws.upbound.io/v1beta1",
+      "kind": "Subnet"
+    },
+    {
+      "name": "VPC.ec2.aws.upbound.io/v1beta1",
+      "description": "VPC is the Schema for the VPCs API.",
+      "url": "https://aws.upbound.io/schemas/ec2.aws.upbound.io/vpc_v1beta1.json",
+      "path": "ec2.aws.upbound.io/vpc_v1beta1.json",
+      "apiVersion": "ec2.aws.upbound.io/v1beta1",
+      "kind": "VPC"
+    }
+  ]
+}
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("WriteBundle(...): -want catalog, +got catalog:\n%s", diff)
+	}
+}
+
+func TestFromCRDNoServedVersions(t *testing.T) {
+	crd := &extv1.CustomResourceDefinition{
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Versions: []extv1.CustomResourceDefinitionVersion{{Name: "v1beta1"}},
+		},
+	}
+	got, err := FromCRD(crd, "aws.upbound.io")
+	if err != nil {
+		t.Fatalf("FromCRD(...): unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("FromCRD(...): want no schemas, got %d", len(got))
+	}
+}
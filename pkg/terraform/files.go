@@ -180,9 +180,11 @@ type FileProducer struct {
 // inspection for tests.  WriteMainTF calls this function and serializes the result to a file as JSON.
 func (fp *FileProducer) BuildMainTF() map[string]any {
 	// If the resource is in a deletion process, we need to remove the deletion
-	// protection.
+	// protection. The protection is also removed in the plan-only
+	// reconciliation mode, in which the plan is never applied, so that
+	// Terraform can plan a replacement instead of failing the plan.
 	lifecycle := map[string]any{
-		"prevent_destroy": !meta.WasDeleted(fp.Resource),
+		"prevent_destroy": !meta.WasDeleted(fp.Resource) && !resource.IsPlanOnly(fp.Resource),
 	}
 
 	if len(fp.ignored) != 0 {
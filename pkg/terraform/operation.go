@@ -5,6 +5,7 @@
 package terraform
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -17,6 +18,12 @@ type Operation struct {
 	endTime   *time.Time
 	err       error
 	mu        sync.RWMutex
+
+	// generation is the generation of the managed resource the operation
+	// has been started for.
+	generation int64
+	cancel     context.CancelFunc
+	superseded bool
 }
 
 // MarkStart marks the operation as started atomically after checking
@@ -32,15 +39,58 @@ func (o *Operation) MarkStart(t string) bool {
 	o.Type = t
 	o.startTime = &now
 	o.endTime = nil
+	o.generation = 0
+	o.cancel = nil
+	o.superseded = false
+	return true
+}
+
+// SetCancel makes the running operation cancelable with the given function,
+// which should cancel the context of the operation. The operation has been
+// started for the given generation of the managed resource.
+func (o *Operation) SetCancel(generation int64, cancel context.CancelFunc) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.generation = generation
+	o.cancel = cancel
+}
+
+// Generation returns the generation of the managed resource the current
+// operation has been started for, if the operation is cancelable.
+func (o *Operation) Generation() int64 {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.generation
+}
+
+// Supersede cancels the running operation if it's cancelable and marks it as
+// superseded. Returns `false` if there is no cancelable running operation or
+// it has already been superseded.
+func (o *Operation) Supersede() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.startTime == nil || o.endTime != nil || o.cancel == nil || o.superseded {
+		return false
+	}
+	o.superseded = true
+	o.cancel()
 	return true
 }
 
+// IsSuperseded returns whether the current operation has been superseded.
+func (o *Operation) IsSuperseded() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.superseded
+}
+
 // MarkEnd marks the operation as ended.
 func (o *Operation) MarkEnd() {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 	now := time.Now()
 	o.endTime = &now
+	o.cancel = nil
 }
 
 // Flush cleans the operation information including the registered error from
@@ -61,6 +111,9 @@ func (o *Operation) Clear(preserveError bool) {
 	o.Type = ""
 	o.startTime = nil
 	o.endTime = nil
+	o.generation = 0
+	o.cancel = nil
+	o.superseded = false
 	if !preserveError {
 		o.err = nil
 	}
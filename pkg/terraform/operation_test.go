@@ -5,6 +5,7 @@
 package terraform
 
 import (
+	"context"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -100,6 +101,68 @@ func TestOperation(t *testing.T) {
 				result: true,
 			},
 		},
+		"Superseded": {
+			args: args{
+				calls: func(o *Operation) {
+					o.MarkStart("type")
+					ctx, cancel := context.WithCancel(context.Background())
+					o.SetCancel(2, cancel)
+					o.Supersede()
+					o.SetError(ctx.Err())
+				},
+			},
+			want: want{
+				checks: func(o *Operation) bool {
+					return o.IsRunning() && o.IsSuperseded() && o.Generation() == 2 && errors.Is(o.err, context.Canceled)
+				},
+				result: true,
+			},
+		},
+		"NotCancelable": {
+			args: args{
+				calls: func(o *Operation) {
+					o.MarkStart("type")
+				},
+			},
+			want: want{
+				checks: func(o *Operation) bool {
+					return !o.Supersede() && !o.IsSuperseded()
+				},
+				result: true,
+			},
+		},
+		"SupersededOnce": {
+			args: args{
+				calls: func(o *Operation) {
+					o.MarkStart("type")
+					o.SetCancel(1, func() {})
+					o.Supersede()
+				},
+			},
+			want: want{
+				checks: func(o *Operation) bool {
+					return !o.Supersede() && o.IsSuperseded()
+				},
+				result: true,
+			},
+		},
+		"RestartedAfterSuperseded": {
+			args: args{
+				calls: func(o *Operation) {
+					o.MarkStart("type")
+					o.SetCancel(1, func() {})
+					o.Supersede()
+					o.MarkEnd()
+					o.MarkStart("type")
+				},
+			},
+			want: want{
+				checks: func(o *Operation) bool {
+					return o.IsRunning() && !o.IsSuperseded() && o.Generation() == 0 && !o.Supersede()
+				},
+				result: true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
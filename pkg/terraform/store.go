@@ -262,6 +262,18 @@ func (ws *WorkspaceStore) Workspace(ctx context.Context, c resource.SecretClient
 	if err != nil {
 		return nil, errors.Wrap(err, errGetID)
 	}
+	// The Terraform ID returned before a failed or interrupted asynchronous
+	// create identifies the external resource if the external-name is not
+	// known yet, e.g., if the workspace has been lost by a restart.
+	if externalName == "" {
+		op, err := resource.GetAsyncOperation(fp.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if op != nil && op.ID != "" {
+			tfStateID = op.ID
+		}
+	}
 
 	// Use GetImportIDFn for the import ID when configured, otherwise
 	// fall back to GetIDFn. This allows providers where the TF import
@@ -209,3 +209,29 @@ func TestWorkspaceStoreGetImportIDFnStateVsTerraformID(t *testing.T) {
 		t.Errorf("terraform.tfstate should NOT contain import ID 'import-id-for-terraform', got:\n%s", stateStr)
 	}
 }
+
+func TestWorkspaceStoreAsyncCreateID(t *testing.T) {
+	ws := newTestWorkspaceStore()
+	cfg := newTestResource(func(r *config.Resource) {
+		r.ExternalName = config.IdentifierFromProvider
+	})
+
+	uid := types.UID("test-async-create-id")
+	tr := newTestTerraformed(uid)
+	meta.RemoveAnnotations(tr, meta.AnnotationKeyExternalName)
+	resource.SetAsyncOperation(tr, resource.AsyncOperation{Type: "create", StartTime: metav1.Now(), ID: "recorded-id"})
+	defer func() {
+		_ = os.RemoveAll(filepath.Join(os.TempDir(), string(uid)))
+	}()
+
+	if _, err := ws.Workspace(context.Background(), nil, tr, testSetup, cfg); err != nil {
+		t.Fatalf("Workspace(...): unexpected error: %v", err)
+	}
+	stateBytes, err := os.ReadFile(filepath.Join(os.TempDir(), string(uid), "terraform.tfstate"))
+	if err != nil {
+		t.Fatalf("cannot read terraform.tfstate: %v", err)
+	}
+	if !strings.Contains(string(stateBytes), "recorded-id") {
+		t.Errorf("terraform.tfstate should contain the Terraform ID recorded for the interrupted async create, got:\n%s", string(stateBytes))
+	}
+}
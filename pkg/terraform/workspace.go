@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	k8sExec "k8s.io/utils/exec"
@@ -27,6 +28,7 @@ import (
 const (
 	defaultAsyncTimeout = 1 * time.Hour
 	envReattachConfig   = "TF_REATTACH_PROVIDERS"
+	planFile            = "terraform.tfplan"
 	fmtEnv              = "%s=%s"
 )
 
@@ -160,18 +162,55 @@ func (w *Workspace) UseProvider(inuse InUse, attachmentConfig string) {
 	w.providerInUse = inuse
 }
 
+// ApplyAsyncOption configures an asynchronous apply operation.
+type ApplyAsyncOption func(*applyAsyncOptions)
+
+type applyAsyncOptions struct {
+	cancelable bool
+	generation int64
+}
+
+// WithCancelableApply makes the asynchronous apply operation cancelable, so
+// that it can be superseded by a newer generation of the managed resource
+// than the given one, or by its deletion. The Terraform CLI process running
+// a superseded apply operation is killed.
+func WithCancelableApply(generation int64) ApplyAsyncOption {
+	return func(o *applyAsyncOptions) {
+		o.cancelable = true
+		o.generation = generation
+	}
+}
+
 // ApplyAsync makes a terraform apply call without blocking and calls the given
 // function once that apply call finishes.
-func (w *Workspace) ApplyAsync(callback CallbackFn) error {
+func (w *Workspace) ApplyAsync(callback CallbackFn, opts ...ApplyAsyncOption) error {
+	o := &applyAsyncOptions{}
+	for _, f := range opts {
+		f(o)
+	}
 	if !w.LastOperation.MarkStart("apply") {
 		return errors.Errorf("%s operation that started at %s is still running", w.LastOperation.Type, w.LastOperation.StartTime().String())
 	}
 	ctx, cancel := context.WithDeadline(context.TODO(), w.LastOperation.StartTime().Add(defaultAsyncTimeout))
+	// The apply is canceled via a child context if it's superseded, so that
+	// the callback can still use the parent context.
+	opCtx, opCancel := context.WithCancel(ctx)
+	if o.cancelable {
+		w.LastOperation.SetCancel(o.generation, opCancel)
+	}
 	w.providerInUse.Increment()
 	go func() {
 		defer cancel()
-		out, err := w.runTF(ctx, ModeASync, "apply", "-auto-approve", "-input=false", "-lock=false", "-json")
-		if err != nil {
+		defer opCancel()
+		out, err := w.runTF(opCtx, ModeASync, "apply", "-auto-approve", "-input=false", "-lock=false", "-json")
+		// a superseded apply is not reported as failed, and the callback
+		// requests an immediate reconcile to schedule the superseding
+		// operation.
+		switch {
+		case err != nil && w.LastOperation.IsSuperseded():
+			w.logger.Debug("apply async superseded", "error", err)
+			err = nil
+		case err != nil:
 			err = tferrors.NewApplyFailed(out)
 		}
 		w.LastOperation.MarkEnd()
@@ -200,15 +239,25 @@ func (w *Workspace) Apply(ctx context.Context) (ApplyResult, error) {
 	if err != nil {
 		return ApplyResult{}, tferrors.NewApplyFailed(out)
 	}
+	s, err := w.State()
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	return ApplyResult{State: s}, nil
+}
+
+// State returns the Terraform state of the workspace as it's recorded in the
+// state file, e.g., after an asynchronous apply operation has ended.
+func (w *Workspace) State() (*json.StateV4, error) {
 	raw, err := w.fs.ReadFile(filepath.Join(w.dir, "terraform.tfstate"))
 	if err != nil {
-		return ApplyResult{}, errors.Wrap(err, "cannot read terraform state file")
+		return nil, errors.Wrap(err, "cannot read terraform state file")
 	}
 	s := &json.StateV4{}
 	if err := json.JSParser.Unmarshal(raw, s); err != nil {
-		return ApplyResult{}, errors.Wrap(err, "cannot unmarshal tfstate file")
+		return nil, errors.Wrap(err, "cannot unmarshal tfstate file")
 	}
-	return ApplyResult{State: s}, nil
+	return s, nil
 }
 
 // DestroyAsync makes a non-blocking terraform destroy call. It doesn't accept
@@ -280,13 +329,9 @@ func (w *Workspace) Refresh(ctx context.Context) (RefreshResult, error) {
 	if err != nil {
 		return RefreshResult{}, tferrors.NewRefreshFailed(out)
 	}
-	raw, err := w.fs.ReadFile(filepath.Join(w.dir, "terraform.tfstate"))
+	s, err := w.State()
 	if err != nil {
-		return RefreshResult{}, errors.Wrap(err, "cannot read terraform state file")
-	}
-	s := &json.StateV4{}
-	if err := json.JSParser.Unmarshal(raw, s); err != nil {
-		return RefreshResult{}, errors.Wrap(err, "cannot unmarshal tfstate file")
+		return RefreshResult{}, err
 	}
 	return RefreshResult{
 		Exists: s.GetAttributes() != nil,
@@ -299,42 +344,56 @@ func (w *Workspace) Refresh(ctx context.Context) (RefreshResult, error) {
 type PlanResult struct {
 	Exists   bool
 	UpToDate bool
+	// Replace is true if the resource is planned to be destroyed and
+	// re-created.
+	Replace bool
+	// Change is the planned change of the resource with its prior and
+	// planned attribute values.
+	Change *tfjson.Change
 }
 
-// Plan makes a blocking terraform plan call.
+// Plan makes a blocking terraform plan call. The plan is saved to a file
+// and read back in the JSON format so that the planned actions and the
+// attribute-level diff of the resource are available.
 func (w *Workspace) Plan(ctx context.Context) (PlanResult, error) {
 	// The last operation is still ongoing.
 	if w.LastOperation.IsRunning() {
 		return PlanResult{}, errors.Errorf("%s operation that started at %s is still running", w.LastOperation.Type, w.LastOperation.StartTime().String())
 	}
-	out, err := w.runTF(ctx, ModeSync, "plan", "-refresh=false", "-input=false", "-lock=false", "-json")
+	defer func() {
+		if err := w.fs.Remove(filepath.Join(w.dir, planFile)); err != nil && !os.IsNotExist(err) {
+			w.logger.Debug("cannot remove the plan file", "error", err)
+		}
+	}()
+	out, err := w.runTF(ctx, ModeSync, "plan", "-refresh=false", "-input=false", "-lock=false", "-json", "-out="+planFile)
 	w.logger.Debug("plan ended", "out", w.filterFn(string(out)))
 	if err != nil {
 		return PlanResult{}, tferrors.NewPlanFailed(out)
 	}
-	line := ""
-	for _, l := range strings.Split(string(out), "\n") {
-		if strings.Contains(l, `"type":"change_summary"`) {
-			line = l
-			break
-		}
+	out, err = w.runTF(ctx, ModeSync, "show", "-json", "-no-color", planFile)
+	if err != nil {
+		return PlanResult{}, errors.WithMessage(errors.New("cannot show the plan"), w.filterFn(string(out)))
 	}
-	if line == "" {
-		return PlanResult{}, errors.Errorf("cannot find the change summary line in plan log: %s", string(out))
+	p := &tfjson.Plan{}
+	if err := p.UnmarshalJSON(out); err != nil {
+		return PlanResult{}, errors.Wrap(err, "cannot unmarshal the plan json")
 	}
-	type plan struct {
-		Changes struct {
-			Add    float64 `json:"add,omitempty"`
-			Change float64 `json:"change,omitempty"`
-		} `json:"changes,omitempty"`
+	var rc *tfjson.ResourceChange
+	for _, c := range p.ResourceChanges {
+		if c.Mode == tfjson.ManagedResourceMode && c.Change != nil {
+			rc = c
+			break
+		}
 	}
-	p := &plan{}
-	if err := json.JSParser.Unmarshal([]byte(line), p); err != nil {
-		return PlanResult{}, errors.Wrap(err, "cannot unmarshal change summary json")
+	if rc == nil {
+		return PlanResult{}, errors.New("cannot find the planned change of the resource in the plan")
 	}
+	a := rc.Change.Actions
 	return PlanResult{
-		Exists:   p.Changes.Add == 0,
-		UpToDate: p.Changes.Change == 0,
+		Exists:   !a.Create(),
+		UpToDate: a.NoOp() || a.Read(),
+		Replace:  a.Replace(),
+		Change:   rc.Change,
 	}, nil
 }
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	k8sExec "k8s.io/utils/exec"
@@ -21,17 +22,19 @@ import (
 )
 
 var (
-	testType              = "very-cool-type"
-	applyType             = "apply"
-	lineage               = "very-cool-lineage"
-	terraformVersion      = "1.0.10"
-	version               = 1
-	serial                = 3
-	directory             = "random-dir/"
-	changeSummaryAdd      = `{"@level":"info","@message":"Plan: 1 to add, 0 to change, 0 to destroy.","@module":"terraform.ui","@timestamp":"0000-00-00T00:00:00.000000+03:00","changes":{"add":1,"change":0,"remove":0,"operation":"plan"},"type":"change_summary"}`
-	changeSummaryUpdate   = `{"@level":"info","@message":"Plan: 0 to add, 1 to change, 0 to destroy.","@module":"terraform.ui","@timestamp":"0000-00-00T00:00:00.000000+03:00","changes":{"add":0,"change":1,"remove":0,"operation":"plan"},"type":"change_summary"}`
-	changeSummaryNoAction = `{"@level":"info","@message":"Plan: 0 to add, 0 to change, 0 to destroy.","@module":"terraform.ui","@timestamp":"0000-00-00T00:00:00.000000+03:00","changes":{"add":0,"change":0,"remove":0,"operation":"plan"},"type":"change_summary"}`
-	filter                = `{"@level":"info","@message":"Terraform 1.2.1","@module":"terraform.ui","@timestamp":"2022-08-08T14:42:59.377073+03:00","terraform":"1.2.1","type":"version","ui":"1.0"}
+	testType             = "very-cool-type"
+	applyType            = "apply"
+	lineage              = "very-cool-lineage"
+	terraformVersion     = "1.0.10"
+	version              = 1
+	serial               = 3
+	directory            = "random-dir/"
+	planNoResourceChange = `{"format_version":"1.2","resource_changes":[]}`
+	planCreate           = `{"format_version":"1.2","resource_changes":[{"address":"test.r","mode":"managed","type":"test","name":"r","change":{"actions":["create"],"before":null,"after":{"name":"new"}}}]}`
+	planUpdate           = `{"format_version":"1.2","resource_changes":[{"address":"test.r","mode":"managed","type":"test","name":"r","change":{"actions":["update"],"before":{"name":"old"},"after":{"name":"new"}}}]}`
+	planReplace          = `{"format_version":"1.2","resource_changes":[{"address":"test.r","mode":"managed","type":"test","name":"r","change":{"actions":["delete","create"],"before":{"name":"old"},"after":{"name":"new"}}}]}`
+	planNoOp             = `{"format_version":"1.2","resource_changes":[{"address":"test.r","mode":"managed","type":"test","name":"r","change":{"actions":["no-op"],"before":{"name":"old"},"after":{"name":"old"}}}]}`
+	filter               = `{"@level":"info","@message":"Terraform 1.2.1","@module":"terraform.ui","@timestamp":"2022-08-08T14:42:59.377073+03:00","terraform":"1.2.1","type":"version","ui":"1.0"}
 {"@level":"error","@message":"Error: error configuring Terraform AWS Provider: error validating provider credentials: error calling sts:GetCallerIdentity: operation error STS: GetCallerIdentity, https response error StatusCode: 403, RequestID: *****, api error InvalidClientTokenId: The security token included in the request is invalid.","@module":"terraform.ui","@timestamp":"2022-08-08T14:43:00.808602+03:00","diagnostic":{"severity":"error","summary":"error configuring Terraform AWS Provider: error validating provider credentials: error calling sts:GetCallerIdentity: operation error STS: GetCallerIdentity, https response error StatusCode: 403, RequestID: *****, api error InvalidClientTokenId: The security token included in the request is invalid.","detail":"","address":"provider[\"registry.terraform.io/hashicorp/aws\"]","range":{"filename":"main.tf.json","start":{"line":1,"column":173,"byte":172},"end":{"line":1,"column":174,"byte":173}},"snippet":{"context":"provider.aws","code":"{\"provider\":{\"aws\":{\"access_key\":\"*****\",\"region\":\"us-east-1\",\"secret_key\":\"/*****\",\"skip_region_validation\":true,\"token\":\"\"}},\"resource\":{\"aws_iam_user\":{\"sample-user\":{\"lifecycle\":{\"prevent_destroy\":true},\"name\":\"sample-user\",\"tags\":{\"crossplane-kind\":\"user.iam.aws.upbound.io\",\"crossplane-name\":\"sample-user\",\"crossplane-providerconfig\":\"default\"}}}},\"terraform\":{\"required_providers\":{\"aws\":{\"source\":\"hashicorp/aws\",\"version\":\"4.15.1\"}}}}","start_line":1,"highlight_start_offset":172,"highlight_end_offset":173,"values":[]}},"type":"diagnostic"}`
 
 	state = &json.StateV4{
@@ -270,6 +273,31 @@ func TestWorkspaceRefresh(t *testing.T) {
 	}
 }
 
+func newFakePlanExec(show string) *testingexec.FakeExec {
+	return &testingexec.FakeExec{
+		CommandScript: []testingexec.FakeCommandAction{
+			func(_ string, _ ...string) k8sExec.Cmd {
+				return &testingexec.FakeCmd{
+					CombinedOutputScript: []testingexec.FakeAction{
+						func() ([]byte, []byte, error) {
+							return nil, nil, nil
+						},
+					},
+				}
+			},
+			func(_ string, _ ...string) k8sExec.Cmd {
+				return &testingexec.FakeCmd{
+					CombinedOutputScript: []testingexec.FakeAction{
+						func() ([]byte, []byte, error) {
+							return []byte(show), nil, nil
+						},
+					},
+				}
+			},
+		},
+	}
+}
+
 func TestWorkspacePlan(t *testing.T) {
 	type args struct {
 		w *Workspace
@@ -291,50 +319,81 @@ func TestWorkspacePlan(t *testing.T) {
 				err: errors.Errorf("%s operation that started at %s is still running", testType, now.String()),
 			},
 		},
-		"NoChangeSummary": {
+		"NoResourceChange": {
 			args: args{
-				w: NewWorkspace(directory, WithExecutor(&testingexec.FakeExec{DisableScripts: true}), WithFilterFn(filterFn)),
+				w: NewWorkspace(directory, WithExecutor(newFakePlanExec(planNoResourceChange)), WithAferoFs(fs), WithFilterFn(filterFn)),
 			},
 			want: want{
-				err: errors.Errorf("cannot find the change summary line in plan log: "),
+				err: errors.New("cannot find the planned change of the resource in the plan"),
 			},
 		},
-		"ChangeSummaryAdd": {
+		"PlanCreate": {
 			args: args{
-				w: NewWorkspace(directory, WithExecutor(newFakeExec(changeSummaryAdd, nil)), WithFilterFn(filterFn)),
+				w: NewWorkspace(directory, WithExecutor(newFakePlanExec(planCreate)), WithAferoFs(fs), WithFilterFn(filterFn)),
 			},
 			want: want{
 				r: PlanResult{
 					Exists:   false,
-					UpToDate: true,
+					UpToDate: false,
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionCreate},
+						After:   map[string]any{"name": "new"},
+					},
+				},
+			},
+		},
+		"PlanUpdate": {
+			args: args{
+				w: NewWorkspace(directory, WithExecutor(newFakePlanExec(planUpdate)), WithAferoFs(fs), WithFilterFn(filterFn)),
+			},
+			want: want{
+				r: PlanResult{
+					Exists:   true,
+					UpToDate: false,
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionUpdate},
+						Before:  map[string]any{"name": "old"},
+						After:   map[string]any{"name": "new"},
+					},
 				},
 			},
 		},
-		"ChangeSummaryUpdate": {
+		"PlanReplace": {
 			args: args{
-				w: NewWorkspace(directory, WithExecutor(newFakeExec(changeSummaryUpdate, nil)), WithFilterFn(filterFn)),
+				w: NewWorkspace(directory, WithExecutor(newFakePlanExec(planReplace)), WithAferoFs(fs), WithFilterFn(filterFn)),
 			},
 			want: want{
 				r: PlanResult{
 					Exists:   true,
 					UpToDate: false,
+					Replace:  true,
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+						Before:  map[string]any{"name": "old"},
+						After:   map[string]any{"name": "new"},
+					},
 				},
 			},
 		},
-		"ChangeSummaryNoAction": {
+		"PlanNoOp": {
 			args: args{
-				w: NewWorkspace(directory, WithExecutor(newFakeExec(changeSummaryNoAction, nil)), WithFilterFn(filterFn)),
+				w: NewWorkspace(directory, WithExecutor(newFakePlanExec(planNoOp)), WithAferoFs(fs), WithFilterFn(filterFn)),
 			},
 			want: want{
 				r: PlanResult{
 					Exists:   true,
 					UpToDate: true,
+					Change: &tfjson.Change{
+						Actions: tfjson.Actions{tfjson.ActionNoop},
+						Before:  map[string]any{"name": "old"},
+						After:   map[string]any{"name": "old"},
+					},
 				},
 			},
 		},
 		"Failure": {
 			args: args{
-				w: NewWorkspace(directory, WithExecutor(newFakeExec(errBoom.Error(), errBoom)), WithFilterFn(filterFn)),
+				w: NewWorkspace(directory, WithExecutor(newFakeExec(errBoom.Error(), errBoom)), WithAferoFs(fs), WithFilterFn(filterFn)),
 			},
 			want: want{
 				err: tferrors.NewPlanFailed([]byte(errBoom.Error())),
@@ -419,6 +478,69 @@ func TestWorkspaceApplyAsync(t *testing.T) {
 	}
 }
 
+// cancelableExec runs the Terraform commands until their contexts are
+// canceled.
+type cancelableExec struct {
+	*testingexec.FakeExec
+}
+
+func (e cancelableExec) CommandContext(ctx context.Context, _ string, _ ...string) k8sExec.Cmd {
+	return &testingexec.FakeCmd{
+		CombinedOutputScript: []testingexec.FakeAction{
+			func() ([]byte, []byte, error) {
+				<-ctx.Done()
+				return nil, nil, ctx.Err()
+			},
+		},
+	}
+}
+
+func TestWorkspaceApplyAsyncSupersede(t *testing.T) {
+	type want struct {
+		superseded bool
+		err        error
+	}
+	cases := map[string]struct {
+		reason string
+		opts   []ApplyAsyncOption
+		want   want
+	}{
+		"Cancelable": {
+			reason: "A cancelable apply should be canceled when it's superseded and should not be reported as failed.",
+			opts:   []ApplyAsyncOption{WithCancelableApply(1)},
+			want:   want{superseded: true},
+		},
+		"NotCancelable": {
+			reason: "An apply that is not cancelable should not be superseded.",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			w := NewWorkspace(directory, WithExecutor(cancelableExec{FakeExec: &testingexec.FakeExec{}}), WithFilterFn(filterFn))
+			errs := make(chan error, 1)
+			if err := w.ApplyAsync(func(err error, _ context.Context) error {
+				errs <- err
+				return nil
+			}, tc.opts...); err != nil {
+				t.Fatalf("ApplyAsync(...): unexpected error: %v", err)
+			}
+			superseded := w.LastOperation.Supersede()
+			if diff := cmp.Diff(tc.want.superseded, superseded); diff != "" {
+				t.Errorf("\n%s\nSupersede(): -want, +got:\n%s", tc.reason, diff)
+			}
+			if !superseded {
+				return
+			}
+			if diff := cmp.Diff(tc.want.err, <-errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nApplyAsync(...): -want callback error, +got callback error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(int64(1), w.LastOperation.Generation()); diff != "" {
+				t.Errorf("\n%s\nApplyAsync(...): -want generation, +got generation:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestWorkspaceDestroyAsync(t *testing.T) {
 	calls := make(chan bool)
 
@@ -0,0 +1,709 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transformers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/ast/astutil"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/types/markers"
+)
+
+const (
+	pathReference     = "github.com/crossplane/crossplane-runtime/v2/pkg/reference"
+	pathErrors        = "github.com/pkg/errors"
+	pathUpjetResource = "github.com/crossplane/upjet/v2/pkg/resource"
+	pathClient        = "sigs.k8s.io/controller-runtime/pkg/client"
+
+	typeNamespacedPolymorphicReference = "NamespacedPolymorphicReference"
+	typeNamespacedReference            = "NamespacedReference"
+)
+
+// polymorphicTarget is a candidate target of a polymorphic reference as
+// parsed from the reference target markers.
+type polymorphicTarget struct {
+	typePath  string
+	extractor string
+}
+
+// referenceField is a polymorphic or an external reference field of a
+// managed resource kind, which angryjet does not resolve.
+type referenceField struct {
+	// path is the Go expression selecting the field, e.g.,
+	// mg.Spec.ForProvider.GatewayID.
+	path string
+	// parent is the Go expression selecting the struct containing the field.
+	parent     string
+	name       string
+	valueType  string
+	refName    string
+	selName    string
+	namespaced bool
+	targets    []polymorphicTarget
+	// external is the target kind of an external reference, which is nil
+	// for a polymorphic reference.
+	external *config.ExternalReference
+	// template is the extractor template of the reference, if any.
+	template string
+}
+
+// injected returns true if the resolution statements of the reference are
+// injected by the resolver transformer instead of being generated by
+// angryjet.
+func (f *referenceField) injected() bool {
+	return len(f.targets) > 0 || f.external != nil
+}
+
+// referenceKind is a managed resource kind with polymorphic or external
+// references.
+type referenceKind struct {
+	name string
+	// stmts are the resolution statements of the kind in the order of the
+	// fields, including the enclosing nil checks and loops.
+	stmts []referenceStmt
+}
+
+// referenceStmt is either a reference field to resolve, or
+// the opening or closing of a nil check or loop enclosing such fields.
+type referenceStmt struct {
+	field *referenceField
+	open  string
+	close bool
+}
+
+// structIndex maps the struct type names of a package to their fields by
+// name.
+type structIndex map[string]map[string]*ast.Field
+
+func newStructIndex(files []*ast.File) structIndex {
+	idx := make(structIndex)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			ts, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			fields := make(map[string]*ast.Field)
+			for _, fl := range st.Fields.List {
+				for _, n := range fl.Names {
+					fields[n.Name] = fl
+				}
+			}
+			idx[ts.Name.Name] = fields
+			return false
+		})
+	}
+	return idx
+}
+
+// injectedReferenceKinds returns the managed resource kinds of the specified
+// package files which have polymorphic or external references in their
+// spec, sorted by name.
+func injectedReferenceKinds(files []*ast.File) ([]referenceKind, error) {
+	return referenceKinds(files, (*referenceField).injected)
+}
+
+// extractorTemplates returns the extractor templates of the references of
+// the specified package files resolved by angryjet, keyed by the kind and
+// the normalized field path as returned by templateKey.
+func extractorTemplates(files []*ast.File) (map[string]string, error) {
+	kinds, err := referenceKinds(files, func(f *referenceField) bool {
+		return f.template != "" && !f.injected()
+	})
+	if err != nil {
+		return nil, err
+	}
+	templates := make(map[string]string)
+	for _, k := range kinds {
+		for _, s := range k.stmts {
+			if s.field != nil {
+				templates[templateKey(k.name, s.field.path)] = s.field.template
+			}
+		}
+	}
+	return templates, nil
+}
+
+// indexPattern matches the index expressions of the field paths, which
+// differ between the generated resolvers.
+var indexPattern = regexp.MustCompile(`\[[^\]]*\]`)
+
+// templateKey returns the key of the extractor template of the field with
+// the specified path, e.g., mg.Spec.ForProvider.Rule[i3].RoleArn, of the
+// specified kind.
+func templateKey(kind, path string) string {
+	return kind + ":" + indexPattern.ReplaceAllString(path, "[]")
+}
+
+// referenceKinds returns the managed resource kinds of the specified
+// package files which have the reference fields matching the specified
+// function in their spec, sorted by name.
+func referenceKinds(files []*ast.File, match func(*referenceField) bool) ([]referenceKind, error) {
+	idx := newStructIndex(files)
+	names := make([]string, 0, len(idx))
+	for n := range idx {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var result []referenceKind
+	for _, n := range names {
+		spec, ok := idx[n]["Spec"]
+		if !ok {
+			continue
+		}
+		specType, ok := spec.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		k := referenceKind{name: n}
+		for _, p := range []string{"ForProvider", "InitProvider"} {
+			f, ok := idx[specType.Name][p]
+			if !ok {
+				continue
+			}
+			t, ok := f.Type.(*ast.Ident)
+			if !ok {
+				continue
+			}
+			stmts, err := idx.referenceStmts(t.Name, "mg.Spec."+p, 0, map[string]bool{}, match)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to find the references of the kind %s", n)
+			}
+			k.stmts = append(k.stmts, stmts...)
+		}
+		if len(k.stmts) > 0 {
+			result = append(result, k)
+		}
+	}
+	return result, nil
+}
+
+// referenceStmts recursively collects the reference fields matching the
+// specified function of the struct type with the specified name, selected by
+// the specified Go expression.
+func (idx structIndex) referenceStmts(typeName, expr string, depth int, visiting map[string]bool, match func(*referenceField) bool) ([]referenceStmt, error) { //nolint:gocyclo // easier to follow as a unit
+	fields, ok := idx[typeName]
+	if !ok || visiting[typeName] {
+		return nil, nil
+	}
+	visiting[typeName] = true
+	defer delete(visiting, typeName)
+
+	names := make([]string, 0, len(fields))
+	for n := range fields {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var result []referenceStmt
+	for _, n := range names {
+		fl := fields[n]
+		path := expr + "." + n
+		if pf, err := idx.parseReferenceField(fields, fl, expr, n); err != nil || pf != nil {
+			if err != nil {
+				return nil, err
+			}
+			if match(pf) {
+				result = append(result, referenceStmt{field: pf})
+			}
+			continue
+		}
+		var open, elem string
+		switch t := fl.Type.(type) {
+		case *ast.Ident:
+			elem = t.Name
+		case *ast.StarExpr:
+			if id, ok := t.X.(*ast.Ident); ok {
+				elem = id.Name
+				open = fmt.Sprintf("if %s != nil {", path)
+			}
+		case *ast.ArrayType:
+			v := "i" + strconv.Itoa(depth+1)
+			switch et := t.Elt.(type) {
+			case *ast.Ident:
+				elem = et.Name
+			case *ast.StarExpr:
+				if id, ok := et.X.(*ast.Ident); ok {
+					elem = id.Name
+				}
+			}
+			open = fmt.Sprintf("for %s := range %s {", v, path)
+			path = fmt.Sprintf("%s[%s]", path, v)
+		}
+		if _, ok := idx[elem]; !ok {
+			continue
+		}
+		nested, err := idx.referenceStmts(elem, path, depth+1, visiting, match)
+		if err != nil {
+			return nil, err
+		}
+		if len(nested) == 0 {
+			continue
+		}
+		if open != "" {
+			result = append(result, referenceStmt{open: open})
+		}
+		result = append(result, nested...)
+		if open != "" {
+			result = append(result, referenceStmt{close: true})
+		}
+	}
+	return result, nil
+}
+
+// parseReferenceField returns the reference field with the specified name if
+// the field has reference target, external reference or extractor template
+// markers.
+func (idx structIndex) parseReferenceField(fields map[string]*ast.Field, fl *ast.Field, parent, name string) (*referenceField, error) {
+	if fl.Doc == nil {
+		return nil, nil
+	}
+	pf := &referenceField{path: parent + "." + name, parent: parent, name: name}
+	for _, c := range fl.Doc.List {
+		if tp, ex, ok := markers.ParseReferenceTarget(c.Text); ok {
+			pf.targets = append(pf.targets, polymorphicTarget{typePath: tp, extractor: ex})
+			continue
+		}
+		if e, ok := markers.ParseExternalReference(c.Text); ok {
+			pf.external = &e
+			continue
+		}
+		if t, ok := markers.ParseExtractorTemplate(c.Text); ok {
+			pf.template = t
+			continue
+		}
+		rn, sn := markers.ParseReferenceFieldNames(c.Text)
+		if rn != "" {
+			pf.refName = rn
+		}
+		if sn != "" {
+			pf.selName = sn
+		}
+	}
+	if !pf.injected() {
+		if pf.template == "" {
+			return nil, nil
+		}
+		// angryjet resolves the reference and only its extractor is set by
+		// the resolver transformer.
+		return pf, nil
+	}
+	if pf.refName == "" {
+		pf.refName = name + "Ref"
+	}
+	if pf.selName == "" {
+		pf.selName = name + "Selector"
+	}
+	ref, ok := fields[pf.refName]
+	if !ok {
+		return nil, errors.Errorf("cannot find the reference field %s of the reference %s", pf.refName, pf.path)
+	}
+	if _, ok := fields[pf.selName]; !ok {
+		return nil, errors.Errorf("cannot find the selector field %s of the reference %s", pf.selName, pf.path)
+	}
+	pf.namespaced = strings.HasSuffix(typeString(ref.Type), "."+typeNamespacedPolymorphicReference)
+	if pf.external != nil {
+		// external references use the regular (namespaced) reference types
+		pf.namespaced = strings.HasSuffix(typeString(ref.Type), "."+typeNamespacedReference)
+	}
+	pf.valueType = typeString(fl.Type)
+	return pf, nil
+}
+
+func typeString(e ast.Expr) string {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + typeString(t.X)
+	case *ast.SelectorExpr:
+		return typeString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt)
+	}
+	return ""
+}
+
+// referenceGenerator generates the resolution statements of the
+// polymorphic and external references in a resolver file.
+type referenceGenerator struct {
+	r              *Resolver
+	file           *ast.File
+	filePath       string
+	apiGroupSuffix string
+	// names are the import names by the import paths used by the
+	// generated statements.
+	names map[string]string
+	// imports are the names of the import paths that are not imported yet
+	// by the resolver file.
+	imports map[string]string
+}
+
+// importName returns the name with which the specified import path is
+// referred to in the resolver file, registering it with the preferred name
+// if it's not imported yet.
+func (g *referenceGenerator) importName(path, preferred string) string {
+	if n, ok := g.names[path]; ok {
+		return n
+	}
+	used := make(map[string]bool)
+	for _, imp := range g.file.Imports {
+		p := strings.Trim(imp.Path.Value, `"`)
+		n := p[strings.LastIndex(p, "/")+1:]
+		if imp.Name != nil {
+			n = imp.Name.Name
+		}
+		if p == path {
+			g.names[path] = n
+			return n
+		}
+		used[n] = true
+	}
+	for _, n := range g.names {
+		used[n] = true
+	}
+	n := preferred
+	for i := 1; used[n]; i++ {
+		n = preferred + strconv.Itoa(i)
+	}
+	g.names[path] = n
+	g.imports[path] = n
+	return n
+}
+
+// extractExpr returns the Go expression of the specified extractor function
+// path, e.g., github.com/upbound/provider-aws/config/common.ARNExtractor().
+func (g *referenceGenerator) extractExpr(extractor string) string {
+	if extractor == "" {
+		return g.importName(pathReference, "reference") + ".ExternalName()"
+	}
+	slash := strings.LastIndex(extractor, "/")
+	if slash == -1 {
+		return extractor
+	}
+	dot := strings.Index(extractor[slash:], ".")
+	if dot == -1 {
+		return extractor
+	}
+	path := extractor[:slash+dot]
+	pkg := strings.NewReplacer("-", "", ".", "").Replace(path[slash+1:])
+	return g.importName(path, pkg) + extractor[slash+dot:]
+}
+
+// gvk returns the group, version and kind of the specified target type path,
+// e.g., github.com/upbound/provider-aws/apis/ec2/v1beta1.VPC. If the type
+// path is not qualified, the target resides in the same package as the
+// resolver file.
+func (g *referenceGenerator) gvk(typePath string) (group, version, kind string, err error) {
+	dot := strings.LastIndex(typePath, ".")
+	pkgPath := ""
+	if dot != -1 && strings.Contains(typePath[:dot], "/") {
+		pkgPath = typePath[:dot]
+	}
+	kind = typePath[dot+1:]
+	var tokens []string
+	if pkgPath != "" {
+		tokens = strings.Split(pkgPath, "/")
+	} else {
+		// apis/cur/v1beta1/zz_generated.resolvers.go
+		tokens = strings.Split(filepath.ToSlash(filepath.Dir(g.filePath)), "/")
+	}
+	if len(tokens) < 2 || kind == "" {
+		return "", "", "", errors.Errorf("failed to extract the GVK of the polymorphic reference target %q", typePath)
+	}
+	version = tokens[len(tokens)-1]
+	group = g.r.overrideGroupName(fmt.Sprintf("%s.%s", tokens[len(tokens)-2], g.apiGroupSuffix))
+	return group, version, kind, nil
+}
+
+// fieldStmts returns the resolution statements of the specified polymorphic
+// or external reference field.
+func (g *referenceGenerator) fieldStmts(f *referenceField) (string, error) {
+	if f.external != nil {
+		return g.externalStmts(f)
+	}
+	return g.polymorphicStmts(f)
+}
+
+// valueConverters returns the format strings of the conversions from and to
+// the string values of a reference field of the specified type.
+func (g *referenceGenerator) valueConverters(valueType string) (from, to string, ok bool) {
+	ref := g.importName(pathReference, "reference")
+	switch valueType {
+	case "*string":
+		return ref + ".FromPtrValue(%s)", ref + ".ToPtrValue(%s)", true
+	case "string", "[]string":
+		return "%s", "%s", true
+	case "*float64":
+		return ref + ".FromFloatPtrValue(%s)", ref + ".ToFloatPtrValue(%s)", true
+	case "*int64":
+		return ref + ".FromIntPtrValue(%s)", ref + ".ToIntPtrValue(%s)", true
+	case "[]*string":
+		return ref + ".FromPtrValues(%s)", ref + ".ToPtrValues(%s)", true
+	case "[]*float64":
+		return ref + ".FromFloatPtrValues(%s)", ref + ".ToFloatPtrValues(%s)", true
+	case "[]*int64":
+		return ref + ".FromIntPtrValues(%s)", ref + ".ToIntPtrValues(%s)", true
+	}
+	return "", "", false
+}
+
+// polymorphicStmts returns the resolution statements of the specified
+// polymorphic reference field.
+func (g *referenceGenerator) polymorphicStmts(f *referenceField) (string, error) {
+	from, to, ok := g.valueConverters(f.valueType)
+	if !ok || strings.HasPrefix(f.valueType, "[]") {
+		return "", errors.Errorf("unsupported type %q of the polymorphic reference %s", f.valueType, f.path)
+	}
+	res := g.importName(pathUpjetResource, "upjetresource")
+	resolve, req := "Resolve", "PolymorphicResolutionRequest"
+	if f.namespaced {
+		resolve, req = "ResolveNamespaced", "NamespacedPolymorphicResolutionRequest"
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "{\nprsp, err := %s.NewPolymorphicResolver(c, mg, %s.GetManagedResource).%s(ctx, %s.%s{\n",
+		res, g.importName(strings.Trim(g.r.apiResolverPackage, `"`), "apisresolver"), resolve, res, req)
+	fmt.Fprintf(sb, "CurrentValue: %s,\nReference: %s.%s,\nSelector: %s.%s,\nTargets: []%s.PolymorphicTarget{\n",
+		fmt.Sprintf(from, f.path), f.parent, f.refName, f.parent, f.selName, res)
+	for _, t := range f.targets {
+		group, version, kind, err := g.gvk(t.typePath)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to generate the resolution statements of the polymorphic reference %s", f.path)
+		}
+		fmt.Fprintf(sb, "{Group: %q, Version: %q, Kind: %q, ListKind: %q, Extract: %s},\n", group, version, kind, kind+"List", g.extractExpr(t.extractor))
+	}
+	fmt.Fprintf(sb, "},\n})\nif err != nil {\nreturn %s.Wrap(err, %q)\n}\n", g.importName(pathErrors, "errors"), f.path)
+	fmt.Fprintf(sb, "%s = %s\n%s.%s = prsp.ResolvedReference\n}\n", f.path, fmt.Sprintf(to, "prsp.ResolvedValue"), f.parent, f.refName)
+	return sb.String(), nil
+}
+
+// externalStmts returns the resolution statements of the specified external
+// reference field. The target kind is not known to the provider and thus,
+// the target is resolved as an unstructured managed resource with the
+// crossplane-runtime API resolver.
+func (g *referenceGenerator) externalStmts(f *referenceField) (string, error) {
+	from, to, ok := g.valueConverters(f.valueType)
+	if !ok {
+		return "", errors.Errorf("unsupported type %q of the external reference %s", f.valueType, f.path)
+	}
+	ref := g.importName(pathReference, "reference")
+	res := g.importName(pathUpjetResource, "upjetresource")
+	extract := ref + ".ExternalName()"
+	switch {
+	case f.template != "":
+		extract = fmt.Sprintf("%s.ExtractTemplate(%q)", res, f.template)
+	case f.external.FieldPath != "":
+		extract = fmt.Sprintf("%s.ExtractFieldPath(%q)", res, f.external.FieldPath)
+	}
+	resolver, req := "NewAPIResolver", "ResolutionRequest"
+	if f.namespaced {
+		resolver, req = "NewAPINamespacedResolver", "NamespacedResolutionRequest"
+	}
+	multi := strings.HasPrefix(f.valueType, "[]")
+	resolve, current, refs, value, resolved := "Resolve", "CurrentValue", "Reference", "ResolvedValue", "ResolvedReference"
+	if multi {
+		req = "Multi" + req
+		resolve, current, refs, value, resolved = "ResolveMultiple", "CurrentValues", "References", "ResolvedValues", "ResolvedReferences"
+	}
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "{\nm, l := %s.NewUnstructuredManaged(%q, %q, %q)\n", res, f.external.Group, f.external.Version, f.external.Kind)
+	fmt.Fprintf(sb, "rsp, err := %s.%s(c, mg).%s(ctx, %s.%s{\n", ref, resolver, resolve, ref, req)
+	fmt.Fprintf(sb, "%s: %s,\nExtract: %s,\n%s: %s.%s,\nSelector: %s.%s,\nTo: %s.To{List: l, Managed: m},\n})\n",
+		current, fmt.Sprintf(from, f.path), extract, refs, f.parent, f.refName, f.parent, f.selName, ref)
+	fmt.Fprintf(sb, "if err != nil {\nreturn %s.Wrap(err, %q)\n}\n", g.importName(pathErrors, "errors"), f.path)
+	fmt.Fprintf(sb, "%s = %s\n%s.%s = rsp.%s\n}\n", f.path, fmt.Sprintf(to, "rsp."+value), f.parent, f.refName, resolved)
+	return sb.String(), nil
+}
+
+// kindStmts returns the resolution statements of all the polymorphic and
+// external references of the specified kind.
+func (g *referenceGenerator) kindStmts(k referenceKind) (string, error) {
+	sb := &strings.Builder{}
+	for _, s := range k.stmts {
+		switch {
+		case s.field != nil:
+			stmts, err := g.fieldStmts(s.field)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(stmts)
+		case s.close:
+			sb.WriteString("}\n")
+		default:
+			sb.WriteString(s.open + "\n")
+		}
+	}
+	return sb.String(), nil
+}
+
+// injectResolvers injects the resolution statements of the polymorphic and
+// external references of the specified kinds into the `ResolveReferences`
+// functions of the specified resolver file source, adding the functions if
+// they do not exist. angryjet does not know about these references and thus,
+// they are resolved by the resolver transformer instead. Returns the
+// formatted source.
+func (r *Resolver) injectResolvers(src []byte, filePath, apiGroupSuffix string, kinds []referenceKind) ([]byte, error) {
+	fset := token.NewFileSet()
+	node, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the resolver file for injecting the reference resolvers")
+	}
+	g := &referenceGenerator{
+		r:              r,
+		file:           node,
+		filePath:       filePath,
+		apiGroupSuffix: apiGroupSuffix,
+		names:          make(map[string]string),
+		imports:        make(map[string]string),
+	}
+	type splice struct {
+		offset int
+		text   string
+	}
+	var splices []splice
+	for _, k := range kinds {
+		stmts, err := g.kindStmts(k)
+		if err != nil {
+			return nil, err
+		}
+		if fn := resolveReferencesFunc(node, k.name); fn != nil {
+			// insert right before the final `return nil`
+			offset := fset.Position(fn.Body.Rbrace).Offset
+			if n := len(fn.Body.List); n > 0 {
+				if ret, ok := fn.Body.List[n-1].(*ast.ReturnStmt); ok {
+					offset = fset.Position(ret.Pos()).Offset
+				}
+			}
+			splices = append(splices, splice{offset: offset, text: stmts + "\n"})
+			continue
+		}
+		splices = append(splices, splice{
+			offset: len(src),
+			text: fmt.Sprintf("\n// ResolveReferences of this %s.\nfunc (mg *%s) ResolveReferences(ctx %s.Context, c %s.Reader) error {\n%s\nreturn nil\n}\n",
+				k.name, k.name, g.importName("context", "context"), g.importName(pathClient, "client"), stmts),
+		})
+	}
+	sort.SliceStable(splices, func(i, j int) bool {
+		return splices[i].offset > splices[j].offset
+	})
+	out := string(src)
+	for _, s := range splices {
+		out = out[:s.offset] + s.text + out[s.offset:]
+	}
+
+	fset = token.NewFileSet()
+	node, err = parser.ParseFile(fset, filePath, out, parser.ParseComments)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the resolver file with the injected reference resolvers")
+	}
+	for path, name := range g.imports {
+		// angryjet names all the imports except for the standard library
+		// ones.
+		if name == path {
+			name = ""
+		}
+		astutil.AddNamedImport(fset, node, name, path)
+	}
+	sb := &strings.Builder{}
+	if err := format.Node(sb, fset, node); err != nil {
+		return nil, errors.Wrap(err, "failed to format the resolver file with the injected reference resolvers")
+	}
+	return []byte(sb.String()), nil
+}
+
+// receiverKind returns the kind of the receiver of the specified method
+// declaration, if any.
+func receiverKind(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	if se, ok := fn.Recv.List[0].Type.(*ast.StarExpr); ok {
+		if id, ok := se.X.(*ast.Ident); ok {
+			return id.Name
+		}
+	}
+	return ""
+}
+
+// setExtractorTemplate sets the extractor of the specified resolution
+// request, generated by angryjet for a reference of the specified kind, to
+// the template extractor of the resource package with the specified name if
+// the reference has an extractor template. Returns true if the extractor is
+// set.
+func setExtractorTemplate(req *ast.CompositeLit, kind string, templates map[string]string, resourcePkg string) bool {
+	se, ok := req.Type.(*ast.SelectorExpr)
+	if !ok || !strings.HasSuffix(se.Sel.Name, "ResolutionRequest") {
+		return false
+	}
+	var current string
+	var extract *ast.KeyValueExpr
+	for _, e := range req.Elts {
+		kv, ok := e.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch key.Name {
+		case "CurrentValue", "CurrentValues":
+			v := kv.Value
+			// e.g., reference.FromPtrValue(mg.Spec.ForProvider.RoleArn)
+			if call, ok := v.(*ast.CallExpr); ok && len(call.Args) == 1 {
+				v = call.Args[0]
+			}
+			current = types.ExprString(v)
+		case "Extract":
+			extract = kv
+		}
+	}
+	tmpl, ok := templates[templateKey(kind, current)]
+	if !ok || extract == nil {
+		return false
+	}
+	extract.Value = &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent(resourcePkg),
+			Sel: ast.NewIdent("ExtractTemplate"),
+		},
+		Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: strconv.Quote(tmpl)}},
+	}
+	return true
+}
+
+// resolveReferencesFunc returns the `ResolveReferences` function of the
+// specified kind in the specified file, if any.
+func resolveReferencesFunc(node *ast.File, kind string) *ast.FuncDecl {
+	for _, d := range node.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == "ResolveReferences" && receiverKind(fn) == kind {
+			return fn
+		}
+	}
+	return nil
+}
+
+// newResolverFileSource returns the source of a new resolver file for the
+// specified package with only the polymorphic or external references to
+// resolve.
+func newResolverFileSource(pkgName string) []byte {
+	return []byte(fmt.Sprintf("// Code generated by upjet. DO NOT EDIT.\n%s\n\npackage %s\n", commentFileTransformed, pkgName))
+}
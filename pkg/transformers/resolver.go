@@ -5,11 +5,13 @@
 package transformers
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/token"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
@@ -175,6 +177,14 @@ func WithAPIGroupOverrides(overrides map[string]string) ResolverOption {
 //	  mg.Spec.ForProvider.VPCIDRef = rsp.ResolvedReference
 //
 // ```
+// TransformPackages also injects the resolution statements of the polymorphic
+// and external references, which are not known to angryjet, into the
+// `ResolveReferences` functions. Polymorphic references are found via the
+// `+upjet:generate:reference:target` markers of the API types and are
+// resolved using a resource.PolymorphicResolver. External references, i.e.,
+// references to the managed resources of other providers, are found via the
+// `+upjet:generate:reference:external` markers and are resolved as
+// unstructured managed resources.
 func (r *Resolver) TransformPackages(resolverFilePattern string, patterns ...string) error {
 	pkgs, err := packages.Load(r.config, patterns...)
 	if err != nil {
@@ -188,14 +198,32 @@ func (r *Resolver) TransformPackages(resolverFilePattern string, patterns ...str
 			}
 			r.logger.Info("Encounter the following issues when loading a package", "name", p.Name, "pkgPath", p.PkgPath, "issues", err.Error())
 		}
+		kinds, err := injectedReferenceKinds(p.Syntax)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load the polymorphic and external references of the package %q", p.Name)
+		}
+		templates, err := extractorTemplates(p.Syntax)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load the extractor templates of the package %q", p.Name)
+		}
+		found := false
 		for i, f := range p.GoFiles {
 			if filepath.Base(f) != resolverFilePattern {
 				continue
 			}
-			if err := r.transformResolverFile(p.Fset, p.Syntax[i], f, strings.Trim(r.apiGroupSuffix, ".")); err != nil {
+			found = true
+			if err := r.transformResolverFile(p.Fset, p.Syntax[i], f, strings.Trim(r.apiGroupSuffix, "."), kinds, templates); err != nil {
 				return errors.Wrapf(err, "failed to transform the resolver file %s", f)
 			}
 		}
+		// angryjet does not generate a resolver file for a package without
+		// any regular references.
+		if !found && len(kinds) > 0 && len(p.GoFiles) > 0 {
+			f := filepath.Join(filepath.Dir(p.GoFiles[0]), resolverFilePattern)
+			if err := r.writeInjectedResolvers(newResolverFileSource(p.Name), f, strings.Trim(r.apiGroupSuffix, "."), kinds); err != nil {
+				return errors.Wrapf(err, "failed to generate the resolver file %s", f)
+			}
+		}
 	}
 	return nil
 }
@@ -251,7 +279,7 @@ func addTransformedComment(fset *token.FileSet, node *ast.File) bool {
 	return true
 }
 
-func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, filePath, apiGroupSuffix string) error { //nolint:gocyclo // Arguably, easier to follow
+func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, filePath, apiGroupSuffix string, kinds []referenceKind, templates map[string]string) error { //nolint:gocyclo // Arguably, easier to follow
 	if !addTransformedComment(fset, node) {
 		return nil
 	}
@@ -270,6 +298,16 @@ func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, fi
 	var block *ast.BlockStmt
 	// these are the GVKs for the MR kind and the associated list kind
 	var group, version, kind, listKind string
+	// mrKind is the kind of the `ResolveReferences` function's receiver.
+	var mrKind string
+	// resourcePkg is the name of the upjet resource package providing the
+	// template extractors.
+	resourcePkg := "upjetresource"
+	for _, imp := range node.Imports {
+		if strings.Trim(imp.Path.Value, `"`) == pathUpjetResource && imp.Name != nil {
+			resourcePkg = imp.Name.Name
+		}
+	}
 
 	// traverse the AST loaded from the given source file to remove the
 	// cross API-group import statements from it. This helps in avoiding
@@ -299,6 +337,7 @@ func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, fi
 		// correct positions.
 		case *ast.FuncDecl:
 			block = x.Body
+			mrKind = receiverKind(x)
 
 			// keep a hold of the `APIResolver.Resolve` and
 		// `APIResolver.ResolveMultiple` return value assignments as we will
@@ -306,6 +345,15 @@ func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, fi
 		case *ast.AssignStmt:
 			assign = x
 
+		// angryjet cannot generate the template extractors, so we set the
+		// extractor templates of the references in the resolution requests
+		// such as:
+		// `reference.ResolutionRequest{CurrentValue: ..., Extract: reference.ExternalName(), ...}`
+		case *ast.CompositeLit:
+			if setExtractorTemplate(x, mrKind, templates, resourcePkg) {
+				importMap[strconv.Quote(pathUpjetResource)] = resourcePkg
+			}
+
 		// we will attempt to transform expressions such as
 		// `reference.To{List: &v1beta1.MRList{}, Managed: &v1beta1.MR{}}`
 		// into:
@@ -460,12 +508,19 @@ func (r *Resolver) transformResolverFile(fset *token.FileSet, node *ast.File, fi
 	for path, name := range importMap {
 		astutil.AddNamedImport(fset, node, name, strings.Trim(path, `"`))
 	}
-	return r.dumpTransformed(fset, node, filePath)
+	return r.dumpTransformed(fset, node, filePath, apiGroupSuffix, kinds)
 }
 
-func (r *Resolver) dumpTransformed(fset *token.FileSet, node *ast.File, filePath string) error {
+func (r *Resolver) dumpTransformed(fset *token.FileSet, node *ast.File, filePath, apiGroupSuffix string, kinds []referenceKind) error {
 	// dump the transformed resolver file
 	adjustFunctionDocs(node)
+	if len(kinds) > 0 {
+		buff := &bytes.Buffer{}
+		if err := format.Node(buff, fset, node); err != nil {
+			return errors.Wrap(err, "failed to format the transformed AST")
+		}
+		return r.writeInjectedResolvers(buff.Bytes(), filePath, apiGroupSuffix, kinds)
+	}
 	outFile, err := r.fs.Create(filepath.Clean(filePath))
 	if err != nil {
 		return errors.Wrap(err, "failed to open the resolver file for writing the transformed AST")
@@ -476,6 +531,17 @@ func (r *Resolver) dumpTransformed(fset *token.FileSet, node *ast.File, filePath
 	return errors.Wrap(format.Node(outFile, fset, node), "failed to dump the transformed AST back into the resolver file")
 }
 
+// writeInjectedResolvers injects the resolution statements of the
+// polymorphic and external references of the specified kinds into the
+// specified resolver file source and writes it to the specified path.
+func (r *Resolver) writeInjectedResolvers(src []byte, filePath, apiGroupSuffix string, kinds []referenceKind) error {
+	out, err := r.injectResolvers(src, filePath, apiGroupSuffix, kinds)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(afero.WriteFile(r.fs, filepath.Clean(filePath), out, 0o600), "failed to write the resolver file with the injected reference resolvers")
+}
+
 func adjustFunctionDocs(node *ast.File) {
 	node.Decls[1].(*ast.FuncDecl).Doc.List[0].Slash = node.Decls[1].(*ast.FuncDecl).Name.Pos()
 }
@@ -25,6 +25,9 @@ func TestTransformPackages(t *testing.T) {
 		ignorePackageLoadErrors bool
 		patterns                []string
 		inputFilePath           string
+		// extraFiles are the other source files of the package, keyed by
+		// their names in the package.
+		extraFiles map[string]string
 	}
 
 	// want struct to define the expected outcome for each test case
@@ -107,16 +110,66 @@ func TestTransformPackages(t *testing.T) {
 				transformedPath: "testdata/apigatewayv2.resolvers.withoverrides.go.txt",
 			},
 		},
+		"SuccessfulTransformationWithPolymorphicReferences": {
+			reason: "Transformation of the source file injects the resolution statements of the polymorphic references, including the kinds without any regular references.",
+			args: args{
+				apiGroupSuffix:          "aws.upbound.io",
+				apiResolverPackage:      "github.com/upbound/provider-aws/internal/apis",
+				resolverFilePattern:     "zz_generated.resolvers.go",
+				inputFilePath:           "testdata/polymorphic.resolvers.go.txt",
+				extraFiles:              map[string]string{"zz_route_types.go": "testdata/polymorphic.types.go.txt"},
+				ignorePackageLoadErrors: true,
+				patterns:                []string{"./testdata"},
+			},
+			want: want{
+				transformedPath: "testdata/polymorphic.resolvers.transformed.go.txt",
+			},
+		},
+		"PolymorphicTransformationIdempotency": {
+			reason: "The transformation of the polymorphic references is idempotent.",
+			args: args{
+				apiGroupSuffix:          "aws.upbound.io",
+				apiResolverPackage:      "github.com/upbound/provider-aws/internal/apis",
+				resolverFilePattern:     "zz_generated.resolvers.go",
+				inputFilePath:           "testdata/polymorphic.resolvers.transformed.go.txt",
+				extraFiles:              map[string]string{"zz_route_types.go": "testdata/polymorphic.types.go.txt"},
+				ignorePackageLoadErrors: true,
+				patterns:                []string{"./testdata"},
+			},
+			want: want{
+				transformedPath: "testdata/polymorphic.resolvers.transformed.go.txt",
+			},
+		},
+		"SuccessfulTransformationWithExtractorTemplates": {
+			reason: "Transformation of the source file sets the template extractors of the references with extractor templates, including the multi-valued ones.",
+			args: args{
+				apiGroupSuffix:          "aws.upbound.io",
+				apiResolverPackage:      "github.com/upbound/provider-aws/internal/apis",
+				resolverFilePattern:     "zz_generated.resolvers.go",
+				inputFilePath:           "testdata/template.resolvers.go.txt",
+				extraFiles:              map[string]string{"zz_policy_types.go": "testdata/template.types.go.txt"},
+				ignorePackageLoadErrors: true,
+				patterns:                []string{"./testdata"},
+			},
+			want: want{
+				transformedPath: "testdata/template.resolvers.transformed.go.txt",
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			inputFileContents := readFile(t, afero.NewOsFs(), tc.args.inputFilePath, tc.reason)
+			files := map[string]interface{}{
+				filepath.Join("testdata", tc.args.resolverFilePattern): inputFileContents,
+			}
+			for n, p := range tc.args.extraFiles {
+				files[filepath.Join("testdata", n)] = readFile(t, afero.NewOsFs(), p, tc.reason)
+			}
 			exported := packagestest.Export(t, packagestest.Modules, []packagestest.Module{{
-				Name: "fake",
-				Files: map[string]interface{}{
-					filepath.Join("testdata", tc.args.resolverFilePattern): inputFileContents,
-				}}})
+				Name:  "fake",
+				Files: files,
+			}})
 			defer exported.Cleanup()
 			exported.Config.Mode = defaultLoadMode
 			memFS := afero.NewMemMapFs()
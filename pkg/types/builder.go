@@ -27,6 +27,10 @@ const (
 
 	// ref: https://kubernetes.io/docs/tasks/extend-kubernetes/custom-resources/custom-resource-definitions/#validation-rules
 	celEscapeSequence = "__%s__"
+	// celNoCreateOrUpdatePolicy is a CEL expression evaluating to true if the
+	// management policies of a managed resource allow neither creating nor
+	// updating the external resource.
+	celNoCreateOrUpdatePolicy = `!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies)`
 	// description for an injected list map key field in the context of the
 	// server-side apply object list merging
 	descriptionInjectedKey = "This is an injected field with a default value for being able to merge items of the parent object list."
@@ -52,6 +56,9 @@ type Generated struct {
 	AtProviderType   *types.Named
 
 	ValidationRules string
+
+	// Fields are the API reference documentation of the generated fields.
+	Fields []FieldDoc
 }
 
 type CRDScope string
@@ -63,6 +70,7 @@ type Builder struct {
 	genTypes        []*types.Named
 	comments        twtypes.Comments
 	validationRules string
+	fieldDocs       []FieldDoc
 
 	scope CRDScope
 }
@@ -90,6 +98,7 @@ func (g *Builder) Build(cfg *config.Resource) (Generated, error) {
 		InitProviderType: ip,
 		AtProviderType:   ap,
 		ValidationRules:  g.validationRules,
+		Fields:           g.fieldDocs,
 	}, errors.Wrapf(err, "cannot build the Types for resource %q", cfg.Name)
 }
 
@@ -148,7 +157,7 @@ func (g *Builder) buildResource(res *schema.Resource, cfg *config.Resource, tfPa
 		return nil, nil, nil, err
 	}
 
-	r := &resource{}
+	r := &resource{constrained: constrainedFields(res)}
 	for _, snakeFieldName := range keys {
 		var reference *config.Reference
 		cPath := traverser.FieldPath(append(tfPath, snakeFieldName))
@@ -184,6 +193,9 @@ func (g *Builder) buildResource(res *schema.Resource, cfg *config.Resource, tfPa
 	}
 
 	paramType, obsType, initType := g.AddToBuilder(typeNames, r)
+	if !cfg.DisableConstraintValidationRules {
+		g.addConstraintValidationRules(res, typeNames, r, tfPath)
+	}
 	return paramType, obsType, initType, nil
 }
 
@@ -208,10 +220,14 @@ func (g *Builder) AddToBuilder(typeNames *TypeNames, r *resource) (*types.Named,
 	for _, p := range r.topLevelRequiredParams {
 		g.validationRules += "\n"
 		sp := sanitizePath(p.path)
+		valueFrom := ""
+		if p.valueFrom != "" {
+			valueFrom = fmt.Sprintf(" || has(self.forProvider.%s)", sanitizePath(p.valueFrom))
+		}
 		if p.includeInit {
-			g.validationRules += fmt.Sprintf(`// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.%s) || (has(self.initProvider) && has(self.initProvider.%s))",message="spec.forProvider.%s is a required parameter"`, sp, sp, p.path)
+			g.validationRules += fmt.Sprintf(`// +kubebuilder:validation:XValidation:rule="%s || has(self.forProvider.%s)%s || (has(self.initProvider) && has(self.initProvider.%s))",message="spec.forProvider.%s is a required parameter"`, celNoCreateOrUpdatePolicy, sp, valueFrom, sp, p.path)
 		} else {
-			g.validationRules += fmt.Sprintf(`// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.%s)",message="spec.forProvider.%s is a required parameter"`, sp, p.path)
+			g.validationRules += fmt.Sprintf(`// +kubebuilder:validation:XValidation:rule="%s || has(self.forProvider.%s)%s",message="spec.forProvider.%s is a required parameter"`, celNoCreateOrUpdatePolicy, sp, valueFrom, p.path)
 		}
 	}
 
@@ -386,11 +402,20 @@ type resource struct {
 	paramFields, initFields, obsFields []*types.Var
 	paramTags, initTags, obsTags       []string
 	topLevelRequiredParams             []*topLevelRequiredParam
+	// constraintFields are the configurable fields of the struct keyed by
+	// their Terraform names.
+	constraintFields map[string]*constraintField
+	// constrained is the set of the fields of the struct that take part in
+	// a constraint of the Terraform schema.
+	constrained map[string]bool
 }
 
 type topLevelRequiredParam struct {
 	path        string
 	includeInit bool
+	// valueFrom is the name of the field specifying the source of the
+	// parameter's value, if any.
+	valueFrom string
 }
 
 func newTopLevelRequiredParam(path string, includeInit bool) *topLevelRequiredParam {
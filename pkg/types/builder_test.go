@@ -15,6 +15,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 )
@@ -768,6 +770,229 @@ func TestBuild(t *testing.T) {
 // +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"`,
 			},
 		},
+		"Constraint_Validation_Rules": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource: constraintsTestSchema(),
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; Block []example.BlockParameters "json:\"block,omitempty\" tf:\"block,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""; F *string "json:\"f,omitempty\" tf:\"f,omitempty\""; G *string "json:\"g,omitempty\" tf:\"g,omitempty\""; H *string "json:\"h,omitempty\" tf:\"h,omitempty\""}`,
+				atProvider:  `type example.Observation struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; Block []example.BlockObservation "json:\"block,omitempty\" tf:\"block,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""; F *string "json:\"f,omitempty\" tf:\"f,omitempty\""; G *string "json:\"g,omitempty\" tf:\"g,omitempty\""; H *string "json:\"h,omitempty\" tf:\"h,omitempty\""}`,
+				validationRules: `
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || !(has(self.forProvider.c) || (has(self.initProvider) && has(self.initProvider.c))) || (has(self.forProvider.d) || (has(self.initProvider) && has(self.initProvider.d)))",message="spec.forProvider.d must be set when spec.forProvider.c is set"
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || ((has(self.forProvider.e) || (has(self.initProvider) && has(self.initProvider.e))) ? 1 : 0) + ((has(self.forProvider.f) || (has(self.initProvider) && has(self.initProvider.f))) ? 1 : 0) == 1",message="exactly one of spec.forProvider.e, spec.forProvider.f must be set"
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || ((has(self.forProvider.g) || (has(self.initProvider) && has(self.initProvider.g))) || (has(self.forProvider.h) || (has(self.initProvider) && has(self.initProvider.h))))",message="at least one of spec.forProvider.g, spec.forProvider.h must be set"
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || !((has(self.forProvider.a) || (has(self.initProvider) && has(self.initProvider.a))) && (has(self.forProvider.b) || (has(self.initProvider) && has(self.initProvider.b))))",message="spec.forProvider.a conflicts with spec.forProvider.b"`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"NestedConflictsWith": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						want := `// +kubebuilder:validation:XValidation:rule="!(has(self.x) && has(self.y))",message="x conflicts with y"`
+						for _, k := range []string{"example.BlockParameters", "example.BlockInitParameters"} {
+							if diff := cmp.Diff(want, comments[k]); diff != "" {
+								t.Errorf("%s: -want comment, +got comment: %s", k, diff)
+							}
+						}
+					},
+				},
+			},
+		},
+		"Computed_Constraint_Fields_Omit_Validation_Rules": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource: computedConstraintsTestSchema(),
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""}`,
+				atProvider:  `type example.Observation struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""}`,
+				validationRules: `
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || !((has(self.forProvider.a) || (has(self.initProvider) && has(self.initProvider.a))) && (has(self.forProvider.e) || (has(self.initProvider) && has(self.initProvider.e))))",message="spec.forProvider.a conflicts with spec.forProvider.e"`,
+			},
+		},
+		"Disabled_Constraint_Validation_Rules": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource:                constraintsTestSchema(),
+					DisableConstraintValidationRules: true,
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; Block []example.BlockParameters "json:\"block,omitempty\" tf:\"block,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""; F *string "json:\"f,omitempty\" tf:\"f,omitempty\""; G *string "json:\"g,omitempty\" tf:\"g,omitempty\""; H *string "json:\"h,omitempty\" tf:\"h,omitempty\""}`,
+				atProvider:  `type example.Observation struct{A *string "json:\"a,omitempty\" tf:\"a,omitempty\""; B *string "json:\"b,omitempty\" tf:\"b,omitempty\""; Block []example.BlockObservation "json:\"block,omitempty\" tf:\"block,omitempty\""; C *string "json:\"c,omitempty\" tf:\"c,omitempty\""; D *string "json:\"d,omitempty\" tf:\"d,omitempty\""; E *string "json:\"e,omitempty\" tf:\"e,omitempty\""; F *string "json:\"f,omitempty\" tf:\"f,omitempty\""; G *string "json:\"g,omitempty\" tf:\"g,omitempty\""; H *string "json:\"h,omitempty\" tf:\"h,omitempty\""}`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"NoNestedRules": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						if v := comments["example.BlockParameters"]; v != "" {
+							t.Errorf("BlockParameters comment must be empty: %s", v)
+						}
+					},
+				},
+			},
+		},
+		"Inferred_Markers": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource: inferredMarkersTestSchema(),
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{Count *float64 "json:\"count,omitempty\" tf:\"count,omitempty\""; Enabled *bool "json:\"enabled,omitempty\" tf:\"enabled,omitempty\""; Fold *string "json:\"fold,omitempty\" tf:\"fold,omitempty\""; Other *string "json:\"other,omitempty\" tf:\"other,omitempty\""; Tier *string "json:\"tier,omitempty\" tf:\"tier,omitempty\""}`,
+				atProvider:  `type example.Observation struct{Count *float64 "json:\"count,omitempty\" tf:\"count,omitempty\""; Enabled *bool "json:\"enabled,omitempty\" tf:\"enabled,omitempty\""; Fold *string "json:\"fold,omitempty\" tf:\"fold,omitempty\""; Other *string "json:\"other,omitempty\" tf:\"other,omitempty\""; Tier *string "json:\"tier,omitempty\" tf:\"tier,omitempty\""}`,
+				validationRules: `
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || !((has(self.forProvider.enabled) || (has(self.initProvider) && has(self.initProvider.enabled))) && (has(self.forProvider.other) || (has(self.initProvider) && has(self.initProvider.other))))",message="spec.forProvider.enabled conflicts with spec.forProvider.other"`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"Markers": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						want := map[string]string{
+							"example.Parameters:Count":     "// +kubebuilder:validation:Optional\n// +kubebuilder:default:=1.5\n",
+							"example.InitParameters:Count": "// +kubebuilder:default:=1.5\n",
+							"example.Parameters:Tier":      "// +kubebuilder:validation:Optional\n// +kubebuilder:default:=\"basic\"\n// +kubebuilder:validation:Enum=\"basic\";\"premium\"\n",
+							"example.InitParameters:Tier":  "// +kubebuilder:default:=\"basic\"\n// +kubebuilder:validation:Enum=\"basic\";\"premium\"\n",
+							"example.Observation:Tier":     "",
+							"example.Parameters:Enabled":   "// +kubebuilder:validation:Optional\n",
+							"example.Parameters:Fold":      "// +kubebuilder:validation:Optional\n",
+						}
+						for k, v := range want {
+							if diff := cmp.Diff(v, comments[k]); diff != "" {
+								t.Errorf("%s: -want comment, +got comment: %s", k, diff)
+							}
+						}
+					},
+				},
+			},
+		},
+		"Inferred_Markers_Overrides": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource:                inferredMarkersTestSchema(),
+					DisableConstraintValidationRules: true,
+					SchemaElementOptions:             config.SchemaElementOptions{},
+				},
+				setupFunc: func(r *config.Resource) {
+					r.SchemaElementOptions.SetInferredMarkers("count", &config.InferredMarkers{DisableDefault: true})
+					r.SchemaElementOptions.SetInferredMarkers("tier", &config.InferredMarkers{DisableEnum: true})
+					r.SchemaElementOptions.SetInferredMarkers("fold", &config.InferredMarkers{Default: ptr.To(`"a"`), Enum: []string{`"a"`, `"b"`}})
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{Count *float64 "json:\"count,omitempty\" tf:\"count,omitempty\""; Enabled *bool "json:\"enabled,omitempty\" tf:\"enabled,omitempty\""; Fold *string "json:\"fold,omitempty\" tf:\"fold,omitempty\""; Other *string "json:\"other,omitempty\" tf:\"other,omitempty\""; Tier *string "json:\"tier,omitempty\" tf:\"tier,omitempty\""}`,
+				atProvider:  `type example.Observation struct{Count *float64 "json:\"count,omitempty\" tf:\"count,omitempty\""; Enabled *bool "json:\"enabled,omitempty\" tf:\"enabled,omitempty\""; Fold *string "json:\"fold,omitempty\" tf:\"fold,omitempty\""; Other *string "json:\"other,omitempty\" tf:\"other,omitempty\""; Tier *string "json:\"tier,omitempty\" tf:\"tier,omitempty\""}`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"Markers": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						want := map[string]string{
+							"example.Parameters:Count": "// +kubebuilder:validation:Optional\n",
+							"example.Parameters:Tier":  "// +kubebuilder:validation:Optional\n// +kubebuilder:default:=\"basic\"\n",
+							"example.Parameters:Fold":  "// +kubebuilder:validation:Optional\n// +kubebuilder:default:=\"a\"\n// +kubebuilder:validation:Enum=\"a\";\"b\"\n",
+						}
+						for k, v := range want {
+							if diff := cmp.Diff(v, comments[k]); diff != "" {
+								t.Errorf("%s: -want comment, +got comment: %s", k, diff)
+							}
+						}
+					},
+				},
+			},
+		},
+		"Immutable_ForceNew_Fields": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource:       immutableTestSchema(),
+					ImmutableForceNewFields: true,
+					SchemaElementOptions:    config.SchemaElementOptions{},
+				},
+				setupFunc: func(r *config.Resource) {
+					r.SchemaElementOptions.SetImmutable("size", true)
+					r.SchemaElementOptions.SetImmutable("zone", false)
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{Block []example.BlockParameters "json:\"block,omitempty\" tf:\"block,omitempty\""; Name *string "json:\"name,omitempty\" tf:\"name,omitempty\""; Size *float64 "json:\"size,omitempty\" tf:\"size,omitempty\""; Zone *string "json:\"zone,omitempty\" tf:\"zone,omitempty\""}`,
+				atProvider:  `type example.Observation struct{Block []example.BlockObservation "json:\"block,omitempty\" tf:\"block,omitempty\""; ID *string "json:\"id,omitempty\" tf:\"id,omitempty\""; Name *string "json:\"name,omitempty\" tf:\"name,omitempty\""; Size *float64 "json:\"size,omitempty\" tf:\"size,omitempty\""; Zone *string "json:\"zone,omitempty\" tf:\"zone,omitempty\""}`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"Rules": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						want := map[string]string{
+							"example.Parameters:Name":     "// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"self == oldSelf\",message=\"spec.forProvider.name is immutable\"\n",
+							"example.Parameters:Size":     "// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"self == oldSelf\",message=\"spec.forProvider.size is immutable\"\n",
+							"example.Parameters:Zone":     "// +kubebuilder:validation:Optional\n",
+							"example.Parameters":          "// +kubebuilder:validation:XValidation:rule=\"!has(oldSelf.name) || has(self.name)\",message=\"spec.forProvider.name is immutable\"\n// +kubebuilder:validation:XValidation:rule=\"!has(oldSelf.size) || has(self.size)\",message=\"spec.forProvider.size is immutable\"",
+							"example.InitParameters":      "",
+							"example.BlockParameters":     "",
+							"example.InitParameters:Name": "",
+							"example.BlockParameters:X":   "// +kubebuilder:validation:Optional\n",
+							"example.Observation:Name":    "",
+						}
+						for k, v := range want {
+							if diff := cmp.Diff(v, comments[k]); diff != "" {
+								t.Errorf("%s: -want comment, +got comment: %s", k, diff)
+							}
+						}
+					},
+				},
+			},
+		},
+		"Immutable_Field_In_List": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource:    immutableTestSchema(),
+					SchemaElementOptions: config.SchemaElementOptions{},
+				},
+				setupFunc: func(r *config.Resource) {
+					r.SchemaElementOptions.SetImmutable("block.x", true)
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errors.Wrapf(errors.Errorf(errFmtImmutableInList, "block.x"), "cannot infer type from resource schema of element type of %s", ".Block"), "cannot infer type from schema of field %s", "block"), "cannot build the Types for resource %q", ""),
+			},
+		},
+		"Value_From_Fields": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				cfg: &config.Resource{
+					TerraformResource: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"port": {
+								Type:     schema.TypeInt,
+								Optional: true,
+							},
+						},
+					},
+					SchemaElementOptions: config.SchemaElementOptions{},
+				},
+				setupFunc: func(r *config.Resource) {
+					r.SchemaElementOptions.SetValueFrom("name")
+					r.SchemaElementOptions.SetValueFrom("port")
+				},
+			},
+			want: want{
+				forProvider: `type example.Parameters struct{Name *string "json:\"name,omitempty\" tf:\"name,omitempty\""; NameFrom *github.com/crossplane/upjet/v2/apis/valuefrom/v1alpha1.ValueFromSource "json:\"nameFrom,omitempty\" tf:\"-\""; Port *int64 "json:\"port,omitempty\" tf:\"port,omitempty\""; PortFrom *github.com/crossplane/upjet/v2/apis/valuefrom/v1alpha1.ValueFromSource "json:\"portFrom,omitempty\" tf:\"-\""}`,
+				atProvider:  `type example.Observation struct{Name *string "json:\"name,omitempty\" tf:\"name,omitempty\""; Port *int64 "json:\"port,omitempty\" tf:\"port,omitempty\""}`,
+				validationRules: `
+// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || has(self.forProvider.nameFrom) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"`,
+				commentChecks: map[string]func(t *testing.T, comments map[string]string){
+					"Comments": func(t *testing.T, comments map[string]string) {
+						t.Helper()
+						want := "// Source of the value of name, which is resolved from a ConfigMap or a field of another object.\n// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"!has(self.secretKeyRef)\",message=\"spec.forProvider.name cannot be sourced from a Secret as it is reported in status.atProvider\"\n"
+						if diff := cmp.Diff(want, comments["example.Parameters:NameFrom"]); diff != "" {
+							t.Errorf("-want comment, +got comment: %s", diff)
+						}
+					},
+				},
+			},
+		},
 		"SSA_InjectedKey_Not_In_Observation_Comments": {
 			args: args{
 				crdScope: CRDScopeCluster,
@@ -874,6 +1099,131 @@ func TestBuild(t *testing.T) {
 	}
 }
 
+func inferredMarkersTestSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"count": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				Default:  1.5,
+			},
+			// the defaults of the constrained fields are not inferred
+			"enabled": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       true,
+				ConflictsWith: []string{"other"},
+			},
+			"other": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tier": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "basic",
+				ValidateFunc: validation.StringInSlice([]string{"basic", "premium"}, false),
+			},
+			// the values of the case-insensitive validations are not inferred
+			"fold": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"a", "b"}, true),
+			},
+		},
+	}
+}
+
+func immutableTestSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"size": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+				ForceNew: true,
+			},
+			// the fields nested in lists cannot be immutable
+			"block": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"x": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func constraintsTestSchema() *schema.Resource {
+	optional := func(s *schema.Schema) *schema.Schema {
+		s.Type = schema.TypeString
+		s.Optional = true
+		return s
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"a": optional(&schema.Schema{ConflictsWith: []string{"b"}}),
+			"b": optional(&schema.Schema{ConflictsWith: []string{"a"}}),
+			"c": optional(&schema.Schema{RequiredWith: []string{"d"}}),
+			"d": optional(&schema.Schema{}),
+			"e": optional(&schema.Schema{ExactlyOneOf: []string{"e", "f"}}),
+			"f": optional(&schema.Schema{ExactlyOneOf: []string{"e", "f"}}),
+			"g": optional(&schema.Schema{AtLeastOneOf: []string{"g", "h", "block.0.x"}}),
+			"h": optional(&schema.Schema{AtLeastOneOf: []string{"g", "h"}}),
+			"block": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"x": optional(&schema.Schema{ConflictsWith: []string{"block.0.y"}}),
+						"y": optional(&schema.Schema{}),
+					},
+				},
+			},
+		},
+	}
+}
+
+// computedConstraintsTestSchema returns a schema where the Optional &
+// Computed field "b" conflicts with "a" and takes part in an ExactlyOneOf
+// constraint with "c" & "d", while "a" also conflicts with "e".
+func computedConstraintsTestSchema() *schema.Resource {
+	optional := func(s *schema.Schema) *schema.Schema {
+		s.Type = schema.TypeString
+		s.Optional = true
+		return s
+	}
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"a": optional(&schema.Schema{ConflictsWith: []string{"b", "e"}}),
+			"b": optional(&schema.Schema{Computed: true, ConflictsWith: []string{"a"}, ExactlyOneOf: []string{"b", "c", "d"}}),
+			"c": optional(&schema.Schema{ExactlyOneOf: []string{"b", "c", "d"}}),
+			"d": optional(&schema.Schema{ExactlyOneOf: []string{"b", "c", "d"}}),
+			"e": optional(&schema.Schema{}),
+		},
+	}
+}
+
 func TestBuildFieldTypeOverride(t *testing.T) {
 	type want struct {
 		forProvider  string
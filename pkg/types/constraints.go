@@ -0,0 +1,332 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	twtypes "github.com/muvaf/typewriter/pkg/types"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+type constraintKind int
+
+const (
+	constraintConflictsWith constraintKind = iota
+	constraintRequiredWith
+	constraintExactlyOneOf
+	constraintAtLeastOneOf
+)
+
+// constraintField is a configurable field of a generated struct that can
+// take part in the ConflictsWith, ExactlyOneOf, AtLeastOneOf & RequiredWith
+// constraints of the Terraform schema.
+type constraintField struct {
+	// paramNames are the JSON names of the parameter fields whose presence
+	// means that the Terraform argument is set, i.e., the field itself and
+	// its reference & selector fields if it's a referencer.
+	paramNames []string
+	// initNames are the init parameter counterparts of paramNames. It's
+	// empty if the field is not an init field.
+	initNames []string
+}
+
+// constraint is a Terraform schema constraint between the sibling fields of
+// a generated struct.
+type constraint struct {
+	kind constraintKind
+	// field is the Terraform name of the field declaring a ConflictsWith or
+	// a RequiredWith constraint.
+	field string
+	// fields are the sorted Terraform names of the other fields taking part
+	// in the constraint. For ExactlyOneOf & AtLeastOneOf constraints, they
+	// are all the fields in the constraint.
+	fields []string
+}
+
+func (c constraint) key() string {
+	return fmt.Sprintf("%d/%s/%s", c.kind, c.field, strings.Join(c.fields, ","))
+}
+
+func (r *resource) addConstraintField(f *Field, o config.TagOverrides) {
+	n := f.JSONTag.Name()
+	// Optional & Computed fields are late-initialized from the observed
+	// state, which may set the both sides of a constraint and make the API
+	// server reject the late-initialization update. So we leave them out
+	// of the constraints.
+	if n == "" || n == "-" || f.Schema.Computed {
+		return
+	}
+	cf := &constraintField{paramNames: []string{n}}
+	if f.Reference != nil {
+		cf.paramNames = append(cf.paramNames, f.TransformedName, f.SelectorName)
+	}
+	if in := f.JSONTag.OverrideFrom(o.JSONTag).Name(); f.isInit() && in != "" && in != "-" {
+		cf.initNames = append([]string{in}, cf.paramNames[1:]...)
+	}
+	if r.constraintFields == nil {
+		r.constraintFields = map[string]*constraintField{}
+	}
+	r.constraintFields[f.Name.Snake] = cf
+}
+
+// siblingFields returns the Terraform names of the configurable fields of
+// the struct at the parent path that the specified Terraform schema keys
+// refer to. The returned bool is false if any of the keys refers to a field
+// outside the struct.
+func (r *resource) siblingFields(keys, parent []string) ([]string, bool) {
+	result := make([]string, 0, len(keys))
+	all := true
+	for _, k := range keys {
+		var segments []string
+		for _, s := range strings.Split(k, ".") {
+			// skip the list indices
+			if _, err := strconv.Atoi(s); err != nil {
+				segments = append(segments, s)
+			}
+		}
+		if len(segments) == 0 || !slices.Equal(segments[:len(segments)-1], parent) || r.constraintFields[segments[len(segments)-1]] == nil {
+			all = false
+			continue
+		}
+		if n := segments[len(segments)-1]; !slices.Contains(result, n) {
+			result = append(result, n)
+		}
+	}
+	slices.Sort(result)
+	return result, all
+}
+
+// constraints returns the constraints between the configurable fields of the
+// struct at tfPath.
+func (r *resource) constraints(res *schema.Resource, tfPath []string) []constraint { //nolint:gocyclo // easier to follow as a unit
+	parent := make([]string, 0, len(tfPath))
+	for _, p := range tfPath {
+		if p != wildcard {
+			parent = append(parent, p)
+		}
+	}
+	var result []constraint
+	seen := map[string]bool{}
+	add := func(c constraint) {
+		if !seen[c.key()] {
+			seen[c.key()] = true
+			result = append(result, c)
+		}
+	}
+	// ConflictsWith is symmetric, so we collect the conflicting pairs to
+	// generate a single rule for each pair.
+	conflicts := map[string][]string{}
+	for _, n := range sortedKeys(res.Schema) {
+		s := res.Schema[n]
+		if r.constraintFields[n] == nil {
+			continue
+		}
+		// partially resolved ConflictsWith & RequiredWith constraints are
+		// still valid for the resolved fields.
+		others, _ := r.siblingFields(s.ConflictsWith, parent)
+		for _, o := range others {
+			a, b := min(n, o), max(n, o)
+			if a != b && !slices.Contains(conflicts[a], b) {
+				conflicts[a] = append(conflicts[a], b)
+			}
+		}
+		others, _ = r.siblingFields(s.RequiredWith, parent)
+		if others = slices.DeleteFunc(others, func(o string) bool { return o == n }); len(others) > 0 {
+			add(constraint{kind: constraintRequiredWith, field: n, fields: others})
+		}
+		// on the other hand, ExactlyOneOf & AtLeastOneOf constraints are
+		// skipped if any of the fields cannot be resolved.
+		if all, ok := r.siblingFields(append(slices.Clone(s.ExactlyOneOf), n), parent); ok && len(s.ExactlyOneOf) > 0 && len(all) > 1 {
+			add(constraint{kind: constraintExactlyOneOf, fields: all})
+		}
+		if all, ok := r.siblingFields(append(slices.Clone(s.AtLeastOneOf), n), parent); ok && len(s.AtLeastOneOf) > 0 && len(all) > 1 {
+			add(constraint{kind: constraintAtLeastOneOf, fields: all})
+		}
+	}
+	for _, n := range sortedKeys(res.Schema) {
+		if others := conflicts[n]; len(others) > 0 {
+			slices.Sort(others)
+			add(constraint{kind: constraintConflictsWith, field: n, fields: others})
+		}
+	}
+	return result
+}
+
+// addConstraintValidationRules generates the CEL validation rules for the
+// ConflictsWith, ExactlyOneOf, AtLeastOneOf & RequiredWith constraints
+// between the configurable fields of the struct at tfPath. The rules for the
+// top level fields are added to the Kind so that they can be satisfied both
+// via spec.forProvider & spec.initProvider, and they are enforced only if
+// the management policies allow creating or updating the external resource.
+// Only the ConflictsWith & the "at most one" part of ExactlyOneOf constraints
+// are enforced for the nested fields, as the nested fields which are also
+// init fields can be omitted in spec.forProvider.
+func (g *Builder) addConstraintValidationRules(res *schema.Resource, typeNames *TypeNames, r *resource, tfPath []string) {
+	if len(r.constraintFields) == 0 {
+		return
+	}
+	for _, c := range r.constraints(res, tfPath) {
+		if len(tfPath) == 0 {
+			rule, message := r.kindConstraintRule(c)
+			g.validationRules += "\n" + validationRuleMarker(rule, message)
+			continue
+		}
+		if rule, message, ok := r.typeConstraintRule(c, func(f *constraintField) []string { return f.paramNames }); ok {
+			g.addTypeValidationRule(typeNames.ParameterTypeName, rule, message)
+		}
+		if rule, message, ok := r.typeConstraintRule(c, func(f *constraintField) []string { return f.initNames }); ok {
+			g.addTypeValidationRule(typeNames.InitTypeName, rule, message)
+		}
+	}
+}
+
+// kindConstraintRule returns the Kind-level validation rule & its message for
+// the specified constraint between top level fields.
+func (r *resource) kindConstraintRule(c constraint) (string, string) {
+	present := func(n string) string {
+		f := r.constraintFields[n]
+		terms := make([]string, 0, len(f.paramNames)+1)
+		for _, p := range f.paramNames {
+			terms = append(terms, fmt.Sprintf("has(self.forProvider.%s)", sanitizePath(p)))
+		}
+		if len(f.initNames) > 0 {
+			init := make([]string, 0, len(f.initNames))
+			for _, p := range f.initNames {
+				init = append(init, fmt.Sprintf("has(self.initProvider.%s)", sanitizePath(p)))
+			}
+			terms = append(terms, fmt.Sprintf("(has(self.initProvider) && %s)", anyOf(init)))
+		}
+		return anyOf(terms)
+	}
+	path := func(n string) string {
+		return "spec.forProvider." + r.constraintFields[n].paramNames[0]
+	}
+	rule, message := constraintRule(c, present, path, true)
+	return celNoCreateOrUpdatePolicy + " || " + rule, message
+}
+
+// typeConstraintRule returns the validation rule & its message for the
+// specified constraint between nested fields using the field names returned
+// by the names function. The returned bool is false if no rule is to be
+// generated, e.g., if the constrained fields are not init fields.
+func (r *resource) typeConstraintRule(c constraint, names func(f *constraintField) []string) (string, string, bool) {
+	if c.kind == constraintRequiredWith || c.kind == constraintAtLeastOneOf {
+		return "", "", false
+	}
+	fields := make([]string, 0, len(c.fields))
+	for _, n := range c.fields {
+		if len(names(r.constraintFields[n])) > 0 {
+			fields = append(fields, n)
+		}
+	}
+	if (c.kind == constraintConflictsWith && (len(names(r.constraintFields[c.field])) == 0 || len(fields) == 0)) ||
+		(c.kind == constraintExactlyOneOf && len(fields) < 2) {
+		return "", "", false
+	}
+	c.fields = fields
+	present := func(n string) string {
+		f := names(r.constraintFields[n])
+		terms := make([]string, 0, len(f))
+		for _, p := range f {
+			terms = append(terms, fmt.Sprintf("has(self.%s)", sanitizePath(p)))
+		}
+		return anyOf(terms)
+	}
+	path := func(n string) string {
+		return names(r.constraintFields[n])[0]
+	}
+	rule, message := constraintRule(c, present, path, false)
+	return rule, message, true
+}
+
+// constraintRule returns the validation rule & its message for the specified
+// constraint using the present function to build the CEL expressions that
+// check whether a field is set, and the path function to name the fields in
+// the message. If atLeast is false, only the "at most one" part of an
+// ExactlyOneOf constraint is enforced.
+func constraintRule(c constraint, present, path func(n string) string, atLeast bool) (string, string) {
+	terms := make([]string, len(c.fields))
+	paths := make([]string, len(c.fields))
+	for i, n := range c.fields {
+		terms[i] = present(n)
+		paths[i] = path(n)
+	}
+	switch c.kind {
+	case constraintConflictsWith:
+		return fmt.Sprintf("!(%s && %s)", present(c.field), anyOf(terms)),
+			fmt.Sprintf("%s conflicts with %s", path(c.field), strings.Join(paths, ", "))
+	case constraintRequiredWith:
+		return fmt.Sprintf("!%s || %s", present(c.field), allOf(terms)),
+			fmt.Sprintf("%s must be set when %s is set", strings.Join(paths, ", "), path(c.field))
+	case constraintExactlyOneOf:
+		counts := make([]string, len(terms))
+		for i, t := range terms {
+			counts[i] = fmt.Sprintf("(%s ? 1 : 0)", t)
+		}
+		if !atLeast {
+			return fmt.Sprintf("%s <= 1", strings.Join(counts, " + ")),
+				fmt.Sprintf("only one of %s can be set", strings.Join(paths, ", "))
+		}
+		return fmt.Sprintf("%s == 1", strings.Join(counts, " + ")),
+			fmt.Sprintf("exactly one of %s must be set", strings.Join(paths, ", "))
+	default:
+		return anyOf(terms), fmt.Sprintf("at least one of %s must be set", strings.Join(paths, ", "))
+	}
+}
+
+func anyOf(terms []string) string {
+	return joinTerms(terms, " || ")
+}
+
+func allOf(terms []string) string {
+	return joinTerms(terms, " && ")
+}
+
+func joinTerms(terms []string, op string) string {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, op) + ")"
+}
+
+func validationRuleMarker(rule, message string) string {
+	return fmt.Sprintf(`// +kubebuilder:validation:XValidation:rule="%s",message="%s"`, rule, message)
+}
+
+// addTypeValidationRule appends a validation rule to the comment of the
+// specified type.
+func (g *Builder) addTypeValidationRule(t *types.TypeName, rule, message string) {
+	c := g.comments[twtypes.QualifiedTypePath(t)]
+	if c != "" {
+		c += "\n"
+	}
+	g.comments.AddTypeComment(t, c+validationRuleMarker(rule, message))
+}
+
+// constrainedFields returns the set of the fields of the specified schema
+// that take part in a ConflictsWith, ExactlyOneOf, AtLeastOneOf or
+// RequiredWith constraint, either by declaring it or by being referred to
+// by a constraint of a sibling.
+func constrainedFields(res *schema.Resource) map[string]bool {
+	result := map[string]bool{}
+	for n, s := range res.Schema {
+		keys := slices.Concat(s.ConflictsWith, s.ExactlyOneOf, s.AtLeastOneOf, s.RequiredWith)
+		if len(keys) > 0 {
+			result[n] = true
+		}
+		for _, k := range keys {
+			segments := strings.Split(k, ".")
+			result[segments[len(segments)-1]] = true
+		}
+	}
+	return result
+}
@@ -87,7 +87,11 @@ func tfJSONAttributeToV2Schema(attr *tfjson.SchemaAttribute) *schemav2.Schema {
 		Description: attr.Description,
 		Computed:    attr.Computed,
 		Deprecated:  deprecatedMessage(attr.Deprecated),
-		Sensitive:   attr.Sensitive,
+		// the write-only arguments are never persisted in the state, so
+		// they are generated like the sensitive arguments, i.e., as secret
+		// references that are excluded from the status.
+		Sensitive: attr.Sensitive || attr.WriteOnly,
+		WriteOnly: attr.WriteOnly,
 	}
 	if err := schemaV2TypeFromCtyType(attr.AttributeType, v2sch); err != nil {
 		panic(err)
@@ -98,13 +102,15 @@ func tfJSONAttributeToV2Schema(attr *tfjson.SchemaAttribute) *schemav2.Schema {
 func tfJSONNestedAttributeTypeToV2Schema(nestedAttr *tfjson.SchemaAttribute) *schemav2.Schema {
 	na := nestedAttr.AttributeNestedType
 	v2sch := &schemav2.Schema{
-		MinItems:   int(na.MinItems), //nolint:gosec
-		MaxItems:   int(na.MaxItems), //nolint:gosec
-		Required:   nestedAttr.Required,
-		Optional:   nestedAttr.Optional,
-		Computed:   nestedAttr.Computed,
-		Sensitive:  nestedAttr.Sensitive,
-		Deprecated: deprecatedMessage(nestedAttr.Deprecated),
+		MinItems:    int(na.MinItems), //nolint:gosec
+		MaxItems:    int(na.MaxItems), //nolint:gosec
+		Required:    nestedAttr.Required,
+		Optional:    nestedAttr.Optional,
+		Computed:    nestedAttr.Computed,
+		Sensitive:   nestedAttr.Sensitive || nestedAttr.WriteOnly,
+		WriteOnly:   nestedAttr.WriteOnly,
+		Description: nestedAttr.Description,
+		Deprecated:  deprecatedMessage(nestedAttr.Deprecated),
 	}
 	switch na.NestingMode {
 	case tfjson.SchemaNestingModeSet:
@@ -161,7 +167,9 @@ func tfJSONBlockTypeToV2Schema(nb *tfjson.SchemaBlockType) *schemav2.Schema { //
 		v2sch.Type = schemav2.TypeList
 	case tfjson.SchemaNestingModeMap:
 		v2sch.Type = schemav2.TypeMap
-	case tfjson.SchemaNestingModeSingle:
+	// a group block is like a single block except that it's always
+	// present, even if none of its attributes are configured.
+	case tfjson.SchemaNestingModeSingle, tfjson.SchemaNestingModeGroup:
 		v2sch.Type = schemav2.TypeList
 		v2sch.MinItems = 0
 		// TODO(erhan): not sure whether we need this
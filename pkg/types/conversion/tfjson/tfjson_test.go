@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tfjson
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestGetV2ResourceMapWriteOnly(t *testing.T) {
+	type flags struct {
+		Sensitive bool
+		WriteOnly bool
+	}
+	rs := GetV2ResourceMap(map[string]*tfjson.Schema{
+		"test_resource": {
+			Block: &tfjson.SchemaBlock{
+				Attributes: map[string]*tfjson.SchemaAttribute{
+					"name":     {AttributeType: cty.String, Required: true},
+					"token":    {AttributeType: cty.String, Optional: true, Sensitive: true},
+					"password": {AttributeType: cty.String, Optional: true, WriteOnly: true},
+					"settings": {
+						Optional:  true,
+						WriteOnly: true,
+						AttributeNestedType: &tfjson.SchemaNestedAttributeType{
+							NestingMode: tfjson.SchemaNestingModeSingle,
+							Attributes: map[string]*tfjson.SchemaAttribute{
+								"key": {AttributeType: cty.String, Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	want := map[string]flags{
+		"name":     {},
+		"token":    {Sensitive: true},
+		"password": {Sensitive: true, WriteOnly: true},
+		"settings": {Sensitive: true, WriteOnly: true},
+	}
+	got := map[string]flags{}
+	for n, s := range rs["test_resource"].Schema {
+		got[n] = flags{Sensitive: s.Sensitive, WriteOnly: s.WriteOnly}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetV2ResourceMap(...): write-only attributes must be sensitive: -want, +got:\n%s", diff)
+	}
+}
@@ -56,6 +56,9 @@ type Field struct {
 	// Sensitive is set if this Field holds sensitive data and is thus
 	// generated as a secret reference.
 	Sensitive bool
+	// Immutable is set if this Field cannot be changed once it's set and
+	// thus the `self == oldSelf` validation rule is generated for it.
+	Immutable bool
 }
 
 // getDocString tries to extract the documentation string for the specified
@@ -174,6 +177,12 @@ func NewField(g *Builder, cfg *config.Resource, r *resource, sch *schema.Schema,
 		}
 	}
 
+	// the arguments with value sources are not late-initialized, as the
+	// resolved values would otherwise conflict with their sources.
+	if len(f.CanonicalPaths) == 1 && cfg.SchemaElementOptions.ValueFrom(traverser.FieldPath(f.TerraformPaths)) {
+		cfg.LateInitializer.AddIgnoredCanonicalFields(traverser.FieldPath(f.CanonicalPaths))
+	}
+
 	fieldType, initType, err := g.buildSchema(f, cfg, names, traverser.FieldPath(append(tfPath, snakeFieldName)), r)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot infer type from schema of field %s", f.Name.Snake)
@@ -181,6 +190,10 @@ func NewField(g *Builder, cfg *config.Resource, r *resource, sch *schema.Schema,
 	f.FieldType = fieldType
 	f.InitType = initType
 
+	inferKubebuilderOptions(f, cfg, r, traverser.FieldPath(append(tfPath, snakeFieldName)))
+	if f.Immutable, err = immutable(f, cfg, traverser.FieldPath(append(tfPath, snakeFieldName))); err != nil {
+		return nil, err
+	}
 	AddServerSideApplyMarkers(f)
 	return f, errors.Wrapf(AddServerSideApplyMarkersFromConfig(f, cfg), "cannot add the server-side apply merge strategy markers for the field")
 }
@@ -370,6 +383,9 @@ func (f *Field) AddToResource(g *Builder, r *resource, typeNames *TypeNames, opt
 	if f.Comment.UpjetOptions.FieldTFTag != nil {
 		f.TFTag = f.Comment.UpjetOptions.FieldTFTag
 	}
+	if !IsObservation(f.Schema) || opt.AddToObservation || !f.TFTag.AlwaysOmitted() || f.Injected {
+		g.fieldDocs = append(g.fieldDocs, newFieldDoc(f, IsObservation(f.Schema)))
+	}
 
 	// Note(turkenh): We want atProvider to be a superset of forProvider, so
 	// we always add the field as an observation field and then add it as a
@@ -396,12 +412,19 @@ func (f *Field) AddToResource(g *Builder, r *resource, typeNames *TypeNames, opt
 		}
 		r.addParameterField(f, field)
 		r.addInitField(f, field, g, typeNames.InitTypeName, initProviderOverrides.TagOverrides)
+		if opt.ValueFrom && len(f.CanonicalPaths) == 1 {
+			r.addValueFromField(g, typeNames.ParameterTypeName, f)
+		}
 	}
 
 	if f.Reference != nil {
 		r.addReferenceFields(g, typeNames.ParameterTypeName, f, false)
 	}
 
+	if !IsObservation(f.Schema) {
+		r.addConstraintField(f, initProviderOverrides.TagOverrides)
+	}
+
 	// Note(lsviben): All fields are optional because observation fields are
 	// optional by default, and forProvider and initProvider fields should
 	// be checked through CEL rules.
@@ -410,7 +433,13 @@ func (f *Field) AddToResource(g *Builder, r *resource, typeNames *TypeNames, opt
 	if f.isInit() {
 		f.Comment.KubebuilderOptions.Required = ptr.To(false)
 	}
-	g.comments.AddFieldComment(typeNames.ParameterTypeName, f.FieldNameCamel, f.Comment.Build())
+	paramComment := f.Comment.Build()
+	if f.Immutable {
+		paramComment += immutableRuleMarker(f) + "\n"
+		rule, message := immutableRemovalRule(f)
+		g.addTypeValidationRule(typeNames.ParameterTypeName, rule, message)
+	}
+	g.comments.AddFieldComment(typeNames.ParameterTypeName, f.FieldNameCamel, paramComment)
 
 	// initProvider and observation fields are always optional.
 	f.Comment.KubebuilderOptions.Required = nil
@@ -440,6 +469,7 @@ func (f *Field) AddToResource(g *Builder, r *resource, typeNames *TypeNames, opt
 			f.Comment.ServerSideApplyOptions.ListMapKey = nil
 		}
 		f.Comment.KubebuilderOptions.Default = nil
+		f.Comment.KubebuilderOptions.Enum = nil
 		g.comments.AddFieldComment(typeNames.ObservationTypeName, f.FieldNameCamel, f.Comment.Build())
 	}
 }
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+// FieldDoc is the API reference documentation of a generated field.
+type FieldDoc struct {
+	// Path is the path of the field relative to spec.forProvider for the
+	// parameters, or relative to status.atProvider for the observations,
+	// e.g., rule[*].name.
+	Path string
+	// Type is the JSON type of the field, e.g., string or []object.
+	Type string
+	// Description is the description of the field.
+	Description string
+	// Deprecated is the deprecation message of the field, if any.
+	Deprecated string
+	// Observation is set if the field is only available under
+	// status.atProvider.
+	Observation bool
+	// Required is set if the field is a required parameter.
+	Required bool
+	// Sensitive is set if the field is a reference to a secret key.
+	Sensitive bool
+	// Immutable is set if the field cannot be changed once it's set.
+	Immutable bool
+	// Reference is the reference configuration of the field, if its value
+	// can be resolved from another managed resource.
+	Reference *config.Reference
+}
+
+// newFieldDoc returns the API reference documentation of the specified field.
+func newFieldDoc(f *Field, observation bool) FieldDoc {
+	segments := f.CRDPaths
+	// the CRD paths of the list fields end with a wildcard.
+	if len(segments) > 1 && segments[len(segments)-1] == wildcard {
+		segments = segments[:len(segments)-1]
+	}
+	var sb strings.Builder
+	for i, s := range segments[:len(segments)-1] {
+		switch {
+		case s == wildcard:
+			sb.WriteString("[*]")
+		case i > 0:
+			sb.WriteString("." + s)
+		default:
+			sb.WriteString(s)
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteString(".")
+	}
+	// the field may have been renamed, e.g., into a secret reference, after
+	// its CRD path is computed.
+	sb.WriteString(f.JSONTag.Name())
+	return FieldDoc{
+		Path:        sb.String(),
+		Type:        docType(f.FieldType),
+		Description: f.Comment.Text,
+		Deprecated:  f.Schema.Deprecated,
+		Observation: observation,
+		Required:    !observation && ((!f.Schema.Optional && !f.Schema.Computed) || f.Required),
+		Sensitive:   f.Sensitive,
+		Immutable:   f.Immutable,
+		Reference:   f.Reference,
+	}
+}
+
+// docType returns the JSON type of the specified generated Go type for the
+// API reference documentation.
+func docType(t types.Type) string {
+	switch u := t.(type) {
+	case *types.Pointer:
+		return docType(u.Elem())
+	case *types.Slice:
+		return "[]" + docType(u.Elem())
+	case *types.Map:
+		return "map[string]" + docType(u.Elem())
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return "boolean"
+		case u.Info()&types.IsInteger != 0:
+			return "integer"
+		case u.Info()&types.IsFloat != 0:
+			return "number"
+		default:
+			return "string"
+		}
+	}
+	switch t {
+	case typeSecretKeySelector, typeLocalSecretKeySelector:
+		return "secret key reference"
+	case typeSecretReference, typeLocalSecretReference:
+		return "secret reference"
+	}
+	return "object"
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+const (
+	errFmtImmutableInList = "cannot make the field %q immutable as it's in a list whose items cannot be correlated with their previous versions"
+)
+
+// immutable returns true if the `self == oldSelf` validation rule should be
+// generated for the specified field at cpath. The ForceNew arguments are
+// immutable if cfg.ImmutableForceNewFields is set, unless overridden via the
+// schema element options. The transition rules are only allowed in the parts
+// of the CRD schema that can be correlated with their previous versions, so
+// the fields nested in lists are skipped.
+func immutable(f *Field, cfg *config.Resource, cpath string) (bool, error) {
+	correlatable := !slices.Contains(f.CRDPaths, wildcard)
+	if opt := cfg.SchemaElementOptions[cpath]; opt != nil && opt.Immutable != nil {
+		if *opt.Immutable && !correlatable {
+			return false, errors.Errorf(errFmtImmutableInList, cpath)
+		}
+		return *opt.Immutable, nil
+	}
+	return cfg.ImmutableForceNewFields && f.Schema.ForceNew && correlatable &&
+		!f.Schema.Sensitive && !IsObservation(f.Schema), nil
+}
+
+// immutableRuleMarker returns the `self == oldSelf` validation rule marker of
+// the specified field.
+func immutableRuleMarker(f *Field) string {
+	return validationRuleMarker("self == oldSelf", immutableMessage(f))
+}
+
+// immutableRemovalRule returns the validation rule & its message rejecting
+// the removal of the specified field from its parent struct. The transition
+// rule of the field itself is only evaluated if the field is set both in the
+// old and the new objects.
+func immutableRemovalRule(f *Field) (string, string) {
+	name := f.JSONTag.Name()
+	return fmt.Sprintf("!has(oldSelf.%s) || has(self.%s)", name, name), immutableMessage(f)
+}
+
+func immutableMessage(f *Field) string {
+	crdPath := slices.Clone(f.CRDPaths)
+	// the field may have been renamed, e.g., into a secret reference, after
+	// its CRD path is computed.
+	crdPath[len(crdPath)-1] = f.JSONTag.Name()
+	return fmt.Sprintf("spec.forProvider.%s is immutable", strings.Join(crdPath, "."))
+}
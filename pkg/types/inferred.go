@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+)
+
+const (
+	// enumProbe is an unlikely value used to probe the validation functions
+	// for their allowed values.
+	enumProbe = "upjet-enum-probe-value"
+)
+
+// reEnumError matches the error message of the validation.StringInSlice
+// validation function of the Terraform Plugin SDK and captures its allowed
+// values formatted with %q.
+var reEnumError = regexp.MustCompile(`^expected .* to be one of \[(.*)\], got ` + enumProbe + `$`)
+
+// inferKubebuilderOptions sets the +kubebuilder:default and the
+// +kubebuilder:validation:Enum markers of the specified field from its
+// Terraform schema where they can be derived safely, unless they are
+// overridden or suppressed via the InferredMarkers of the schema element at
+// cpath.
+func inferKubebuilderOptions(f *Field, cfg *config.Resource, r *resource, cpath string) {
+	o := config.InferredMarkers{}
+	if opt := cfg.SchemaElementOptions[cpath]; opt != nil && opt.InferredMarkers != nil {
+		o = *opt.InferredMarkers
+	}
+	switch {
+	case o.Default != nil:
+		f.Comment.KubebuilderOptions.Default = ptr.To(*o.Default)
+	case o.DisableDefault || f.Comment.KubebuilderOptions.Default != nil:
+	case inferableField(f, cfg, cpath) && !r.constrained[f.Name.Snake]:
+		// a field with a default value is always set, so it would violate
+		// the constraints it takes part in. Also, a field with a default
+		// value cannot be set via a reference.
+		if _, ok := cfg.References[cpath]; !ok {
+			f.Comment.KubebuilderOptions.Default = inferDefault(f.Schema, cfg, cpath)
+		}
+	}
+	switch {
+	case o.Enum != nil:
+		f.Comment.KubebuilderOptions.Enum = slices.Clone(o.Enum)
+	case o.DisableEnum || f.Comment.KubebuilderOptions.Enum != nil:
+	case inferableField(f, cfg, cpath):
+		if values := inferEnum(f.Schema, cfg, cpath); len(values) > 0 {
+			f.Comment.KubebuilderOptions.Enum = make([]string, len(values))
+			for i, v := range values {
+				f.Comment.KubebuilderOptions.Enum[i] = strconv.Quote(v)
+			}
+		}
+	}
+}
+
+// inferableField returns true if the markers of the specified field can be
+// inferred from its Terraform schema, i.e., if it's a configurable scalar
+// field whose type is not overridden.
+func inferableField(f *Field, cfg *config.Resource, cpath string) bool {
+	if f.Schema.Sensitive || f.Identifier || IsObservation(f.Schema) || cfg.FieldTypeOverride(cpath).ParameterTypeOverride != nil {
+		return false
+	}
+	switch f.Schema.Type { //nolint:exhaustive // only the scalar types are inferable
+	case schema.TypeBool, schema.TypeInt, schema.TypeFloat, schema.TypeString:
+		return true
+	default:
+		return false
+	}
+}
+
+// inferDefault returns the +kubebuilder:default marker value for the static
+// default value of the specified schema, or for the static default value of
+// the Terraform Plugin Framework attribute at cpath if the schema has none.
+// It returns nil if there is no default value or it does not match the
+// schema's type.
+func inferDefault(s *schema.Schema, cfg *config.Resource, cpath string) *string {
+	d := s.Default
+	if v, ok := cfg.FrameworkAttributeValues(cpath); ok && d == nil {
+		d = v.Default
+	}
+	switch v := d.(type) {
+	case bool:
+		if s.Type == schema.TypeBool {
+			return ptr.To(strconv.FormatBool(v))
+		}
+	case int:
+		if s.Type == schema.TypeInt {
+			return ptr.To(strconv.Itoa(v))
+		}
+	case float64:
+		if s.Type == schema.TypeFloat {
+			return ptr.To(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+	case string:
+		// skip the values requiring escaping so that they are safe to be
+		// used in the markers.
+		if q := strconv.Quote(v); s.Type == schema.TypeString && q == `"`+v+`"` {
+			return ptr.To(q)
+		}
+	}
+	return nil
+}
+
+// inferEnum returns the allowed values of the specified string schema if its
+// validation function is a case-sensitive validation.StringInSlice. As the
+// validation functions are opaque, we probe the function with an invalid
+// value, parse the allowed values from the returned error and then verify
+// them against the function. If the schema has no validation function, the
+// allowed values of the Terraform Plugin Framework attribute at cpath are
+// returned.
+func inferEnum(s *schema.Schema, cfg *config.Resource, cpath string) []string {
+	if s.Type != schema.TypeString {
+		return nil
+	}
+	var validate func(v string) []string
+	switch {
+	case s.ValidateFunc != nil:
+		validate = func(v string) []string {
+			_, errs := s.ValidateFunc(v, "")
+			result := make([]string, len(errs))
+			for i, err := range errs {
+				result[i] = err.Error()
+			}
+			return result
+		}
+	case s.ValidateDiagFunc != nil:
+		validate = func(v string) []string {
+			var result []string
+			for _, d := range s.ValidateDiagFunc(v, nil) {
+				if d.Severity == 0 { // diag.Error
+					result = append(result, d.Summary)
+				}
+			}
+			return result
+		}
+	default:
+		if v, ok := cfg.FrameworkAttributeValues(cpath); ok {
+			return slices.Clone(v.Enum)
+		}
+		return nil
+	}
+	return enumValues(validate)
+}
+
+func enumValues(validate func(v string) []string) (values []string) {
+	// validation functions are not expected to panic with string values,
+	// but we would rather not infer any values than fail.
+	defer func() {
+		if recover() != nil {
+			values = nil
+		}
+	}()
+	errs := validate(enumProbe)
+	if len(errs) != 1 {
+		return nil
+	}
+	m := reEnumError.FindStringSubmatch(errs[0])
+	if m == nil {
+		return nil
+	}
+	for rest := m[1]; rest != ""; {
+		q, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil
+		}
+		v, err := strconv.Unquote(q)
+		if err != nil {
+			return nil
+		}
+		values = append(values, v)
+		rest = strings.TrimPrefix(rest[len(q):], " ")
+	}
+	for _, v := range values {
+		if len(validate(v)) != 0 {
+			return nil
+		}
+		// the values are not enumerable if the function ignores the case.
+		for _, c := range []string{strings.ToUpper(v), strings.ToLower(v)} {
+			if c != v && !slices.Contains(values, c) && len(validate(c)) == 0 {
+				return nil
+			}
+		}
+	}
+	return values
+}
@@ -6,6 +6,7 @@ package markers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/crossplane/upjet/v2/pkg/config"
 )
@@ -19,6 +20,17 @@ var (
 	markerPrefixRefExtractor    = fmt.Sprintf("%sgenerate:reference:extractor=", markerPrefixCrossplane)
 	markerPrefixRefFieldName    = fmt.Sprintf("%sgenerate:reference:refFieldName=", markerPrefixCrossplane)
 	markerPrefixRefSelectorName = fmt.Sprintf("%sgenerate:reference:selectorFieldName=", markerPrefixCrossplane)
+	// the candidate targets of the polymorphic references are not processed
+	// by angryjet but by the upjet resolver transformer, hence the upjet
+	// prefix.
+	markerPrefixRefTarget = fmt.Sprintf("%sgenerate:reference:target=", markerPrefixUpjet)
+	// the references to the kinds of the other providers are also resolved
+	// by the upjet resolver transformer.
+	markerPrefixRefExternal = fmt.Sprintf("%sgenerate:reference:external=", markerPrefixUpjet)
+	// angryjet cannot generate calls to the extractor functions with
+	// arbitrary string arguments, so the resolver transformer sets the
+	// template extractors.
+	markerPrefixRefExtractorTemplate = fmt.Sprintf("%sgenerate:reference:extractorTemplate=", markerPrefixUpjet)
 )
 
 // CrossplaneOptions represents the Crossplane marker options that upjet
@@ -42,6 +54,94 @@ func (o CrossplaneOptions) String() string {
 	if o.SelectorFieldName != "" {
 		m += fmt.Sprintf("%s%s\n", markerPrefixRefSelectorName, o.SelectorFieldName)
 	}
+	for _, t := range o.Targets {
+		if t.Type == "" {
+			continue
+		}
+		m += fmt.Sprintf("%s%s", markerPrefixRefTarget, t.Type)
+		if t.Extractor != "" {
+			m += " " + t.Extractor
+		}
+		m += "\n"
+	}
+	if e := o.External; e != nil {
+		m += fmt.Sprintf("%s%s/%s.%s", markerPrefixRefExternal, e.Group, e.Version, e.Kind)
+		if e.FieldPath != "" {
+			m += " " + e.FieldPath
+		}
+		m += "\n"
+	}
+	if o.ExtractorTemplate != "" {
+		m += fmt.Sprintf("%s%s\n", markerPrefixRefExtractorTemplate, o.ExtractorTemplate)
+	}
 
 	return m
 }
+
+// ParseReferenceTarget parses the specified comment line as a polymorphic
+// reference target marker, i.e.,
+// +upjet:generate:reference:target=<type path> [<extractor>]
+// and returns the Go type path of the target and its extractor, if any.
+// Returns false if the line is not a reference target marker.
+func ParseReferenceTarget(line string) (typePath, extractor string, ok bool) {
+	v, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//")), markerPrefixRefTarget)
+	if !ok {
+		return "", "", false
+	}
+	typePath, extractor, _ = strings.Cut(v, " ")
+	return typePath, strings.TrimSpace(extractor), typePath != ""
+}
+
+// ParseExternalReference parses the specified comment line as an external
+// reference marker, i.e.,
+// +upjet:generate:reference:external=<group>/<version>.<kind> [<field path>]
+// and returns the referenced kind of the other provider. Returns false if the
+// line is not an external reference marker.
+func ParseExternalReference(line string) (config.ExternalReference, bool) {
+	v, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//")), markerPrefixRefExternal)
+	if !ok {
+		return config.ExternalReference{}, false
+	}
+	gvk, fieldPath, _ := strings.Cut(v, " ")
+	group, vk, ok := strings.Cut(gvk, "/")
+	if !ok {
+		return config.ExternalReference{}, false
+	}
+	version, kind, ok := strings.Cut(vk, ".")
+	if !ok || group == "" || version == "" || kind == "" {
+		return config.ExternalReference{}, false
+	}
+	return config.ExternalReference{
+		Group:     group,
+		Version:   version,
+		Kind:      kind,
+		FieldPath: strings.TrimSpace(fieldPath),
+	}, true
+}
+
+// ParseExtractorTemplate parses the specified comment line as an extractor
+// template marker, i.e.,
+// +upjet:generate:reference:extractorTemplate=<template>
+// and returns the template. Returns false if the line is not an extractor
+// template marker.
+func ParseExtractorTemplate(line string) (string, bool) {
+	v, ok := strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//")), markerPrefixRefExtractorTemplate)
+	if !ok || v == "" {
+		return "", false
+	}
+	return v, true
+}
+
+// ParseReferenceFieldNames parses the specified comment line as a
+// reference or selector field name marker and returns the configured field
+// names. The returned names are empty if the line is not such a marker.
+func ParseReferenceFieldNames(line string) (refFieldName, selectorFieldName string) {
+	l := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "//"))
+	if v, ok := strings.CutPrefix(l, markerPrefixRefFieldName); ok {
+		return v, ""
+	}
+	if v, ok := strings.CutPrefix(l, markerPrefixRefSelectorName); ok {
+		return "", v
+	}
+	return "", ""
+}
@@ -18,6 +18,9 @@ func TestCrossplaneOptions_String(t *testing.T) {
 		referenceExtractor         string
 		referenceFieldName         string
 		referenceSelectorFieldName string
+		referenceTargets           []config.ReferenceTarget
+		referenceExternal          *config.ExternalReference
+		referenceExtractorTemplate string
 	}
 	type want struct {
 		out string
@@ -54,6 +57,44 @@ func TestCrossplaneOptions_String(t *testing.T) {
 +crossplane:generate:reference:extractor=github.com/crossplane/provider-aws/apis/ec2/v1beta1.SubnetARN()
 +crossplane:generate:reference:refFieldName=SubnetIDRefs
 +crossplane:generate:reference:selectorFieldName=SubnetIDSelector
+`,
+			},
+		},
+		"WithTargets": {
+			args: args{
+				referenceTargets: []config.ReferenceTarget{
+					{Type: "github.com/crossplane/provider-aws/apis/ec2/v1beta1.Subnet"},
+					{Type: "github.com/crossplane/provider-aws/apis/ec2/v1beta1.VPC", Extractor: `github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)`},
+					{TerraformName: "aws_unresolved"},
+				},
+			},
+			want: want{
+				out: `+upjet:generate:reference:target=github.com/crossplane/provider-aws/apis/ec2/v1beta1.Subnet
++upjet:generate:reference:target=github.com/crossplane/provider-aws/apis/ec2/v1beta1.VPC github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)
+`,
+			},
+		},
+		"WithExternal": {
+			args: args{
+				referenceFieldName:         "LBRef",
+				referenceSelectorFieldName: "LBSelector",
+				referenceExternal:          &config.ExternalReference{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LB", FieldPath: "status.atProvider.dnsName"},
+			},
+			want: want{
+				out: `+crossplane:generate:reference:refFieldName=LBRef
++crossplane:generate:reference:selectorFieldName=LBSelector
++upjet:generate:reference:external=elbv2.aws.upbound.io/v1beta1.LB status.atProvider.dnsName
+`,
+			},
+		},
+		"WithExtractorTemplate": {
+			args: args{
+				referenceToType:            "github.com/crossplane/provider-aws/apis/iam/v1beta1.Role",
+				referenceExtractorTemplate: "arn:aws:iam::{{ .status.atProvider.accountId }}:role/{{ .external_name }}",
+			},
+			want: want{
+				out: `+crossplane:generate:reference:type=github.com/crossplane/provider-aws/apis/iam/v1beta1.Role
++upjet:generate:reference:extractorTemplate=arn:aws:iam::{{ .status.atProvider.accountId }}:role/{{ .external_name }}
 `,
 			},
 		},
@@ -66,6 +107,9 @@ func TestCrossplaneOptions_String(t *testing.T) {
 					Extractor:         tc.referenceExtractor,
 					RefFieldName:      tc.referenceFieldName,
 					SelectorFieldName: tc.referenceSelectorFieldName,
+					Targets:           tc.referenceTargets,
+					External:          tc.referenceExternal,
+					ExtractorTemplate: tc.referenceExtractorTemplate,
 				},
 			}
 			got := o.String()
@@ -75,3 +119,115 @@ func TestCrossplaneOptions_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReferenceTarget(t *testing.T) {
+	type want struct {
+		typePath  string
+		extractor string
+		ok        bool
+	}
+	cases := map[string]struct {
+		line string
+		want want
+	}{
+		"NotATarget": {
+			line: "// +crossplane:generate:reference:type=Subnet",
+		},
+		"WithoutExtractor": {
+			line: "// +upjet:generate:reference:target=github.com/crossplane/provider-aws/apis/ec2/v1beta1.Subnet",
+			want: want{
+				typePath: "github.com/crossplane/provider-aws/apis/ec2/v1beta1.Subnet",
+				ok:       true,
+			},
+		},
+		"WithExtractor": {
+			line: `+upjet:generate:reference:target=github.com/crossplane/provider-aws/apis/ec2/v1beta1.VPC github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)`,
+			want: want{
+				typePath:  "github.com/crossplane/provider-aws/apis/ec2/v1beta1.VPC",
+				extractor: `github.com/crossplane/upjet/v2/pkg/resource.ExtractParamPath("arn",true)`,
+				ok:        true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			typePath, extractor, ok := ParseReferenceTarget(tc.line)
+			if diff := cmp.Diff(tc.want, want{typePath: typePath, extractor: extractor, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ParseReferenceTarget(%q): -want, +got:\n%s", tc.line, diff)
+			}
+		})
+	}
+}
+
+func TestParseExternalReference(t *testing.T) {
+	type want struct {
+		ref config.ExternalReference
+		ok  bool
+	}
+	cases := map[string]struct {
+		line string
+		want want
+	}{
+		"NotAnExternalReference": {
+			line: "// +upjet:generate:reference:target=github.com/crossplane/provider-aws/apis/ec2/v1beta1.Subnet",
+		},
+		"MissingKind": {
+			line: "// +upjet:generate:reference:external=elbv2.aws.upbound.io/v1beta1",
+		},
+		"WithoutFieldPath": {
+			line: "// +upjet:generate:reference:external=elbv2.aws.upbound.io/v1beta1.LB",
+			want: want{
+				ref: config.ExternalReference{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LB"},
+				ok:  true,
+			},
+		},
+		"WithFieldPath": {
+			line: "+upjet:generate:reference:external=elbv2.aws.upbound.io/v1beta1.LB status.atProvider.dnsName",
+			want: want{
+				ref: config.ExternalReference{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LB", FieldPath: "status.atProvider.dnsName"},
+				ok:  true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ref, ok := ParseExternalReference(tc.line)
+			if diff := cmp.Diff(tc.want, want{ref: ref, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ParseExternalReference(%q): -want, +got:\n%s", tc.line, diff)
+			}
+		})
+	}
+}
+
+func TestParseExtractorTemplate(t *testing.T) {
+	type want struct {
+		tmpl string
+		ok   bool
+	}
+	cases := map[string]struct {
+		line string
+		want want
+	}{
+		"NotAnExtractorTemplate": {
+			line: "// +crossplane:generate:reference:extractor=github.com/crossplane/upjet/v2/pkg/resource.ExtractResourceID()",
+		},
+		"EmptyTemplate": {
+			line: "// +upjet:generate:reference:extractorTemplate=",
+		},
+		"Template": {
+			line: "// +upjet:generate:reference:extractorTemplate={{ .spec.forProvider.region }}:{{ .external_name }}",
+			want: want{
+				tmpl: "{{ .spec.forProvider.region }}:{{ .external_name }}",
+				ok:   true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tmpl, ok := ParseExtractorTemplate(tc.line)
+			if diff := cmp.Diff(tc.want, want{tmpl: tmpl, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ParseExtractorTemplate(%q): -want, +got:\n%s", tc.line, diff)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ package kubebuilder
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"k8s.io/utils/ptr"
 )
@@ -31,6 +33,11 @@ type Options struct {
 	// marker. Please note that you will need to include the quotes when setting
 	// Default as needed, e.g., `"10"`.
 	Default *string
+	// Enum generates the
+	// +kubebuilder:validation:Enum=<val1>;<val2>...
+	// marker. Similar to Default, you will need to include the quotes for
+	// the string values as needed, e.g., `"1"`.
+	Enum []string
 }
 
 func (o *Options) setFrom(opt *Options) {
@@ -46,6 +53,9 @@ func (o *Options) setFrom(opt *Options) {
 	if opt.Default != nil {
 		o.Default = ptr.To(*opt.Default)
 	}
+	if opt.Enum != nil {
+		o.Enum = slices.Clone(opt.Enum)
+	}
 }
 
 // OverrideFrom returns a new Options of o with its attributes overridden from
@@ -83,6 +93,9 @@ func (o *Options) String() string {
 	if o.Default != nil {
 		m += fmt.Sprintf("+kubebuilder:default:=%s\n", *o.Default)
 	}
+	if len(o.Enum) > 0 {
+		m += fmt.Sprintf("+kubebuilder:validation:Enum=%s\n", strings.Join(o.Enum, ";"))
+	}
 
 	return m
 }
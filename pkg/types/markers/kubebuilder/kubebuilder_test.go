@@ -22,6 +22,7 @@ func TestOptionsString(t *testing.T) {
 		minimum    *int
 		maximum    *int
 		defaultVal *string
+		enum       []string
 	}
 	type want struct {
 		out string
@@ -65,6 +66,19 @@ func TestOptionsString(t *testing.T) {
 			want: want{
 				out: `+kubebuilder:validation:Optional
 +kubebuilder:default:="10"
+`,
+			},
+		},
+		"OptionalWithDefaultAndEnum": {
+			args: args{
+				required:   &optional,
+				defaultVal: &default10,
+				enum:       []string{`"1"`, `"10"`},
+			},
+			want: want{
+				out: `+kubebuilder:validation:Optional
++kubebuilder:default:="10"
++kubebuilder:validation:Enum="1";"10"
 `,
 			},
 		},
@@ -76,6 +90,7 @@ func TestOptionsString(t *testing.T) {
 				Minimum:  tc.minimum,
 				Maximum:  tc.maximum,
 				Default:  tc.defaultVal,
+				Enum:     tc.enum,
 			}
 			got := o.String()
 			if diff := cmp.Diff(tc.want.out, got); diff != "" {
@@ -163,6 +178,24 @@ func TestOptionsOverrideFrom(t *testing.T) {
 				},
 			},
 		},
+		"OverrideEnumOnly": {
+			reason: "OverrideFrom should override only the Enum field when only that is set in override.",
+			args: args{
+				o: &Options{
+					Default: &default1,
+					Enum:    []string{"default1", "default2"},
+				},
+				opt: &Options{
+					Enum: []string{"default2"},
+				},
+			},
+			want: want{
+				result: Options{
+					Default: &default1,
+					Enum:    []string{"default2"},
+				},
+			},
+		},
 		"OverrideRequiredOnly": {
 			reason: "OverrideFrom should override only the Required field when only that is set in override.",
 			args: args{
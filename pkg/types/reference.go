@@ -26,6 +26,9 @@ const (
 	// PackagePathXPV2CommonAPIs is the go path for the Crossplane core APIs
 	// package with common v2 APIs (consolidated into the same package in v2.3.0).
 	PackagePathXPV2CommonAPIs = "github.com/crossplane/crossplane/apis/v2/core/v2"
+	// PackagePathPolymorphicReferenceAPIs is the go path for the polymorphic
+	// reference APIs package.
+	PackagePathPolymorphicReferenceAPIs = "github.com/crossplane/upjet/v2/apis/reference/v1alpha1"
 )
 
 // Types to use from by reference generator.
@@ -71,6 +74,26 @@ var (
 		types.NewStruct(nil, nil),
 		nil,
 	)
+	typePolymorphicReferenceField types.Type = types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage(PackagePathPolymorphicReferenceAPIs, "v1alpha1"), "PolymorphicReference", nil),
+		types.NewStruct(nil, nil),
+		nil,
+	)
+	typePolymorphicSelectorField types.Type = types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage(PackagePathPolymorphicReferenceAPIs, "v1alpha1"), "PolymorphicSelector", nil),
+		types.NewStruct(nil, nil),
+		nil,
+	)
+	typeNamespacedPolymorphicReferenceField types.Type = types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage(PackagePathPolymorphicReferenceAPIs, "v1alpha1"), "NamespacedPolymorphicReference", nil),
+		types.NewStruct(nil, nil),
+		nil,
+	)
+	typeNamespacedPolymorphicSelectorField types.Type = types.NewNamed(
+		types.NewTypeName(token.NoPos, types.NewPackage(PackagePathPolymorphicReferenceAPIs, "v1alpha1"), "NamespacedPolymorphicSelector", nil),
+		types.NewStruct(nil, nil),
+		nil,
+	)
 	commentOptional = &comments.Comment{
 		Options: markers.Options{
 			KubebuilderOptions: kubebuilder.Options{
@@ -81,6 +104,9 @@ var (
 )
 
 func (g *Builder) generateReferenceFields(t *types.TypeName, f *Field) (fields []*types.Var, tags []string) {
+	if f.Reference.IsPolymorphic() {
+		return g.generatePolymorphicReferenceFields(t, f)
+	}
 	_, isSlice := f.FieldType.(*types.Slice)
 
 	rfn := name.ReferenceFieldName(f.Name, isSlice, f.Reference.RefFieldName)
@@ -89,6 +115,11 @@ func (g *Builder) generateReferenceFields(t *types.TypeName, f *Field) (fields [
 	refTag := fmt.Sprintf(`json:"%s,omitempty" tf:"-"`, rfn.LowerCamelComputed)
 	selTag := fmt.Sprintf(`json:"%s,omitempty" tf:"-"`, sfn.LowerCamelComputed)
 
+	description := friendlyTypeDescription(f.Reference.Type)
+	if e := f.Reference.External; e != nil {
+		description = fmt.Sprintf("%s in %s", e.Kind, e.Group)
+	}
+
 	var tr types.Type
 	if g.scope == CRDScopeCluster {
 		tr = types.NewPointer(typeReferenceField)
@@ -96,18 +127,18 @@ func (g *Builder) generateReferenceFields(t *types.TypeName, f *Field) (fields [
 		tr = types.NewPointer(typeNamespacedReferenceField)
 	}
 	refComment := fmt.Sprintf("// Reference to a %s to populate %s.\n%s",
-		friendlyTypeDescription(f.Reference.Type), f.Name.LowerCamelComputed, commentOptional.Build())
+		description, f.Name.LowerCamelComputed, commentOptional.Build())
 	selComment := fmt.Sprintf("// Selector for a %s to populate %s.\n%s",
-		friendlyTypeDescription(f.Reference.Type), f.Name.LowerCamelComputed, commentOptional.Build())
+		description, f.Name.LowerCamelComputed, commentOptional.Build())
 	if isSlice {
 		tr = types.NewSlice(typeNamespacedReferenceField)
 		if g.scope == CRDScopeCluster {
 			tr = types.NewSlice(typeReferenceField)
 		}
 		refComment = fmt.Sprintf("// References to %s to populate %s.\n%s",
-			friendlyTypeDescription(f.Reference.Type), f.Name.LowerCamelComputed, commentOptional.Build())
+			description, f.Name.LowerCamelComputed, commentOptional.Build())
 		selComment = fmt.Sprintf("// Selector for a list of %s to populate %s.\n%s",
-			friendlyTypeDescription(f.Reference.Type), f.Name.LowerCamelComputed, commentOptional.Build())
+			description, f.Name.LowerCamelComputed, commentOptional.Build())
 	}
 	ref := types.NewField(token.NoPos, g.Package, rfn.Camel, tr, false)
 	tsel := types.NewPointer(typeNamespacedSelectorField)
@@ -120,10 +151,84 @@ func (g *Builder) generateReferenceFields(t *types.TypeName, f *Field) (fields [
 	g.comments.AddFieldComment(t, sfn.Camel, selComment)
 	f.TransformedName = rfn.LowerCamelComputed
 	f.SelectorName = sfn.LowerCamelComputed
+	// the resolver transformer finds the Ref and Selector fields of the
+	// external reference via these markers.
+	if f.Reference.External != nil && f.Comment != nil {
+		f.Comment.Reference.RefFieldName = rfn.Camel
+		f.Comment.Reference.SelectorFieldName = sfn.Camel
+	}
 
 	return []*types.Var{ref, sel}, []string{refTag, selTag}
 }
 
+// generatePolymorphicReferenceFields generates the Ref and Selector fields of
+// a polymorphic reference, which carry the kind of the chosen target.
+func (g *Builder) generatePolymorphicReferenceFields(t *types.TypeName, f *Field) (fields []*types.Var, tags []string) {
+	rfn := name.ReferenceFieldName(f.Name, false, f.Reference.RefFieldName)
+	sfn := name.SelectorFieldName(f.Name, f.Reference.SelectorFieldName)
+
+	refTag := fmt.Sprintf(`json:"%s,omitempty" tf:"-"`, rfn.LowerCamelComputed)
+	selTag := fmt.Sprintf(`json:"%s,omitempty" tf:"-"`, sfn.LowerCamelComputed)
+
+	tr, tsel := typeNamespacedPolymorphicReferenceField, typeNamespacedPolymorphicSelectorField
+	if g.scope == CRDScopeCluster {
+		tr, tsel = typePolymorphicReferenceField, typePolymorphicSelectorField
+	}
+	descriptions := make([]string, 0, len(f.Reference.Targets))
+	kinds := make([]string, 0, len(f.Reference.Targets))
+	for _, tg := range f.Reference.Targets {
+		if tg.Type == "" {
+			continue
+		}
+		descriptions = append(descriptions, friendlyTypeDescription(tg.Type))
+		kinds = append(kinds, tg.Type[strings.LastIndex(tg.Type, ".")+1:])
+	}
+	kindRule := polymorphicKindRule(kinds)
+	refComment := fmt.Sprintf("// Reference to a %s to populate %s.\n%s%s",
+		joinAlternatives(descriptions), f.Name.LowerCamelComputed, commentOptional.Build(), kindRule)
+	selComment := fmt.Sprintf("// Selector for a %s to populate %s.\n%s%s",
+		joinAlternatives(descriptions), f.Name.LowerCamelComputed, commentOptional.Build(), kindRule)
+
+	ref := types.NewField(token.NoPos, g.Package, rfn.Camel, types.NewPointer(tr), false)
+	sel := types.NewField(token.NoPos, g.Package, sfn.Camel, types.NewPointer(tsel), false)
+
+	g.comments.AddFieldComment(t, rfn.Camel, refComment)
+	g.comments.AddFieldComment(t, sfn.Camel, selComment)
+	f.TransformedName = rfn.LowerCamelComputed
+	f.SelectorName = sfn.LowerCamelComputed
+	// the resolver transformer finds the Ref and Selector fields of the
+	// polymorphic reference via these markers.
+	if f.Comment != nil {
+		f.Comment.Reference.RefFieldName = rfn.Camel
+		f.Comment.Reference.SelectorFieldName = sfn.Camel
+	}
+
+	return []*types.Var{ref, sel}, []string{refTag, selTag}
+}
+
+// polymorphicKindRule returns the CEL validation rule marker restricting the
+// kind of a polymorphic reference or selector to the specified kinds.
+func polymorphicKindRule(kinds []string) string {
+	if len(kinds) == 0 {
+		return ""
+	}
+	quoted := make([]string, len(kinds))
+	for i, k := range kinds {
+		quoted[i] = fmt.Sprintf("'%s'", k)
+	}
+	return fmt.Sprintf("// +kubebuilder:validation:XValidation:rule=\"!has(self.kind) || self.kind in [%s]\",message=\"kind must be one of %s\"\n",
+		strings.Join(quoted, ", "), strings.Join(kinds, ", "))
+}
+
+// joinAlternatives joins the specified descriptions as alternatives, e.g.,
+// "A, B or C".
+func joinAlternatives(descriptions []string) string {
+	if len(descriptions) < 2 {
+		return strings.Join(descriptions, "")
+	}
+	return strings.Join(descriptions[:len(descriptions)-1], ", ") + " or " + descriptions[len(descriptions)-1]
+}
+
 // TypePath returns go package path for the input type. This is a helper
 // function to be used whenever this information is needed, like configuring to
 // reference to a type. Should not be used if the type is in the same package as
@@ -165,6 +165,61 @@ func TestBuilder_generateReferenceFields(t *testing.T) {
 				},
 			},
 		},
+		"PolymorphicReference": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				t:        types.NewTypeName(token.NoPos, tp, "Params", types.Universe.Lookup("string").Type()),
+				f: &Field{
+					Name: name.NewFromCamel("TestField"),
+					Reference: &config.Reference{
+						Targets: []config.ReferenceTarget{
+							{TerraformName: "aws_vpc", Type: "github.com/upbound/provider-aws/apis/ec2/v1beta1.VPC"},
+							{TerraformName: "aws_subnet", Type: "github.com/upbound/provider-aws/apis/ec2/v1beta1.Subnet"},
+						},
+					},
+					FieldType: types.Universe.Lookup("string").Type(),
+				},
+			}, want: want{
+				outFields: []*types.Var{
+					types.NewField(token.NoPos, tp, "TestFieldRef", types.NewPointer(typePolymorphicReferenceField), false),
+					types.NewField(token.NoPos, tp, "TestFieldSelector", types.NewPointer(typePolymorphicSelectorField), false),
+				},
+				outTags: []string{
+					`json:"testFieldRef,omitempty" tf:"-"`,
+					`json:"testFieldSelector,omitempty" tf:"-"`,
+				},
+				outComments: twtypes.Comments{
+					"github.com/crossplane/upjet/v2/pkg/types.Params:TestFieldRef":      "// Reference to a VPC in ec2 or Subnet in ec2 to populate testField.\n// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"!has(self.kind) || self.kind in ['VPC', 'Subnet']\",message=\"kind must be one of VPC, Subnet\"\n",
+					"github.com/crossplane/upjet/v2/pkg/types.Params:TestFieldSelector": "// Selector for a VPC in ec2 or Subnet in ec2 to populate testField.\n// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"!has(self.kind) || self.kind in ['VPC', 'Subnet']\",message=\"kind must be one of VPC, Subnet\"\n",
+				},
+			},
+		},
+		"ExternalReferenceSlice": {
+			args: args{
+				crdScope: CRDScopeCluster,
+				t:        types.NewTypeName(token.NoPos, tp, "Params", types.Universe.Lookup("string").Type()),
+				f: &Field{
+					Name: name.NewFromCamel("TestField"),
+					Reference: &config.Reference{
+						External: &config.ExternalReference{Group: "elbv2.aws.upbound.io", Version: "v1beta1", Kind: "LB"},
+					},
+					FieldType: types.NewSlice(types.Universe.Lookup("string").Type()),
+				},
+			}, want: want{
+				outFields: []*types.Var{
+					types.NewField(token.NoPos, tp, "TestFieldRefs", types.NewSlice(typeReferenceField), false),
+					types.NewField(token.NoPos, tp, "TestFieldSelector", types.NewPointer(typeSelectorField), false),
+				},
+				outTags: []string{
+					`json:"testFieldRefs,omitempty" tf:"-"`,
+					`json:"testFieldSelector,omitempty" tf:"-"`,
+				},
+				outComments: twtypes.Comments{
+					"github.com/crossplane/upjet/v2/pkg/types.Params:TestFieldRefs":     "// References to LB in elbv2.aws.upbound.io to populate testField.\n// +kubebuilder:validation:Optional\n",
+					"github.com/crossplane/upjet/v2/pkg/types.Params:TestFieldSelector": "// Selector for a list of LB in elbv2.aws.upbound.io to populate testField.\n// +kubebuilder:validation:Optional\n",
+				},
+			},
+		},
 		// namespaced CRD tests
 		"OnlyRefType_namespaced": {
 			args: args{
@@ -298,6 +353,37 @@ func TestBuilder_generateReferenceFields(t *testing.T) {
 				},
 			},
 		},
+		"PolymorphicReference_namespaced": {
+			args: args{
+				crdScope: CRDScopeNamespaced,
+				t:        types.NewTypeName(token.NoPos, tp, "Params", types.Universe.Lookup("string").Type()),
+				f: &Field{
+					Name: name.NewFromCamel("TestField"),
+					Reference: &config.Reference{
+						RefFieldName: "CustomRef",
+						Targets: []config.ReferenceTarget{
+							{TerraformName: "aws_vpc", Type: "VPC"},
+							{TerraformName: "aws_subnet", Type: "Subnet"},
+							{TerraformName: "aws_eip", Type: "EIP"},
+						},
+					},
+					FieldType: types.Universe.Lookup("string").Type(),
+				},
+			}, want: want{
+				outFields: []*types.Var{
+					types.NewField(token.NoPos, tp, "CustomRef", types.NewPointer(typeNamespacedPolymorphicReferenceField), false),
+					types.NewField(token.NoPos, tp, "TestFieldSelector", types.NewPointer(typeNamespacedPolymorphicSelectorField), false),
+				},
+				outTags: []string{
+					`json:"customRef,omitempty" tf:"-"`,
+					`json:"testFieldSelector,omitempty" tf:"-"`,
+				},
+				outComments: twtypes.Comments{
+					"github.com/crossplane/upjet/v2/pkg/types.Params:CustomRef":         "// Reference to a VPC, Subnet or EIP to populate testField.\n// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"!has(self.kind) || self.kind in ['VPC', 'Subnet', 'EIP']\",message=\"kind must be one of VPC, Subnet, EIP\"\n",
+					"github.com/crossplane/upjet/v2/pkg/types.Params:TestFieldSelector": "// Selector for a VPC, Subnet or EIP to populate testField.\n// +kubebuilder:validation:Optional\n// +kubebuilder:validation:XValidation:rule=\"!has(self.kind) || self.kind in ['VPC', 'Subnet', 'EIP']\",message=\"kind must be one of VPC, Subnet, EIP\"\n",
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
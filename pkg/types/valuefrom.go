@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2025 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+
+	"github.com/crossplane/upjet/v2/pkg/config"
+	"github.com/crossplane/upjet/v2/pkg/types/name"
+)
+
+// PackagePathValueFromAPIs is the go path for the value source APIs package.
+const PackagePathValueFromAPIs = "github.com/crossplane/upjet/v2/apis/valuefrom/v1alpha1"
+
+var typeValueFromSourceField types.Type = types.NewNamed(
+	types.NewTypeName(token.NoPos, types.NewPackage(PackagePathValueFromAPIs, "v1alpha1"), "ValueFromSource", nil),
+	types.NewStruct(nil, nil),
+	nil,
+)
+
+// addValueFromField adds the `<field>From` parameter field, which specifies
+// the source of the value of the specified top-level argument. The field is
+// not mirrored in initProvider as the values are resolved only before the
+// Terraform configuration is built for the forProvider arguments. As the
+// resolved value is reported in status.atProvider, selecting a Secret key is
+// rejected via a validation rule.
+func (r *resource) addValueFromField(g *Builder, paramName *types.TypeName, f *Field) {
+	n := name.NewFromCamel(f.Name.Camel + "From")
+	jsonName := config.ValueFromFieldName(f.Name.Snake)
+	r.paramFields = append(r.paramFields, types.NewField(token.NoPos, g.Package, n.Camel, types.NewPointer(typeValueFromSourceField), false))
+	r.paramTags = append(r.paramTags, fmt.Sprintf(`json:"%s,omitempty" tf:"-"`, jsonName))
+	g.comments.AddFieldComment(paramName, n.Camel, fmt.Sprintf("// Source of the value of %s, which is resolved from a ConfigMap or a field of another object.\n%s%s\n",
+		f.Name.LowerCamelComputed, commentOptional.Build(), validationRuleMarker("!has(self.secretKeyRef)", fmt.Sprintf("spec.forProvider.%s cannot be sourced from a Secret as it is reported in status.atProvider", f.Name.LowerCamelComputed))))
+	for _, p := range r.topLevelRequiredParams {
+		if p.path == f.TransformedName {
+			p.valueFrom = jsonName
+		}
+	}
+}